@@ -0,0 +1,112 @@
+package coderunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"hr-helper/internal/service_models"
+)
+
+type PistonConfig struct {
+	URL string
+}
+
+// Piston runs code answers in a self-hosted Piston sandbox (https://github.com/engineer-man/piston).
+type Piston struct {
+	cfg    PistonConfig
+	client *http.Client
+}
+
+func NewPiston(cfg PistonConfig) *Piston {
+	return &Piston{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+type pistonFile struct {
+	Content string `json:"content"`
+}
+
+type pistonRequest struct {
+	Language string       `json:"language"`
+	Version  string       `json:"version"`
+	Files    []pistonFile `json:"files"`
+	Stdin    string       `json:"stdin"`
+}
+
+type pistonStage struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+	// Code is null when the process is killed by a signal, e.g. on timeout.
+	Code *int `json:"code"`
+}
+
+type pistonResponse struct {
+	Compile *pistonStage `json:"compile"`
+	Run     pistonStage  `json:"run"`
+}
+
+// Run executes the code with the latest installed version of the language. A failed compilation
+// is returned as the run result, not as an error.
+func (p *Piston) Run(ctx context.Context, language string, code string, stdin string) (service_models.CodeRunResult, error) {
+	body, err := json.Marshal(pistonRequest{
+		Language: language,
+		Version:  "*",
+		Files:    []pistonFile{{Content: code}},
+		Stdin:    stdin,
+	})
+	if err != nil {
+		return service_models.CodeRunResult{}, fmt.Errorf("can't marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL+"/api/v2/execute", bytes.NewReader(body))
+	if err != nil {
+		return service_models.CodeRunResult{}, fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return service_models.CodeRunResult{}, fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return service_models.CodeRunResult{}, fmt.Errorf("can't read body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return service_models.CodeRunResult{}, fmt.Errorf("non-2xx status: %s\nbody: %s\n", resp.Status, string(respBody))
+	}
+
+	var result pistonResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return service_models.CodeRunResult{}, fmt.Errorf("can't unmarshal result: %w", err)
+	}
+
+	stage := result.Run
+	if result.Compile != nil && exitCode(*result.Compile) != 0 {
+		stage = *result.Compile
+	}
+
+	return service_models.CodeRunResult{
+		Stdout:   stage.Stdout,
+		Stderr:   stage.Stderr,
+		ExitCode: exitCode(stage),
+	}, nil
+}
+
+func exitCode(stage pistonStage) int {
+	if stage.Code == nil {
+		return -1
+	}
+
+	return *stage.Code
+}
@@ -0,0 +1,89 @@
+package coderunner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hr-helper/internal/service_models"
+)
+
+func TestPistonRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     service_models.CodeRunResult
+		wantErr  bool
+	}{
+		{
+			name:     "run",
+			status:   http.StatusOK,
+			response: `{"run": {"stdout": "4\n", "stderr": "", "code": 0}}`,
+			want:     service_models.CodeRunResult{Stdout: "4\n", ExitCode: 0},
+		},
+		{
+			name:     "compiled and run",
+			status:   http.StatusOK,
+			response: `{"compile": {"stdout": "", "stderr": "", "code": 0}, "run": {"stdout": "ok", "stderr": "warn", "code": 1}}`,
+			want:     service_models.CodeRunResult{Stdout: "ok", Stderr: "warn", ExitCode: 1},
+		},
+		{
+			name:     "compilation failed",
+			status:   http.StatusOK,
+			response: `{"compile": {"stdout": "", "stderr": "undefined: x", "code": 2}, "run": {"stdout": "", "stderr": "", "code": null}}`,
+			want:     service_models.CodeRunResult{Stderr: "undefined: x", ExitCode: 2},
+		},
+		{
+			name:     "killed",
+			status:   http.StatusOK,
+			response: `{"run": {"stdout": "partial", "stderr": "", "code": null, "signal": "SIGKILL"}}`,
+			want:     service_models.CodeRunResult{Stdout: "partial", ExitCode: -1},
+		},
+		{
+			name:     "unknown runtime",
+			status:   http.StatusBadRequest,
+			response: `{"message": "cobol-* runtime is unknown"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid response",
+			status:   http.StatusOK,
+			response: `<html>`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pistonRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v2/execute" {
+					t.Errorf("request = %s %s, want POST /api/v2/execute", r.Method, r.URL.Path)
+				}
+				err := json.NewDecoder(r.Body).Decode(&got)
+				if err != nil {
+					t.Errorf("can't decode request: %v", err)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			res, err := NewPiston(PistonConfig{URL: srv.URL}).Run(context.Background(), "go", "package main", "2 2")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if res != tt.want {
+				t.Errorf("Run() = %+v, want %+v", res, tt.want)
+			}
+
+			if got.Language != "go" || got.Version != "*" || got.Stdin != "2 2" || len(got.Files) != 1 || got.Files[0].Content != "package main" {
+				t.Errorf("request body = %+v", got)
+			}
+		})
+	}
+}
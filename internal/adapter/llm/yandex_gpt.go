@@ -59,10 +59,48 @@ const (
 Твой ответ обязательно должен представлять собой валидный JSON с двумя полями: {\"feedback\": \"<общее_описание, string>\", \"score\": <оценка, int>}.
 Резюме кандидата: %s`
 
-	baseScoreQuestionPrompt = `Оцени ответ кандидата: дай ему оценку по 100-бальной шкале, 
+	baseScoreQuestionPrompt = `Оцени ответ кандидата на вопрос интервью на вакансию %s с требованиями: %s. Дай ответу оценку по 100-бальной шкале, 
 где 100 - означает отличный ответ, полностью соответствующий референсному ответу, 0 - крайне плохой ответ, не соответсвующий ни референсу, ни действительности. Подойди к оценке комплексно.
-Твой ответ обязательно должен представлять собой валидный JSON с одним полем: {\"score\": <оценка, int>}.
-Ответ кандидата: %s, референсный ответ: %s`
+Кратко (1-2 предложения) обоснуй оценку и перечисли ключевые моменты референсного ответа, которых нет в ответе кандидата.
+Твой ответ обязательно должен представлять собой валидный JSON с тремя полями: {\"score\": <оценка, int>, \"explanation\": \"<обоснование, string>\", \"missing_points\": [<упущенный момент, string>]}.
+Вопрос: %s
+Референсный ответ: %s
+Ответ кандидата: %s`
+
+	baseScoreRubricPrompt = `Оцени ответ кандидата на вопрос интервью на вакансию %s с требованиями: %s по списку критериев.
+Для каждого критерия укажи, насколько полно ответ его раскрывает: число от 0 (не раскрыт) до 1 (раскрыт полностью).
+Кратко (1-2 предложения) обоснуй оценку и перечисли критерии, которые ответ не раскрывает или раскрывает частично.
+Твой ответ обязательно должен представлять собой валидный JSON с тремя полями: {\"rubric_coverage\": [<раскрытие критерия, float, по одному числу на критерий в том же порядке>], \"explanation\": \"<обоснование, string>\", \"missing_points\": [<упущенный критерий, string>]}.
+Вопрос: %s
+Критерии:
+%s
+Ответ кандидата: %s`
+
+	codeQuestionPrompt = `Задача на программирование на языке %s, ответ кандидата - исходный код решения.
+Оцени корректность, читаемость и эффективность решения, код не запускай.
+%s`
+
+	baseExtractVacancyPrompt = `Извлеки из описания вакансии её название и ключевые требования к кандидату: навыки, технологии, опыт и личные качества.
+Требования должны быть короткими (1-5 слов каждое), без повторов, не более 15 штук.
+Твой ответ обязательно должен представлять собой валидный JSON с двумя полями: {\"title\": \"<название, string>\", \"key_requirements\": [<требование, string>]}.
+Описание вакансии: %s`
+
+	baseSummarizeCandidatePrompt = `Составь итоговую характеристику кандидата на вакансию %s по его резюме и ответам на вопросы интервью.
+Требования вакансии: %s.
+Выдели сильные стороны, слабые стороны и тревожные сигналы (несоответствия между резюме и ответами, уклончивые или списанные ответы и т.п.),
+каждый пункт - одно короткое предложение. Если тревожных сигналов нет, оставь список пустым.
+Дай рекомендацию: "hire" - приглашать на следующий этап, "no_hire" - не приглашать, и кратко обоснуй её.
+Твой ответ обязательно должен представлять собой валидный JSON: {\"strengths\": [<string>], \"weaknesses\": [<string>], \"red_flags\": [<string>], \"recommendation\": \"<hire|no_hire>\", \"rationale\": \"<обоснование, string>\"}.
+Резюме кандидата: %s
+Вопросы и ответы интервью:
+%s`
+
+	baseJudgeAIGeneratedPrompt = `Оцени, насколько вероятно, что ответ кандидата на вопрос интервью сгенерирован нейросетью (ChatGPT и т.п.), а не написан самим кандидатом.
+Обрати внимание на характерные признаки: шаблонная структура, списки и заголовки, обобщённые формулировки, избыточная полнота, отсутствие личного опыта.
+Дай вероятность от 0 (точно написан человеком) до 1 (точно сгенерирован) и кратко (1 предложение) обоснуй её.
+Твой ответ обязательно должен представлять собой валидный JSON с двумя полями: {\"likelihood\": <вероятность, float>, \"reason\": \"<обоснование, string>\"}.
+Вопрос: %s
+Ответ кандидата: %s`
 )
 
 type Yandex struct {
@@ -113,14 +151,30 @@ func (y *Yandex) ScoreResume(ctx context.Context, resumeText string, vacancy ent
 	return res, nil
 }
 
-func (y *Yandex) ScoreAnswer(ctx context.Context, answer string, reference string) (service_models.AnswerScoringResult, error) {
+// ScoreAnswer scores the answer against the question rubric if it has one, otherwise against the reference.
+// For rubric questions only per-point coverage is returned, the score is left to the caller.
+func (y *Yandex) ScoreAnswer(ctx context.Context, answer string, question entity.Question, vacancy entity.Vacancy) (service_models.AnswerScoringResult, error) {
 	var res service_models.AnswerScoringResult
 
+	requirements := strings.Join(vacancy.KeyRequirements, ",")
+	content := question.Content
+	if question.Type == entity.QuestionTypeCode {
+		content = fmt.Sprintf(codeQuestionPrompt, question.CodeLanguage, question.Content)
+	}
+	prompt := fmt.Sprintf(baseScoreQuestionPrompt, vacancy.Title, requirements, content, question.Reference, answer)
+	if len(question.Rubric) > 0 {
+		var rubric strings.Builder
+		for i, point := range question.Rubric {
+			fmt.Fprintf(&rubric, "%d. %s\n", i+1, point.Point)
+		}
+		prompt = fmt.Sprintf(baseScoreRubricPrompt, vacancy.Title, requirements, content, rubric.String(), answer)
+	}
+
 	err := retry.Do(
 		func() error {
 			resp, err := y.doRequest(ctx, []Message{
 				{Role: "system", Text: "Ты специалист, проводящий скрининг ответов кандидатов"},
-				{Role: "user", Text: fmt.Sprintf(baseScoreQuestionPrompt, answer, reference)},
+				{Role: "user", Text: prompt},
 			})
 			if err != nil {
 				return fmt.Errorf("can't do llm request: %w", err)
@@ -129,10 +183,14 @@ func (y *Yandex) ScoreAnswer(ctx context.Context, answer string, reference strin
 			resp = strings.Trim(resp, "`\n")
 			loggy.Infoln(resp)
 
+			res = service_models.AnswerScoringResult{}
 			err = json.Unmarshal([]byte(resp), &res)
 			if err != nil {
 				return fmt.Errorf("can't unmarshal result: %w", err)
 			}
+			if len(question.Rubric) > 0 && len(res.RubricCoverage) != len(question.Rubric) {
+				return fmt.Errorf("got coverage of %d rubric points, want %d", len(res.RubricCoverage), len(question.Rubric))
+			}
 
 			return nil
 		},
@@ -147,6 +205,118 @@ func (y *Yandex) ScoreAnswer(ctx context.Context, answer string, reference strin
 	return res, nil
 }
 
+func (y *Yandex) ExtractVacancy(ctx context.Context, description string) (service_models.VacancyDraft, error) {
+	var res service_models.VacancyDraft
+
+	err := retry.Do(
+		func() error {
+			resp, err := y.doRequest(ctx, []Message{
+				{Role: "system", Text: "Ты HR-специалист, составляющий вакансии"},
+				{Role: "user", Text: fmt.Sprintf(baseExtractVacancyPrompt, description)},
+			})
+			if err != nil {
+				return fmt.Errorf("can't do llm request: %w", err)
+			}
+
+			resp = strings.Trim(resp, "`\n")
+
+			err = json.Unmarshal([]byte(resp), &res)
+			if err != nil {
+				return fmt.Errorf("can't unmarshal result: %w", err)
+			}
+
+			return nil
+		},
+		retry.Attempts(5),
+		retry.DelayType(retry.FixedDelay),
+		retry.Delay(time.Second*1),
+	)
+	if err != nil {
+		return service_models.VacancyDraft{}, fmt.Errorf("can't extract vacancy: %w", err)
+	}
+
+	return res, nil
+}
+
+func (y *Yandex) SummarizeCandidate(ctx context.Context, resumeText string, vacancy entity.Vacancy, answers []entity.CandidateQuestionAnswer) (service_models.CandidateSummaryResult, error) {
+	var res service_models.CandidateSummaryResult
+
+	var interview strings.Builder
+	for i, qa := range answers {
+		fmt.Fprintf(&interview, "%d. Вопрос: %s\nРеференсный ответ: %s\nОтвет кандидата: %s\nОценка ответа: %d из 100\n\n",
+			i+1, qa.Question.Content, qa.Question.Reference, qa.Answer.Content, qa.Answer.Score)
+	}
+
+	msgs := []Message{
+		{Role: "system", Text: "Ты HR-специалист, подводящий итоги отбора кандидата"},
+		{Role: "user", Text: fmt.Sprintf(baseSummarizeCandidatePrompt, vacancy.Title, strings.Join(vacancy.KeyRequirements, ","), resumeText, interview.String())},
+	}
+
+	err := retry.Do(
+		func() error {
+			resp, err := y.doRequest(ctx, msgs)
+			if err != nil {
+				return fmt.Errorf("can't do llm request: %w", err)
+			}
+
+			resp = strings.Trim(resp, "`\n")
+
+			err = json.Unmarshal([]byte(resp), &res)
+			if err != nil {
+				return fmt.Errorf("can't unmarshal result: %w", err)
+			}
+
+			return nil
+		},
+		retry.Attempts(5),
+		retry.DelayType(retry.FixedDelay),
+		retry.Delay(time.Second*1),
+	)
+	if err != nil {
+		return service_models.CandidateSummaryResult{}, fmt.Errorf("can't summarize candidate: %w", err)
+	}
+
+	return res, nil
+}
+
+func (y *Yandex) JudgeAIGenerated(ctx context.Context, question string, answer string) (service_models.AIGeneratedJudgement, error) {
+	var res service_models.AIGeneratedJudgement
+
+	msgs := []Message{
+		{Role: "system", Text: "Ты специалист, проверяющий ответы кандидатов на самостоятельность"},
+		{Role: "user", Text: fmt.Sprintf(baseJudgeAIGeneratedPrompt, question, answer)},
+	}
+
+	err := retry.Do(
+		func() error {
+			resp, err := y.doRequest(ctx, msgs)
+			if err != nil {
+				return fmt.Errorf("can't do llm request: %w", err)
+			}
+
+			resp = strings.Trim(resp, "`\n")
+
+			err = json.Unmarshal([]byte(resp), &res)
+			if err != nil {
+				return fmt.Errorf("can't unmarshal result: %w", err)
+			}
+			if res.Likelihood < 0 || res.Likelihood > 1 {
+				return fmt.Errorf("likelihood %v is out of [0, 1]", res.Likelihood)
+			}
+
+			return nil
+		},
+		retry.Attempts(5),
+		retry.DelayType(retry.FixedDelay),
+		retry.Delay(time.Second*1),
+	)
+	if err != nil {
+		return service_models.AIGeneratedJudgement{}, fmt.Errorf("can't judge answer: %w", err)
+	}
+
+	return res, nil
+}
+
 func (y *Yandex) doRequest(ctx context.Context, messages []Message) (string, error) {
 	modelURI := fmt.Sprintf("gpt://%s/yandexgpt/latest", y.cfg.FolderID)
 
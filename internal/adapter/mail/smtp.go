@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// lineLength is the maximum length of base64 lines, see RFC 2045.
+const lineLength = 76
+
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{
+		cfg: cfg,
+	}
+}
+
+// SendHTML sends an HTML email to a single recipient, so that recipients don't see each other.
+// Authentication is skipped when no username is configured, which is how local SMTP stand-ins like Mailpit are usually run.
+func (m *SMTPMailer) SendHTML(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(m.cfg.Addr)
+		if err != nil {
+			return fmt.Errorf("invalid smtp addr: %w", err)
+		}
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, host)
+	}
+
+	err := smtp.SendMail(m.cfg.Addr, auth, m.cfg.From, []string{to}, m.buildMessage(to, subject, body))
+	if err != nil {
+		return fmt.Errorf("can't send mail: %w", err)
+	}
+
+	return nil
+}
+
+func (m *SMTPMailer) buildMessage(to string, subject string, body string) []byte {
+	var msg bytes.Buffer
+
+	msg.WriteString("From: " + m.cfg.From + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n")
+	msg.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > lineLength {
+		msg.WriteString(encoded[:lineLength] + "\r\n")
+		encoded = encoded[lineLength:]
+	}
+	msg.WriteString(encoded + "\r\n")
+
+	return msg.Bytes()
+}
@@ -0,0 +1,148 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/base64"
+	"mime"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpMessage is what the stand-in server received in a single session.
+type smtpMessage struct {
+	from string
+	rcpt []string
+	data string
+}
+
+// serveSMTP accepts a single session of a minimal SMTP server without extensions.
+func serveSMTP(t *testing.T) (addr string, received <-chan smtpMessage) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	ch := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				_ = tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				msg.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				_ = tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				msg.rcpt = append(msg.rcpt, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				ch <- msg
+				return
+			default:
+				_ = tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return l.Addr().String(), ch
+}
+
+func TestSMTPMailerSendHTML(t *testing.T) {
+	addr, received := serveSMTP(t)
+	m := NewSMTPMailer(SMTPConfig{Addr: addr, From: "hr@example.com"})
+
+	body := "<p>" + strings.Repeat("Сильный кандидат ", 10) + "</p>"
+	err := m.SendHTML("recruiter@example.com", "Сильный кандидат: Go (95)", body)
+	if err != nil {
+		t.Fatalf("SendHTML() error = %v", err)
+	}
+
+	msg := <-received
+	if msg.from != "hr@example.com" {
+		t.Errorf("MAIL FROM = %q, want %q", msg.from, "hr@example.com")
+	}
+	if len(msg.rcpt) != 1 || msg.rcpt[0] != "recruiter@example.com" {
+		t.Errorf("RCPT TO = %q, want a single recipient", msg.rcpt)
+	}
+
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(msg.data)))
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("can't read headers: %v", err)
+	}
+	if got := header.Get("To"); got != "recruiter@example.com" {
+		t.Errorf("To = %q, want %q", got, "recruiter@example.com")
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+	if err != nil || subject != "Сильный кандидат: Go (95)" {
+		t.Errorf("Subject = %q (%v), want the decoded subject", subject, err)
+	}
+
+	var encoded strings.Builder
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			break
+		}
+		if len(line) > lineLength {
+			t.Errorf("body line is %d characters long, want at most %d", len(line), lineLength)
+		}
+		encoded.WriteString(line)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.String())
+	if err != nil {
+		t.Fatalf("can't decode body: %v", err)
+	}
+	if string(decoded) != body {
+		t.Errorf("body = %q, want %q", decoded, body)
+	}
+}
+
+func TestSMTPMailerSendHTMLRejected(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = textproto.NewConn(conn).PrintfLine("554 no service")
+	}()
+
+	m := NewSMTPMailer(SMTPConfig{Addr: l.Addr().String(), From: "hr@example.com"})
+	err = m.SendHTML("recruiter@example.com", "subject", "body")
+	if err == nil {
+		t.Fatal("SendHTML() error = nil, want the relay rejection")
+	}
+}
@@ -31,16 +31,19 @@ func (s *ResumeStorage) Download(ctx context.Context, candidateID int64, vacancy
 
 	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
 	if err != nil {
-		var minioErr minio.ErrorResponse
-		if errors.As(err, &minioErr) {
-			if minioErr.Code == minio.NoSuchKey {
-				return nil, inerrors.ErrNotFound
-			}
-		}
 		return nil, fmt.Errorf("can't download object: %w", err)
 	}
 	defer object.Close()
 
+	// GetObject is lazy, a missing object is only reported by the first request
+	_, err = object.Stat()
+	if isNoSuchKey(err) {
+		return nil, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't stat object: %w", err)
+	}
+
 	buf := new(bytes.Buffer)
 	_, err = io.Copy(buf, object)
 	if err != nil {
@@ -60,3 +63,67 @@ func (s *ResumeStorage) GetPresignedURL(ctx context.Context, candidateID int64,
 
 	return presignedURL.String(), nil
 }
+
+// Copy copies the resume to another candidate and keeps the source, so it's safe to repeat.
+// A resume the other candidate already has isn't overwritten.
+func (s *ResumeStorage) Copy(ctx context.Context, fromCandidateID int64, toCandidateID int64, vacancyID uuid.UUID) error {
+	srcKey := fmt.Sprintf("%d/%s", fromCandidateID, vacancyID)
+	dstKey := fmt.Sprintf("%d/%s", toCandidateID, vacancyID)
+
+	return copyIfAbsent(ctx, s.client, s.bucket, srcKey, dstKey)
+}
+
+// DeleteAll removes resumes uploaded by the candidate for every vacancy.
+func (s *ResumeStorage) DeleteAll(ctx context.Context, candidateID int64) error {
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    fmt.Sprintf("%d/", candidateID),
+		Recursive: true,
+	})
+
+	for removeErr := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if removeErr.Err != nil {
+			return fmt.Errorf("can't remove object %s: %w", removeErr.ObjectName, removeErr.Err)
+		}
+	}
+
+	return nil
+}
+
+func copyIfAbsent(ctx context.Context, client *minio.Client, bucket string, srcKey string, dstKey string) error {
+	_, err := client.StatObject(ctx, bucket, dstKey, minio.StatObjectOptions{})
+	if err == nil {
+		return nil
+	}
+	if !isNoSuchKey(err) {
+		return fmt.Errorf("can't stat object: %w", err)
+	}
+
+	_, err = client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: bucket, Object: srcKey},
+	)
+	if isNoSuchKey(err) {
+		return inerrors.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("can't copy object: %w", err)
+	}
+
+	return nil
+}
+
+func isNoSuchKey(err error) bool {
+	var minioErr minio.ErrorResponse
+	return errors.As(err, &minioErr) && minioErr.Code == minio.NoSuchKey
+}
+
+func (s *ResumeStorage) Delete(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	key := fmt.Sprintf("%d/%s", candidateID, vacancyID)
+
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("can't remove object: %w", err)
+	}
+
+	return nil
+}
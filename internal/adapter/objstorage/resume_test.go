@@ -0,0 +1,84 @@
+package objstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"hr-helper/internal/inerrors"
+)
+
+// newTestStorage serves objects from the map as an S3 bucket named "resumes".
+func newTestStorage(t *testing.T, objects map[string]string) *ResumeStorage {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/resumes/")
+		body, ok := objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method != http.MethodHead {
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			}
+			return
+		}
+
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Content-Type", "application/pdf")
+		http.ServeContent(w, r, key, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), strings.NewReader(body))
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewResumeStorage("resumes", client)
+}
+
+func TestResumeStorageDownload(t *testing.T) {
+	vacancyID := uuid.MustParse("0b9f6a4e-3d6c-4a53-9b0c-1f2d3e4f5a6b")
+	storage := newTestStorage(t, map[string]string{
+		"1/" + vacancyID.String(): "resume",
+	})
+
+	tests := []struct {
+		name        string
+		candidateID int64
+		want        string
+		wantErr     error
+	}{
+		{name: "existing", candidateID: 1, want: "resume"},
+		{name: "missing", candidateID: 2, wantErr: inerrors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Download(context.Background(), tt.candidateID, vacancyID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Download() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Download() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
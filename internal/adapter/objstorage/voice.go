@@ -0,0 +1,72 @@
+package objstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+)
+
+// VoiceStorage keeps voice answers in the resume bucket under the candidate prefix,
+// so ResumeStorage.DeleteAll erases them together with the resumes.
+type VoiceStorage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewVoiceStorage(bucket string, client *minio.Client) *VoiceStorage {
+	return &VoiceStorage{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (s *VoiceStorage) Upload(ctx context.Context, candidateID int64, vacancyID uuid.UUID, questionID int64, data []byte, contentType string) error {
+	key := fmt.Sprintf("%d/voice/%s/%d", candidateID, vacancyID, questionID)
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("can't put object: %w", err)
+	}
+
+	return nil
+}
+
+// CopyAll copies every voice answer of the candidate to another candidate, keeping the ones the other candidate has.
+func (s *VoiceStorage) CopyAll(ctx context.Context, fromCandidateID int64, toCandidateID int64) error {
+	prefix := fmt.Sprintf("%d/voice/", fromCandidateID)
+
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return fmt.Errorf("can't list objects: %w", object.Err)
+		}
+
+		dstKey := fmt.Sprintf("%d/voice/%s", toCandidateID, object.Key[len(prefix):])
+		err := copyIfAbsent(ctx, s.client, s.bucket, object.Key, dstKey)
+		if err != nil {
+			return fmt.Errorf("can't copy object %s: %w", object.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes voice answers given by the candidate for the vacancy.
+func (s *VoiceStorage) Delete(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    fmt.Sprintf("%d/voice/%s/", candidateID, vacancyID),
+		Recursive: true,
+	})
+
+	for removeErr := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if removeErr.Err != nil {
+			return fmt.Errorf("can't remove object %s: %w", removeErr.ObjectName, removeErr.Err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/service_models"
+)
+
+type AnalyticsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(db *pgxpool.Pool) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db: db,
+	}
+}
+
+// GetApplicationTimelines returns applications to the vacancy screened within the filter range.
+func (r *AnalyticsRepository) GetApplicationTimelines(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.ApplicationTimeline, error) {
+	const q = `
+		SELECT
+    rs.candidate_id,
+    m.status,
+    rs.score,
+    m.interview_score,
+    rs.created_at,
+    a.first_answer_at,
+    a.last_answer_at,
+    s.booked_at
+FROM resume_screening rs
+         JOIN candidate_vacancy_meta m ON m.candidate_id = rs.candidate_id AND m.vacancy_id = rs.vacancy_id
+         LEFT JOIN LATERAL (
+             SELECT min(a.created_at) AS first_answer_at,
+                    max(a.created_at) AS last_answer_at
+               FROM answer a
+               JOIN question q ON q.id = a.question_id
+              WHERE a.candidate_id = rs.candidate_id
+                AND q.vacancy_id = rs.vacancy_id
+         ) a ON true
+         LEFT JOIN interview_slot s ON s.candidate_id = rs.candidate_id AND s.booked_vacancy_id = rs.vacancy_id
+WHERE rs.vacancy_id = $1
+  AND rs.created_at >= $2
+  AND rs.created_at < $3`
+
+	rows, err := r.db.Query(ctx, q, filter.VacancyID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var timelines []service_models.ApplicationTimeline
+	for rows.Next() {
+		var t service_models.ApplicationTimeline
+		err = rows.Scan(
+			&t.CandidateID,
+			&t.Status,
+			&t.ResumeScore,
+			&t.InterviewScore,
+			&t.ScreenedAt,
+			&t.FirstAnswerAt,
+			&t.LastAnswerAt,
+			&t.BookedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		timelines = append(timelines, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return timelines, nil
+}
+
+// GetQuestionReach counts, for every vacancy question, the candidates screened within the filter range who answered it.
+func (r *AnalyticsRepository) GetQuestionReach(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.QuestionReach, error) {
+	const q = `
+		SELECT
+    q.id,
+    q.position,
+    q.content,
+    count(DISTINCT rs.id)
+FROM question q
+         LEFT JOIN answer a ON a.question_id = q.id
+         LEFT JOIN resume_screening rs ON rs.candidate_id = a.candidate_id
+                                      AND rs.vacancy_id = q.vacancy_id
+                                      AND rs.created_at >= $2
+                                      AND rs.created_at < $3
+WHERE q.vacancy_id = $1
+  AND q.candidate_id IS NULL
+GROUP BY q.id, q.round, q.position, q.content
+ORDER BY q.round, q.position`
+
+	rows, err := r.db.Query(ctx, q, filter.VacancyID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var reaches []service_models.QuestionReach
+	for rows.Next() {
+		var reach service_models.QuestionReach
+		err = rows.Scan(
+			&reach.QuestionID,
+			&reach.Position,
+			&reach.Content,
+			&reach.Answered,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		reaches = append(reaches, reach)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return reaches, nil
+}
+
+// GetAnswerOutcomes returns answers to the vacancy questions given within the filter range.
+func (r *AnalyticsRepository) GetAnswerOutcomes(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.AnswerOutcome, error) {
+	const q = `
+		SELECT
+    q.id,
+    q.vacancy_id,
+    q.content,
+    q.reference,
+    q.time_limit,
+    q.position,
+
+    a.score,
+    a.time_taken,
+    COALESCE(m.status, '')
+FROM question q
+         JOIN answer a ON a.question_id = q.id
+         LEFT JOIN candidate_vacancy_meta m ON m.candidate_id = a.candidate_id AND m.vacancy_id = q.vacancy_id
+WHERE q.vacancy_id = $1
+  AND a.created_at >= $2
+  AND a.created_at < $3
+ORDER BY q.position, q.id`
+
+	rows, err := r.db.Query(ctx, q, filter.VacancyID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	var outcomes []service_models.AnswerOutcome
+	for rows.Next() {
+		var outcome service_models.AnswerOutcome
+		err = rows.Scan(
+			&outcome.Question.ID,
+			&outcome.Question.VacancyID,
+			&outcome.Question.Content,
+			&outcome.Question.Reference,
+			&outcome.Question.TimeLimit,
+			&outcome.Question.Position,
+
+			&outcome.Score,
+			&outcome.TimeTaken,
+			&outcome.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return outcomes, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry entity.AuditEntry) error {
+	const q = `
+		INSERT INTO audit_log (
+actor,
+action,
+target_type,
+target_id,
+before,
+after,
+status_code,
+method,
+path,
+remote_addr,
+user_agent,
+request_id
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.db.Exec(ctx, q,
+		entry.Actor,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Before,
+		entry.After,
+		entry.StatusCode,
+		entry.Method,
+		entry.Path,
+		entry.RemoteAddr,
+		entry.UserAgent,
+		entry.RequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuditRepository) Find(ctx context.Context, filter service_models.AuditFilter) ([]entity.AuditEntry, error) {
+	qb := psql.Select(
+		"id",
+		"actor",
+		"action",
+		"target_type",
+		"target_id",
+		"before",
+		"after",
+		"status_code",
+		"method",
+		"path",
+		"remote_addr",
+		"user_agent",
+		"request_id",
+		"created_at",
+	).
+		From("audit_log").
+		OrderBy("created_at DESC", "id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset)
+
+	if filter.Actor != "" {
+		qb = qb.Where(sq.Eq{"actor": filter.Actor})
+	}
+	if filter.Action != "" {
+		qb = qb.Where(sq.Eq{"action": filter.Action})
+	}
+	if filter.TargetType != "" {
+		qb = qb.Where(sq.Eq{"target_type": filter.TargetType})
+	}
+	if filter.TargetID != "" {
+		qb = qb.Where(sq.Eq{"target_id": filter.TargetID})
+	}
+	if filter.From != nil {
+		qb = qb.Where(sq.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		qb = qb.Where(sq.Lt{"created_at": *filter.To})
+	}
+
+	q, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AuditEntry])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return entries, nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"hr-helper/internal/dto_models"
@@ -32,74 +35,1024 @@ telegram_id,
 telegram_username,
 full_name,
 phone,
-city
+city,
+email,
+preferred_contact
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	 RETURNING id`
+
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	err = tx.QueryRow(ctx, q,
+		nullTelegramID(candidate.TelegramID),
+		candidate.TelegramUsername,
+		candidate.FullName,
+		candidate.Phone,
+		candidate.City,
+		candidate.Email,
+		candidate.PreferredContact,
+	).Scan(&id)
+	if isUniqueViolation(err) {
+		return 0, candidateUniqueViolation(err, candidate.TelegramID, candidate.Phone)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = insertConsents(ctx, tx, id, candidate.Consents)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *CandidateRepository) Upsert(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error) {
+	const q = `
+		INSERT INTO candidate (
+telegram_id,
+telegram_username,
+full_name,
+phone,
+city,
+email,
+preferred_contact
 )
-		VALUES ($1, $2, $3, $4, $5)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+   ON CONFLICT (telegram_id) WHERE telegram_id IS NOT NULL
+	 DO UPDATE
+		   SET
+telegram_username = COALESCE(NULLIF(EXCLUDED.telegram_username, ''), candidate.telegram_username),
+full_name         = COALESCE(NULLIF(EXCLUDED.full_name, ''), candidate.full_name),
+phone             = COALESCE(NULLIF(EXCLUDED.phone, ''), candidate.phone),
+city              = COALESCE(NULLIF(EXCLUDED.city, ''), candidate.city),
+email             = COALESCE(NULLIF(EXCLUDED.email, ''), candidate.email),
+preferred_contact = COALESCE(NULLIF(EXCLUDED.preferred_contact, ''), candidate.preferred_contact)
 	 RETURNING id`
 
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	var id int64
-	err := r.db.QueryRow(ctx, q,
-		candidate.TelegramID,
+	err = tx.QueryRow(ctx, q,
+		nullTelegramID(candidate.TelegramID),
 		candidate.TelegramUsername,
 		candidate.FullName,
 		candidate.Phone,
 		candidate.City,
+		candidate.Email,
+		candidate.PreferredContact,
+	).Scan(&id)
+	if isUniqueViolation(err) {
+		return 0, candidateUniqueViolation(err, candidate.TelegramID, candidate.Phone)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = insertConsents(ctx, tx, id, candidate.Consents)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *CandidateRepository) CreateConsents(ctx context.Context, candidateID int64, consents []dto_models.CreateConsentRequest) error {
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = insertConsents(ctx, tx, candidateID, consents)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func insertConsents(ctx context.Context, tx pgx.Tx, candidateID int64, consents []dto_models.CreateConsentRequest) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	insertBuilder := psql.Insert("candidate_consent").
+		Columns(
+			"candidate_id",
+			"type",
+			"policy_version",
+			"channel",
+		)
+	for _, consent := range consents {
+		insertBuilder = insertBuilder.Values(candidateID, consent.Type, consent.PolicyVersion, consent.Channel)
+	}
+
+	q, args, err := insertBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, q, args...)
+	if isForeignKeyViolation(err) {
+		return inerrors.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) GetConsents(ctx context.Context, candidateID int64) ([]entity.Consent, error) {
+	const q = `
+		SELECT
+id,
+candidate_id,
+type,
+policy_version,
+channel,
+granted_at,
+withdrawn_at
+		  FROM candidate_consent
+		 WHERE candidate_id = $1
+	  ORDER BY granted_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, q, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	consents, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Consent])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return consents, nil
+}
+
+func (r *CandidateRepository) WithdrawConsent(ctx context.Context, candidateID int64, consentType string) error {
+	const q = `
+		UPDATE candidate_consent SET
+withdrawn_at = now()
+		 WHERE candidate_id = $1
+		   AND type = $2
+		   AND withdrawn_at IS NULL`
+
+	tag, err := executor(ctx, r.db).Exec(ctx, q, candidateID, consentType)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return inerrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) GetAll(ctx context.Context) ([]entity.Candidate, error) {
+	const q = `
+		SELECT
+id,
+COALESCE(telegram_id, 0) AS telegram_id,
+COALESCE(telegram_username, '') AS telegram_username,
+COALESCE(full_name, '') AS full_name,
+COALESCE(phone, '') AS phone,
+COALESCE(city, '') AS city,
+email,
+preferred_contact,
+created_at
+		  FROM candidate
+	  ORDER BY id`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	candidates, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Candidate])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// GetMergedVacancyIDs returns vacancies of the source candidate that Merge moves to the target one.
+func (r *CandidateRepository) GetMergedVacancyIDs(ctx context.Context, targetID int64, sourceID int64) ([]uuid.UUID, error) {
+	const q = `
+		SELECT vacancy_id
+		  FROM candidate_vacancy_meta
+		 WHERE candidate_id = $2
+		   AND vacancy_id NOT IN (SELECT vacancy_id FROM candidate_vacancy_meta WHERE candidate_id = $1)`
+
+	rows, err := executor(ctx, r.db).Query(ctx, q, targetID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	vacancyIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return vacancyIDs, nil
+}
+
+// GetFormerPhones returns phones the phone migration cleared as duplicates or invalid ones, by candidate id.
+func (r *CandidateRepository) GetFormerPhones(ctx context.Context) (map[int64]string, error) {
+	const q = `
+		SELECT DISTINCT ON (candidate_id) candidate_id, old_value
+		  FROM candidate_change
+		 WHERE field = 'phone'
+		   AND source = 'migration'
+		   AND new_value = ''
+	  ORDER BY candidate_id, id DESC`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+	defer rows.Close()
+
+	phones := make(map[int64]string)
+	for rows.Next() {
+		var candidateID int64
+		var phone string
+		err = rows.Scan(&candidateID, &phone)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+		phones[candidateID] = phone
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return phones, nil
+}
+
+// GetMergedFiles returns files of candidates collapsed by the deduplication migration that are still to be moved.
+func (r *CandidateRepository) GetMergedFiles(ctx context.Context) ([]service_models.MergedFiles, error) {
+	const q = `
+		SELECT source_id, target_id, vacancy_ids
+		  FROM candidate_merged_files
+	  ORDER BY source_id`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	files, err := pgx.CollectRows(rows, pgx.RowToStructByName[service_models.MergedFiles])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return files, nil
+}
+
+func (r *CandidateRepository) DeleteMergedFiles(ctx context.Context, sourceID int64) error {
+	const q = `DELETE FROM candidate_merged_files
+                     WHERE source_id = $1`
+
+	_, err := r.db.Exec(ctx, q, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// Merge re-points screenings, meta and answers of the source candidate to the target one and deletes the source.
+// Rows the target already has for the same vacancy (or question) are dropped together with the source.
+func (r *CandidateRepository) Merge(ctx context.Context, targetID int64, sourceID int64) error {
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = `
+		SELECT id
+		  FROM candidate
+		 WHERE id = ANY($1)
+		   FOR UPDATE`
+
+	rows, err := tx.Query(ctx, lockQuery, []int64{targetID, sourceID})
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	lockedIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return fmt.Errorf("can't collect rows: %w", err)
+	}
+	if len(lockedIDs) != 2 {
+		return inerrors.ErrNotFound
+	}
+
+	const moveMetaQuery = `
+		UPDATE candidate_vacancy_meta
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND vacancy_id NOT IN (SELECT vacancy_id FROM candidate_vacancy_meta WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveMetaQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const moveResumeScreeningQuery = `
+		UPDATE resume_screening
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND vacancy_id NOT IN (SELECT vacancy_id FROM resume_screening WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveResumeScreeningQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const moveRoundResultsQuery = `
+		UPDATE interview_round_result
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND vacancy_id NOT IN (SELECT vacancy_id FROM interview_round_result WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveRoundResultsQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const moveSummaryQuery = `
+		UPDATE candidate_summary
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND vacancy_id NOT IN (SELECT vacancy_id FROM candidate_summary WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveSummaryQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const moveAnswersQuery = `
+		UPDATE answer
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND question_id NOT IN (SELECT question_id FROM answer WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveAnswersQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const moveQuestionDrawsQuery = `
+		UPDATE bank_question_draw
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND (vacancy_id, round) NOT IN (SELECT vacancy_id, round FROM bank_question_draw WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveQuestionDrawsQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	// drawn questions follow their draws, the rest are dropped with the source
+	const moveDrawnQuestionsQuery = `
+		UPDATE question
+		   SET candidate_id = $1
+		 WHERE candidate_id = $2
+		   AND (vacancy_id, round) IN (SELECT vacancy_id, round FROM bank_question_draw WHERE candidate_id = $1)`
+
+	_, err = tx.Exec(ctx, moveDrawnQuestionsQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	// the history, consents, personal data requests and notifications of the source now belong to the target
+	for _, table := range []string{"candidate_consent", "candidate_change", "personal_data_request", "telegram_notification"} {
+		_, err = tx.Exec(ctx, fmt.Sprintf(`UPDATE %s SET candidate_id = $1 WHERE candidate_id = $2`, table), targetID, sourceID)
+		if err != nil {
+			return fmt.Errorf("can't exec query: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE candidate_merged_files SET target_id = $1 WHERE target_id = $2`, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	// the same locks BookSlot takes, in the id order to avoid deadlocks
+	for _, id := range []int64{min(targetID, sourceID), max(targetID, sourceID)} {
+		_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('interview_slot_candidate:' || $1::text))`, id)
+		if err != nil {
+			return fmt.Errorf("can't lock candidate bookings: %w", err)
+		}
+	}
+
+	// a slot for a vacancy the target has already booked or overlapping a target's booking is released
+	const moveSlotsQuery = `
+		UPDATE interview_slot s
+		   SET candidate_id = $1
+		 WHERE s.candidate_id = $2
+		   AND NOT EXISTS (
+		           SELECT 1
+		             FROM interview_slot b
+		            WHERE b.candidate_id = $1
+		              AND (b.booked_vacancy_id = s.booked_vacancy_id OR (b.starts_at < s.ends_at AND b.ends_at > s.starts_at))
+		       )`
+
+	_, err = tx.Exec(ctx, moveSlotsQuery, targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const releaseSlotsQuery = `
+		UPDATE interview_slot
+		   SET candidate_id      = NULL,
+		       booked_vacancy_id = NULL,
+		       booked_at         = NULL
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, releaseSlotsQuery, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteSourceQuery = `
+		DELETE FROM candidate
+		 WHERE id = $1
+	 RETURNING telegram_id, telegram_username, full_name, phone, city, email, preferred_contact`
+
+	var source struct {
+		TelegramID       *int64
+		TelegramUsername *string
+		FullName         *string
+		Phone            *string
+		City             *string
+		Email            string
+		PreferredContact string
+	}
+	err = tx.QueryRow(ctx, deleteSourceQuery, sourceID).Scan(
+		&source.TelegramID,
+		&source.TelegramUsername,
+		&source.FullName,
+		&source.Phone,
+		&source.City,
+		&source.Email,
+		&source.PreferredContact,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const fillTargetQuery = `
+		UPDATE candidate SET
+telegram_id       = COALESCE(telegram_id, $2),
+telegram_username = COALESCE(NULLIF(telegram_username, ''), $3),
+full_name         = COALESCE(NULLIF(full_name, ''), $4),
+phone             = COALESCE(NULLIF(phone, ''), $5),
+city              = COALESCE(NULLIF(city, ''), $6),
+email             = COALESCE(NULLIF(email, ''), $7),
+preferred_contact = COALESCE(NULLIF(preferred_contact, ''), $8)
+		 WHERE id = $1`
+
+	_, err = tx.Exec(ctx, fillTargetQuery,
+		targetID,
+		source.TelegramID,
+		source.TelegramUsername,
+		source.FullName,
+		source.Phone,
+		source.City,
+		source.Email,
+		source.PreferredContact,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) Delete(ctx context.Context, candidateID int64) error {
+	const q = `
+		DELETE FROM candidate
+	     WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// Anonymize wipes personal data of the candidate, but keeps scores and statuses for statistics.
+func (r *CandidateRepository) Anonymize(ctx context.Context, candidateID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const anonymizeCandidateQuery = `
+		UPDATE candidate SET
+telegram_id       = NULL,
+telegram_username = '',
+full_name         = '',
+phone             = '',
+city              = '',
+email             = '',
+preferred_contact = '',
+erased_at         = now()
+		 WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, anonymizeCandidateQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return inerrors.ErrNotFound
+	}
+
+	const anonymizeResumeScreeningQuery = `
+		UPDATE resume_screening SET
+feedback = ''
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, anonymizeResumeScreeningQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteSummariesQuery = `
+		DELETE FROM candidate_summary
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, deleteSummariesQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteSignalsQuery = `
+		DELETE FROM answer_signal
+		 USING answer
+		 WHERE answer_signal.answer_id = answer.id
+		   AND answer.candidate_id = $1`
+
+	_, err = tx.Exec(ctx, deleteSignalsQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const anonymizeAnswersQuery = `
+		UPDATE answer SET
+content        = '',
+explanation    = '',
+missing_points = '{}'
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, anonymizeAnswersQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteChangesQuery = `
+		DELETE FROM candidate_change
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, deleteChangesQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	// files of candidates collapsed into this one are deleted instead of being copied to it
+	const forgetMergedFilesQuery = `
+		UPDATE candidate_merged_files
+		   SET target_id = NULL
+		 WHERE target_id = $1`
+
+	_, err = tx.Exec(ctx, forgetMergedFilesQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	// the rows stay to keep notifications from being sent twice
+	const anonymizeNotificationsQuery = `
+		UPDATE telegram_notification SET
+telegram_id = NULL,
+text        = ''
+		 WHERE candidate_id = $1`
+
+	_, err = tx.Exec(ctx, anonymizeNotificationsQuery, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) FindApplicationsForRetention(ctx context.Context, filter service_models.RetentionFilter) ([]entity.Meta, error) {
+	qb := psql.Select(
+		"candidate_id",
+		"vacancy_id",
+		"interview_score",
+		"status",
+		"current_round",
+		"updated_at",
+		"is_archived",
+	).
+		From("candidate_vacancy_meta").
+		Where(sq.Eq{"anonymized_at": nil}).
+		Where(sq.Lt{"updated_at": filter.UpdatedBefore}).
+		OrderBy("updated_at")
+
+	if filter.Status != "" {
+		qb = qb.Where(sq.Eq{"status": filter.Status})
+	}
+	if filter.IsArchived != nil {
+		qb = qb.Where(sq.Eq{"is_archived": *filter.IsArchived})
+	}
+	if filter.SkipResumeDeleted {
+		qb = qb.Where(sq.Eq{"resume_deleted_at": nil})
+	}
+
+	q, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	metas, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Meta])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return metas, nil
+}
+
+func (r *CandidateRepository) MarkResumeDeleted(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	const q = `
+		UPDATE candidate_vacancy_meta SET
+resume_deleted_at = now()
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err := r.db.Exec(ctx, q, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// AnonymizeApplication wipes resume feedback and answers the candidate gave for the vacancy, keeping scores.
+func (r *CandidateRepository) AnonymizeApplication(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const anonymizeResumeScreeningQuery = `
+		UPDATE resume_screening SET
+feedback = ''
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, anonymizeResumeScreeningQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteSummaryQuery = `
+		DELETE FROM candidate_summary
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, deleteSummaryQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const deleteSignalsQuery = `
+		DELETE FROM answer_signal
+		 USING answer, question
+		 WHERE answer_signal.answer_id = answer.id
+		   AND answer.question_id = question.id
+		   AND answer.candidate_id = $1
+		   AND question.vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, deleteSignalsQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const anonymizeAnswersQuery = `
+		UPDATE answer SET
+content        = '',
+explanation    = '',
+missing_points = '{}'
+		  FROM question
+		 WHERE answer.question_id = question.id
+		   AND answer.candidate_id = $1
+		   AND question.vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, anonymizeAnswersQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const anonymizeNotificationsQuery = `
+		UPDATE telegram_notification SET
+text = ''
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, anonymizeNotificationsQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	const markMetaQuery = `
+		UPDATE candidate_vacancy_meta SET
+resume_deleted_at = COALESCE(resume_deleted_at, now()),
+anonymized_at     = now()
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, markMetaQuery, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) CreatePersonalDataRequest(ctx context.Context, request entity.PersonalDataRequest) (int64, error) {
+	const q = `
+		INSERT INTO personal_data_request (
+candidate_id,
+type,
+reason
+)
+		VALUES ($1, $2, $3)
+	 RETURNING id`
+
+	var id int64
+	err := executor(ctx, r.db).QueryRow(ctx, q,
+		request.CandidateID,
+		request.Type,
+		request.Reason,
 	).Scan(&id)
 	if err != nil {
-		return 0, fmt.Errorf("can't exec query: %w", err)
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *CandidateRepository) CompletePersonalDataRequest(ctx context.Context, requestID int64) error {
+	const q = `
+		UPDATE personal_data_request SET
+completed_at = now()
+		 WHERE id = $1`
+
+	_, err := executor(ctx, r.db).Exec(ctx, q, requestID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) GetPersonalDataRequests(ctx context.Context, candidateID int64) ([]entity.PersonalDataRequest, error) {
+	const q = `
+		SELECT
+id,
+candidate_id,
+type,
+reason,
+created_at,
+completed_at
+		  FROM personal_data_request
+		 WHERE candidate_id = $1
+	  ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, q, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	requests, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.PersonalDataRequest])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (r *CandidateRepository) GetByTelegramID(ctx context.Context, telegramID int64) (entity.Candidate, error) {
+	return r.getCandidate(ctx, "telegram_id", telegramID, false)
+}
+
+// GetByTelegramIDForUpdate locks the candidate until the end of the transaction.
+func (r *CandidateRepository) GetByTelegramIDForUpdate(ctx context.Context, telegramID int64) (entity.Candidate, error) {
+	return r.getCandidate(ctx, "telegram_id", telegramID, true)
+}
+
+func (r *CandidateRepository) GetByID(ctx context.Context, candidateID int64) (entity.Candidate, error) {
+	return r.getCandidate(ctx, "id", candidateID, false)
+}
+
+// GetByIDForUpdate locks the candidate until the end of the transaction.
+func (r *CandidateRepository) GetByIDForUpdate(ctx context.Context, candidateID int64) (entity.Candidate, error) {
+	return r.getCandidate(ctx, "id", candidateID, true)
+}
+
+func (r *CandidateRepository) getCandidate(ctx context.Context, column string, value int64, forUpdate bool) (entity.Candidate, error) {
+	q := `
+		SELECT 
+id,
+COALESCE(telegram_id, 0),
+COALESCE(telegram_username, ''),
+COALESCE(full_name, ''),
+COALESCE(phone, ''),
+COALESCE(city, ''),
+email,
+preferred_contact,
+created_at
+		  FROM candidate
+		 WHERE ` + column + ` = $1`
+	if forUpdate {
+		q += `
+		   FOR UPDATE`
+	}
+
+	var candidate entity.Candidate
+	err := executor(ctx, r.db).QueryRow(ctx, q, value).Scan(
+		&candidate.ID,
+		&candidate.TelegramID,
+		&candidate.TelegramUsername,
+		&candidate.FullName,
+		&candidate.Phone,
+		&candidate.City,
+		&candidate.Email,
+		&candidate.PreferredContact,
+		&candidate.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Candidate{}, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.Candidate{}, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return candidate, nil
+}
+
+// Update saves the profile and the field-level history of changes made to it in one transaction.
+func (r *CandidateRepository) Update(ctx context.Context, candidate entity.Candidate, changes []entity.CandidateChange) error {
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const updateQuery = `
+		UPDATE candidate SET
+full_name         = $2,
+phone             = $3,
+city              = $4,
+email             = $5,
+preferred_contact = $6,
+telegram_username = $7
+		 WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, updateQuery,
+		candidate.ID,
+		candidate.FullName,
+		candidate.Phone,
+		candidate.City,
+		candidate.Email,
+		candidate.PreferredContact,
+		candidate.TelegramUsername,
+	)
+	if isUniqueViolation(err) {
+		return candidateUniqueViolation(err, candidate.TelegramID, candidate.Phone)
+	}
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return inerrors.ErrNotFound
 	}
 
-	return id, nil
-}
+	if len(changes) > 0 {
+		insertBuilder := psql.Insert("candidate_change").
+			Columns(
+				"candidate_id",
+				"field",
+				"old_value",
+				"new_value",
+				"source",
+			)
+		for _, change := range changes {
+			insertBuilder = insertBuilder.Values(candidate.ID, change.Field, change.OldValue, change.NewValue, change.Source)
+		}
 
-func (r *CandidateRepository) Delete(ctx context.Context, candidateID int64) error {
-	const q = `
-		DELETE FROM candidate
-	     WHERE id = $1`
+		q, args, err := insertBuilder.ToSql()
+		if err != nil {
+			return fmt.Errorf("can't build query: %w", err)
+		}
 
-	_, err := r.db.Exec(ctx, q, candidateID)
+		_, err = tx.Exec(ctx, q, args...)
+		if err != nil {
+			return fmt.Errorf("can't exec query: %w", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
-		return fmt.Errorf("can't exec query: %w", err)
+		return fmt.Errorf("can't commit tx: %w", err)
 	}
 
 	return nil
 }
 
-func (r *CandidateRepository) GetByTelegramID(ctx context.Context, telegramID int64) (entity.Candidate, error) {
+func (r *CandidateRepository) GetChanges(ctx context.Context, candidateID int64) ([]entity.CandidateChange, error) {
 	const q = `
-		SELECT 
+		SELECT
 id,
-telegram_id,
-telegram_username,
-full_name,
-phone,
-city,
+candidate_id,
+field,
+old_value,
+new_value,
+source,
 created_at
-		  FROM candidate
-		 WHERE telegram_id = $1`
+		  FROM candidate_change
+		 WHERE candidate_id = $1
+	  ORDER BY created_at DESC, id DESC`
 
-	var candidate entity.Candidate
-	err := r.db.QueryRow(ctx, q, telegramID).Scan(
-		&candidate.ID,
-		&candidate.TelegramID,
-		&candidate.TelegramUsername,
-		&candidate.FullName,
-		&candidate.Phone,
-		&candidate.City,
-		&candidate.CreatedAt,
-	)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return entity.Candidate{}, inerrors.ErrNotFound
+	rows, err := r.db.Query(ctx, q, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
 	}
+
+	changes, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.CandidateChange])
 	if err != nil {
-		return entity.Candidate{}, fmt.Errorf("can't exec query: %w", err)
+		return nil, fmt.Errorf("can't collect rows: %w", err)
 	}
 
-	return candidate, nil
+	return changes, nil
 }
 
 func (r *CandidateRepository) UpdateScreeningResult(ctx context.Context, candidateID int64, vacancyID uuid.UUID, result service_models.ResumeScreeningResultWithStatus) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := executor(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("can't begin tx: %w", err)
 	}
@@ -203,6 +1156,7 @@ candidate_id,
 vacancy_id,  
 interview_score,
 status,      
+current_round,
 updated_at
 		  FROM candidate_vacancy_meta
 		 WHERE candidate_id = $1 
@@ -214,6 +1168,7 @@ updated_at
 		&meta.VacancyID,
 		&meta.InterviewScore,
 		&meta.Status,
+		&meta.CurrentRound,
 		&meta.UpdatedAt,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -226,96 +1181,16 @@ updated_at
 	return meta, nil
 }
 
-func (r *CandidateRepository) GetCandidateVacancyInfos(ctx context.Context) ([]entity.CandidateVacancyInfo, error) {
-	const q = `
-		SELECT 
-    c.id AS candidate_id,
-    c.telegram_id,
-    c.full_name,
-    c.phone,
-    c.city,
-    c.created_at AS candidate_created_at,
-
-    v.id AS vacancy_id,
-    v.title,
-    v.key_requirements,
-    v.created_at AS vacancy_created_at,
-
-    m.candidate_id AS meta_candidate_id,
-    m.vacancy_id AS meta_vacancy_id,
-    m.interview_score,
-    m.status,
-    m.is_archived,
-    m.updated_at,
-    
-    rs.id,
-    rs.score,
-    rs.feedback,
-    rs.created_at,
-    rs.updated_at
-
-FROM candidate c
-JOIN candidate_vacancy_meta m ON m.candidate_id = c.id
-JOIN vacancy v ON v.id = m.vacancy_id
-JOIN resume_screening rs ON rs.candidate_id = c.id AND rs.vacancy_id = v.id`
-
-	var infos []entity.CandidateVacancyInfo
-	rows, err := r.db.Query(ctx, q)
-	if err != nil {
-		return nil, fmt.Errorf("can't exec query: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var info entity.CandidateVacancyInfo
-		var keyRequirements []string
-
-		err = rows.Scan(
-			&info.Candidate.ID,
-			&info.Candidate.TelegramID,
-			&info.Candidate.FullName,
-			&info.Candidate.Phone,
-			&info.Candidate.City,
-			&info.Candidate.CreatedAt,
-
-			&info.Vacancy.ID,
-			&info.Vacancy.Title,
-			&keyRequirements,
-			&info.Vacancy.CreatedAt,
-
-			&info.Meta.CandidateID,
-			&info.Meta.VacancyID,
-			&info.Meta.InterviewScore,
-			&info.Meta.Status,
-			&info.Meta.IsArchived,
-			&info.Meta.UpdatedAt,
-
-			&info.ResumeScreening.ID,
-			&info.ResumeScreening.Score,
-			&info.ResumeScreening.Feedback,
-			&info.ResumeScreening.CreatedAt,
-			&info.ResumeScreening.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("can't scan row: %w", err)
-		}
-		info.Vacancy.KeyRequirements = keyRequirements
-
-		infos = append(infos, info)
-	}
-
-	return infos, nil
-}
-
-func (r *CandidateRepository) GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error) {
-	const q = `
+const candidateVacancyInfoQuery = `
 		SELECT 
     c.id AS candidate_id,
-    c.telegram_id,
-    c.telegram_username,
-    c.full_name,
-    c.phone,
-    c.city,
+    COALESCE(c.telegram_id, 0),
+    COALESCE(c.telegram_username, ''),
+    COALESCE(c.full_name, ''),
+    COALESCE(c.phone, ''),
+    COALESCE(c.city, ''),
+    c.email,
+    c.preferred_contact,
     c.created_at AS candidate_created_at,
 
     v.id AS vacancy_id,
@@ -327,6 +1202,7 @@ func (r *CandidateRepository) GetCandidateVacancyInfo(ctx context.Context, candi
     m.vacancy_id AS meta_vacancy_id,
     m.interview_score,
     m.status,
+    m.current_round,
     m.is_archived,
     m.updated_at,
     
@@ -339,14 +1215,12 @@ func (r *CandidateRepository) GetCandidateVacancyInfo(ctx context.Context, candi
  FROM candidate c
  JOIN candidate_vacancy_meta m ON m.candidate_id = c.id
  JOIN vacancy v ON v.id = m.vacancy_id
- JOIN resume_screening rs ON rs.candidate_id = c.id AND rs.vacancy_id = v.id
-WHERE c.id = $1 AND v.id = $2`
+ JOIN resume_screening rs ON rs.candidate_id = c.id AND rs.vacancy_id = v.id`
 
+func scanCandidateVacancyInfo(row pgx.Row) (entity.CandidateVacancyInfo, error) {
 	var info entity.CandidateVacancyInfo
 	var keyRequirements []string
 
-	row := r.db.QueryRow(ctx, q, candidateID, vacancyID)
-
 	err := row.Scan(
 		&info.Candidate.ID,
 		&info.Candidate.TelegramID,
@@ -354,6 +1228,8 @@ WHERE c.id = $1 AND v.id = $2`
 		&info.Candidate.FullName,
 		&info.Candidate.Phone,
 		&info.Candidate.City,
+		&info.Candidate.Email,
+		&info.Candidate.PreferredContact,
 		&info.Candidate.CreatedAt,
 
 		&info.Vacancy.ID,
@@ -365,6 +1241,7 @@ WHERE c.id = $1 AND v.id = $2`
 		&info.Meta.VacancyID,
 		&info.Meta.InterviewScore,
 		&info.Meta.Status,
+		&info.Meta.CurrentRound,
 		&info.Meta.IsArchived,
 		&info.Meta.UpdatedAt,
 
@@ -374,13 +1251,81 @@ WHERE c.id = $1 AND v.id = $2`
 		&info.ResumeScreening.CreatedAt,
 		&info.ResumeScreening.UpdatedAt,
 	)
+	if err != nil {
+		return entity.CandidateVacancyInfo{}, err
+	}
+	info.Vacancy.KeyRequirements = keyRequirements
+
+	return info, nil
+}
+
+func (r *CandidateRepository) GetCandidateVacancyInfos(ctx context.Context) ([]entity.CandidateVacancyInfo, error) {
+	const q = candidateVacancyInfoQuery + `
+WHERE c.erased_at IS NULL`
+
+	return r.queryCandidateVacancyInfos(ctx, q)
+}
+
+func (r *CandidateRepository) GetCandidateVacancyInfosByVacancyID(ctx context.Context, vacancyID uuid.UUID) ([]entity.CandidateVacancyInfo, error) {
+	const q = candidateVacancyInfoQuery + `
+WHERE c.erased_at IS NULL AND v.id = $1`
+
+	return r.queryCandidateVacancyInfos(ctx, q, vacancyID)
+}
+
+func (r *CandidateRepository) GetCandidateVacancyInfosByCandidateID(ctx context.Context, candidateID int64) ([]entity.CandidateVacancyInfo, error) {
+	const q = candidateVacancyInfoQuery + `
+WHERE c.id = $1`
+
+	return r.queryCandidateVacancyInfos(ctx, q, candidateID)
+}
+
+// GetScreenedCandidateVacancyInfos returns applications that passed resume screening within [from, to).
+func (r *CandidateRepository) GetScreenedCandidateVacancyInfos(ctx context.Context, from time.Time, to time.Time) ([]entity.CandidateVacancyInfo, error) {
+	const q = candidateVacancyInfoQuery + `
+WHERE c.erased_at IS NULL
+  AND m.status <> $1
+  AND rs.updated_at >= $2
+  AND rs.updated_at < $3
+ORDER BY v.title, rs.score DESC`
+
+	return r.queryCandidateVacancyInfos(ctx, q, entity.CandidateVacancyStatusScreeningFailed, from, to)
+}
+
+func (r *CandidateRepository) queryCandidateVacancyInfos(ctx context.Context, q string, args ...any) ([]entity.CandidateVacancyInfo, error) {
+	var infos []entity.CandidateVacancyInfo
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		info, err := scanCandidateVacancyInfo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		infos = append(infos, info)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return infos, nil
+}
+
+func (r *CandidateRepository) GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error) {
+	const q = candidateVacancyInfoQuery + `
+WHERE c.id = $1 AND v.id = $2`
+
+	info, err := scanCandidateVacancyInfo(r.db.QueryRow(ctx, q, candidateID, vacancyID))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return entity.CandidateVacancyInfo{}, inerrors.ErrNotFound
 	}
 	if err != nil {
 		return entity.CandidateVacancyInfo{}, fmt.Errorf("can't scan row: %w", err)
 	}
-	info.Vacancy.KeyRequirements = keyRequirements
 
 	return info, nil
 }
@@ -390,8 +1335,10 @@ func (r *CandidateRepository) GetCandidateAnswers(ctx context.Context, candidate
 		SELECT
     q.id,
     q.vacancy_id,
+    q.type,
     q.content,
     q.reference,
+    q.rubric,
 
     a.id,
     a.candidate_id,
@@ -399,6 +1346,9 @@ func (r *CandidateRepository) GetCandidateAnswers(ctx context.Context, candidate
     a.content,
     a.score,
     a.time_taken,
+    a.explanation,
+    a.missing_points,
+    a.voice,
     a.created_at
 
 FROM candidate c
@@ -422,8 +1372,78 @@ ORDER BY q.position`
 		err = rows.Scan(
 			&questionAnswer.Question.ID,
 			&questionAnswer.Question.VacancyID,
+			&questionAnswer.Question.Type,
+			&questionAnswer.Question.Content,
+			&questionAnswer.Question.Reference,
+			&questionAnswer.Question.Rubric,
+
+			&questionAnswer.Answer.ID,
+			&questionAnswer.Answer.CandidateID,
+			&questionAnswer.Answer.QuestionID,
+			&questionAnswer.Answer.Content,
+			&questionAnswer.Answer.Score,
+			&questionAnswer.Answer.TimeTaken,
+			&questionAnswer.Answer.Explanation,
+			&questionAnswer.Answer.MissingPoints,
+			&questionAnswer.Answer.Voice,
+			&questionAnswer.Answer.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		questionAnswers = append(questionAnswers, questionAnswer)
+	}
+
+	return questionAnswers, nil
+}
+
+// GetVacancyAnswers returns answers of all candidates to the vacancy questions ordered by question position.
+func (r *CandidateRepository) GetVacancyAnswers(ctx context.Context, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error) {
+	const q = `
+		SELECT
+    q.id,
+    q.vacancy_id,
+    q.type,
+    q.content,
+    q.reference,
+    q.time_limit,
+    q.position,
+
+    a.id,
+    a.candidate_id,
+    a.question_id,
+    a.content,
+    a.score,
+    a.time_taken,
+    a.voice,
+    a.created_at
+
+FROM question q
+         JOIN answer a ON a.question_id = q.id
+         JOIN candidate c ON c.id = a.candidate_id
+WHERE q.vacancy_id = $1 AND c.erased_at IS NULL
+ORDER BY q.position, a.candidate_id`
+
+	var questionAnswers []entity.CandidateQuestionAnswer
+
+	rows, err := r.db.Query(ctx, q, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var questionAnswer entity.CandidateQuestionAnswer
+
+		err = rows.Scan(
+			&questionAnswer.Question.ID,
+			&questionAnswer.Question.VacancyID,
+			&questionAnswer.Question.Type,
 			&questionAnswer.Question.Content,
 			&questionAnswer.Question.Reference,
+			&questionAnswer.Question.TimeLimit,
+			&questionAnswer.Question.Position,
 
 			&questionAnswer.Answer.ID,
 			&questionAnswer.Answer.CandidateID,
@@ -431,6 +1451,7 @@ ORDER BY q.position`
 			&questionAnswer.Answer.Content,
 			&questionAnswer.Answer.Score,
 			&questionAnswer.Answer.TimeTaken,
+			&questionAnswer.Answer.Voice,
 			&questionAnswer.Answer.CreatedAt,
 		)
 		if err != nil {
@@ -442,3 +1463,177 @@ ORDER BY q.position`
 
 	return questionAnswers, nil
 }
+
+func (r *CandidateRepository) UpsertCandidateSummary(ctx context.Context, summary entity.CandidateSummary) error {
+	const q = `
+		INSERT INTO candidate_summary (
+candidate_id,
+vacancy_id,
+strengths,
+weaknesses,
+red_flags,
+recommendation,
+rationale
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+   ON CONFLICT (candidate_id, vacancy_id) DO UPDATE SET
+strengths      = EXCLUDED.strengths,
+weaknesses     = EXCLUDED.weaknesses,
+red_flags      = EXCLUDED.red_flags,
+recommendation = EXCLUDED.recommendation,
+rationale      = EXCLUDED.rationale,
+updated_at     = now()`
+
+	_, err := r.db.Exec(ctx, q,
+		summary.CandidateID,
+		summary.VacancyID,
+		summary.Strengths,
+		summary.Weaknesses,
+		summary.RedFlags,
+		summary.Recommendation,
+		summary.Rationale,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CandidateRepository) GetCandidateSummary(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateSummary, error) {
+	const q = `
+		SELECT
+candidate_id,
+vacancy_id,
+strengths,
+weaknesses,
+red_flags,
+recommendation,
+rationale,
+created_at,
+updated_at
+		  FROM candidate_summary
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	rows, err := r.db.Query(ctx, q, candidateID, vacancyID)
+	if err != nil {
+		return entity.CandidateSummary{}, fmt.Errorf("can't query: %w", err)
+	}
+
+	summary, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entity.CandidateSummary])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.CandidateSummary{}, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.CandidateSummary{}, fmt.Errorf("can't collect row: %w", err)
+	}
+
+	return summary, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// candidateUniqueViolation tells which contact of the candidate is already taken by another one.
+func candidateUniqueViolation(err error, telegramID int64, phone string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "candidate_phone_unique_idx" {
+		return fmt.Errorf("%w: candidate with phone %s", inerrors.ErrAlreadyExists, phone)
+	}
+
+	return fmt.Errorf("%w: candidate with telegram id %d", inerrors.ErrAlreadyExists, telegramID)
+}
+
+// nullTelegramID stores a missing Telegram id as NULL, so that it doesn't collide with other candidates without one.
+func nullTelegramID(telegramID int64) *int64 {
+	if telegramID == 0 {
+		return nil
+	}
+
+	return &telegramID
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+func (r *CandidateRepository) UpsertAnswerSignals(ctx context.Context, signals entity.AnswerSignals) error {
+	const q = `
+		INSERT INTO answer_signal (
+answer_id,
+chars_per_minute,
+peer_similarity,
+similar_answer_id,
+reference_similarity,
+ai_likelihood,
+ai_reason,
+flags,
+risk
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+   ON CONFLICT (answer_id) DO UPDATE SET
+chars_per_minute     = EXCLUDED.chars_per_minute,
+peer_similarity      = EXCLUDED.peer_similarity,
+similar_answer_id    = EXCLUDED.similar_answer_id,
+reference_similarity = EXCLUDED.reference_similarity,
+ai_likelihood        = EXCLUDED.ai_likelihood,
+ai_reason            = EXCLUDED.ai_reason,
+flags                = EXCLUDED.flags,
+risk                 = EXCLUDED.risk,
+updated_at           = now()`
+
+	_, err := r.db.Exec(ctx, q,
+		signals.AnswerID,
+		signals.CharsPerMinute,
+		signals.PeerSimilarity,
+		signals.SimilarAnswerID,
+		signals.ReferenceSimilarity,
+		signals.AILikelihood,
+		signals.AIReason,
+		signals.Flags,
+		signals.Risk,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetAnswerSignals returns signals of the candidate answers to the vacancy questions, answers without signals are skipped.
+func (r *CandidateRepository) GetAnswerSignals(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.AnswerSignals, error) {
+	const q = `
+		SELECT
+s.answer_id,
+s.chars_per_minute,
+s.peer_similarity,
+s.similar_answer_id,
+s.reference_similarity,
+s.ai_likelihood,
+s.ai_reason,
+s.flags,
+s.risk,
+s.created_at,
+s.updated_at
+		  FROM answer_signal s
+		  JOIN answer a ON a.id = s.answer_id
+		  JOIN question q ON q.id = a.question_id
+		 WHERE a.candidate_id = $1
+		   AND q.vacancy_id = $2`
+
+	rows, err := r.db.Query(ctx, q, candidateID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	signals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AnswerSignals])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return signals, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+// executor returns the transaction started by dobby.PGXTransactor if ctx carries one, otherwise the pool.
+// Begin on a transaction creates a savepoint, so methods with their own tx compose into the outer one.
+func executor(ctx context.Context, db *pgxpool.Pool) dobby.Executor {
+	if tx := dobby.ExtractPGXTx(ctx); tx != nil {
+		return tx
+	}
+
+	return db
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+// ExportRepository reads rows one at a time, so that exports don't hold whole tables in memory.
+type ExportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewExportRepository(db *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{
+		db: db,
+	}
+}
+
+func (r *ExportRepository) EachCandidateVacancyInfo(ctx context.Context, filter service_models.CandidateExportFilter, fn func(entity.CandidateVacancyInfo) error) error {
+	where := sq.And{sq.Expr("c.erased_at IS NULL")}
+	if filter.VacancyID != nil {
+		where = append(where, sq.Eq{"v.id": *filter.VacancyID})
+	}
+	if filter.Status != "" {
+		where = append(where, sq.Eq{"m.status": filter.Status})
+	}
+	if !filter.IncludeArchived {
+		where = append(where, sq.Expr("NOT m.is_archived"))
+	}
+
+	cond, args, err := where.ToSql()
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+	q, err := sq.Dollar.ReplacePlaceholders(candidateVacancyInfoQuery + `
+WHERE ` + cond + `
+ORDER BY v.title, c.id`)
+	if err != nil {
+		return fmt.Errorf("can't build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		info, err := scanCandidateVacancyInfo(rows)
+		if err != nil {
+			return fmt.Errorf("can't scan row: %w", err)
+		}
+
+		err = fn(info)
+		if err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return nil
+}
+
+// EachVacancyAnswerRow yields application rows grouped by candidate, an application without answers yields one row.
+func (r *ExportRepository) EachVacancyAnswerRow(ctx context.Context, vacancyID uuid.UUID, fn func(service_models.VacancyAnswerRow) error) error {
+	const q = `
+		SELECT
+    c.id,
+    COALESCE(c.full_name, ''),
+
+    m.interview_score,
+    m.status,
+    m.is_archived,
+
+    a.question_id,
+    a.score
+
+FROM candidate_vacancy_meta m
+         JOIN candidate c ON c.id = m.candidate_id
+         LEFT JOIN answer a ON a.candidate_id = c.id
+    AND a.question_id IN (SELECT id FROM question WHERE vacancy_id = m.vacancy_id)
+WHERE m.vacancy_id = $1 AND c.erased_at IS NULL
+ORDER BY c.id`
+
+	rows, err := r.db.Query(ctx, q, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row service_models.VacancyAnswerRow
+		err = rows.Scan(
+			&row.Candidate.ID,
+			&row.Candidate.FullName,
+
+			&row.Meta.InterviewScore,
+			&row.Meta.Status,
+			&row.Meta.IsArchived,
+
+			&row.QuestionID,
+			&row.Score,
+		)
+		if err != nil {
+			return fmt.Errorf("can't scan row: %w", err)
+		}
+		row.Meta.CandidateID = row.Candidate.ID
+		row.Meta.VacancyID = vacancyID
+
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type InterviewSlotRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInterviewSlotRepository(db *pgxpool.Pool) *InterviewSlotRepository {
+	return &InterviewSlotRepository{
+		db: db,
+	}
+}
+
+const interviewSlotColumns = `
+id,
+recruiter_email,
+vacancy_id,
+starts_at,
+ends_at,
+location,
+candidate_id,
+booked_vacancy_id,
+booked_at,
+created_at`
+
+// CreateSlot returns inerrors.ErrConflict if the recruiter already has an overlapping slot.
+func (r *InterviewSlotRepository) CreateSlot(ctx context.Context, slot entity.InterviewSlot) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// serializes slot creation per recruiter, so that concurrent requests can't both pass the overlap check
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('interview_slot:' || $1))`, slot.RecruiterEmail)
+	if err != nil {
+		return 0, fmt.Errorf("can't lock recruiter slots: %w", err)
+	}
+
+	const overlapQuery = `
+		SELECT EXISTS (
+		    SELECT 1
+		      FROM interview_slot
+		     WHERE recruiter_email = $1
+		       AND starts_at < $3
+		       AND ends_at > $2
+		)`
+
+	var overlaps bool
+	err = tx.QueryRow(ctx, overlapQuery, slot.RecruiterEmail, slot.StartsAt, slot.EndsAt).Scan(&overlaps)
+	if err != nil {
+		return 0, fmt.Errorf("can't check overlap: %w", err)
+	}
+	if overlaps {
+		return 0, fmt.Errorf("%w: slot overlaps another slot of %s", inerrors.ErrConflict, slot.RecruiterEmail)
+	}
+
+	const insertQuery = `
+		INSERT INTO interview_slot (
+recruiter_email,
+vacancy_id,
+starts_at,
+ends_at,
+location
+)
+		VALUES ($1, $2, $3, $4, $5)
+	 RETURNING id`
+
+	var id int64
+	err = tx.QueryRow(ctx, insertQuery,
+		slot.RecruiterEmail,
+		slot.VacancyID,
+		slot.StartsAt,
+		slot.EndsAt,
+		slot.Location,
+	).Scan(&id)
+	if isForeignKeyViolation(err) {
+		return 0, fmt.Errorf("vacancy %s: %w", slot.VacancyID, inerrors.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *InterviewSlotRepository) GetSlot(ctx context.Context, slotID int64) (entity.InterviewSlot, error) {
+	const q = `
+		SELECT` + interviewSlotColumns + `
+		  FROM interview_slot
+		 WHERE id = $1`
+
+	return r.getSlot(ctx, q, slotID)
+}
+
+func (r *InterviewSlotRepository) GetBookedSlot(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.InterviewSlot, error) {
+	const q = `
+		SELECT` + interviewSlotColumns + `
+		  FROM interview_slot
+		 WHERE candidate_id = $1
+		   AND booked_vacancy_id = $2`
+
+	return r.getSlot(ctx, q, candidateID, vacancyID)
+}
+
+func (r *InterviewSlotRepository) getSlot(ctx context.Context, q string, args ...any) (entity.InterviewSlot, error) {
+	rows, err := executor(ctx, r.db).Query(ctx, q, args...)
+	if err != nil {
+		return entity.InterviewSlot{}, fmt.Errorf("can't query: %w", err)
+	}
+
+	slot, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entity.InterviewSlot])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.InterviewSlot{}, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.InterviewSlot{}, fmt.Errorf("can't collect row: %w", err)
+	}
+
+	return slot, nil
+}
+
+func (r *InterviewSlotRepository) GetRecruiterSlots(ctx context.Context, recruiterEmail string, from time.Time, to time.Time) ([]entity.InterviewSlot, error) {
+	const q = `
+		SELECT` + interviewSlotColumns + `
+		  FROM interview_slot
+		 WHERE recruiter_email = $1
+		   AND starts_at >= $2
+		   AND starts_at < $3
+	  ORDER BY starts_at`
+
+	return r.getSlots(ctx, q, recruiterEmail, from, to)
+}
+
+// GetAvailableSlots returns free future slots offered for the vacancy or for any vacancy.
+func (r *InterviewSlotRepository) GetAvailableSlots(ctx context.Context, vacancyID uuid.UUID) ([]entity.InterviewSlot, error) {
+	const q = `
+		SELECT` + interviewSlotColumns + `
+		  FROM interview_slot
+		 WHERE candidate_id IS NULL
+		   AND starts_at > now()
+		   AND (vacancy_id IS NULL OR vacancy_id = $1)
+	  ORDER BY starts_at`
+
+	return r.getSlots(ctx, q, vacancyID)
+}
+
+func (r *InterviewSlotRepository) getSlots(ctx context.Context, q string, args ...any) ([]entity.InterviewSlot, error) {
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	slots, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.InterviewSlot])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return slots, nil
+}
+
+// DeleteSlot deletes a free slot of the recruiter; booked slots can't be deleted.
+func (r *InterviewSlotRepository) DeleteSlot(ctx context.Context, slotID int64, recruiterEmail string) error {
+	slot, err := r.GetSlot(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	if slot.RecruiterEmail != recruiterEmail {
+		return inerrors.ErrNotFound
+	}
+
+	const q = `
+		DELETE FROM interview_slot
+		 WHERE id = $1
+		   AND candidate_id IS NULL`
+
+	tag, err := r.db.Exec(ctx, q, slotID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: slot %d is booked", inerrors.ErrConflict, slotID)
+	}
+
+	return nil
+}
+
+// BookSlot assigns a free future slot to the candidate's application and moves the application to
+// the interview_scheduled status. It returns inerrors.ErrConflict if the slot is taken or overlaps
+// another booking of the candidate.
+func (r *InterviewSlotRepository) BookSlot(ctx context.Context, slotID int64, candidateID int64, vacancyID uuid.UUID) error {
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('interview_slot_candidate:' || $1::text))`, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't lock candidate bookings: %w", err)
+	}
+
+	// the status is checked under the lock, so that two bookings can't both see interview_ok
+	const statusQuery = `
+		SELECT status
+		  FROM candidate_vacancy_meta
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2
+		   FOR UPDATE`
+
+	var status string
+	err = tx.QueryRow(ctx, statusQuery, candidateID, vacancyID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: application of candidate %d to vacancy %s", inerrors.ErrNotFound, candidateID, vacancyID)
+	}
+	if err != nil {
+		return fmt.Errorf("can't get status: %w", err)
+	}
+	if status != entity.CandidateVacancyStatusInterviewOk {
+		return fmt.Errorf("%w: application is in status %s, not %s", inerrors.ErrConflict, status, entity.CandidateVacancyStatusInterviewOk)
+	}
+
+	const bookQuery = `
+		UPDATE interview_slot s SET
+candidate_id      = $2,
+booked_vacancy_id = $3,
+booked_at         = now()
+		 WHERE s.id = $1
+		   AND s.candidate_id IS NULL
+		   AND s.starts_at > now()
+		   AND (s.vacancy_id IS NULL OR s.vacancy_id = $3)
+		   AND NOT EXISTS (
+		           SELECT 1
+		             FROM interview_slot b
+		            WHERE b.candidate_id = $2
+		              AND (b.booked_vacancy_id = $3 OR (b.starts_at < s.ends_at AND b.ends_at > s.starts_at))
+		       )`
+
+	tag, err := tx.Exec(ctx, bookQuery, slotID, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: slot %d is not available or overlaps another booking", inerrors.ErrConflict, slotID)
+	}
+
+	const updateMetaQuery = `
+		UPDATE candidate_vacancy_meta SET
+status     = $3,
+updated_at = now()
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2`
+
+	_, err = tx.Exec(ctx, updateMetaQuery, candidateID, vacancyID, entity.CandidateVacancyStatusInterviewScheduled)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
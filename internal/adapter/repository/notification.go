@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type NotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationRepository) CreateTelegramNotification(ctx context.Context, notification entity.TelegramNotification) error {
+	const q = `
+		INSERT INTO telegram_notification (
+event_id,
+candidate_id,
+vacancy_id,
+telegram_id,
+status,
+text,
+error
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, q,
+		notification.EventID,
+		notification.CandidateID,
+		notification.VacancyID,
+		notification.TelegramID,
+		notification.Status,
+		notification.Text,
+		notification.Error,
+	)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("notification for event %s: %w", notification.EventID, inerrors.ErrAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) IsTelegramNotificationSent(ctx context.Context, eventID uuid.UUID) (bool, error) {
+	const q = `
+		SELECT EXISTS (
+		    SELECT 1
+		      FROM telegram_notification
+		     WHERE event_id = $1
+		       AND error = ''
+		)`
+
+	var sent bool
+	err := r.db.QueryRow(ctx, q, eventID).Scan(&sent)
+	if err != nil {
+		return false, fmt.Errorf("can't query row: %w", err)
+	}
+
+	return sent, nil
+}
+
+func (r *NotificationRepository) GetTelegramNotifications(ctx context.Context, candidateID int64) ([]entity.TelegramNotification, error) {
+	const q = `
+		SELECT
+id,
+event_id,
+candidate_id,
+vacancy_id,
+COALESCE(telegram_id, 0) AS telegram_id,
+status,
+text,
+error,
+created_at
+		  FROM telegram_notification
+		 WHERE candidate_id = $1
+	  ORDER BY id DESC`
+
+	rows, err := r.db.Query(ctx, q, candidateID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	notifications, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.TelegramNotification])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return notifications, nil
+}
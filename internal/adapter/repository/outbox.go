@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// Publish stores the event in the outbox. Called within a transaction it is committed together with the state change.
+func (r *OutboxRepository) Publish(ctx context.Context, event entity.Event) error {
+	const q = `
+		INSERT INTO outbox (
+event_id,
+event_type,
+occurred_at,
+data
+)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := executor(ctx, r.db).Exec(ctx, q,
+		event.ID,
+		event.Type,
+		event.OccurredAt,
+		event.Data,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// Dispatch creates deliveries of undispatched messages to the sinks and returns the number of dispatched messages.
+func (r *OutboxRepository) Dispatch(ctx context.Context, limit int, sinks []string) (int, error) {
+	const q = `
+		WITH dispatched AS (
+		    UPDATE outbox
+		       SET dispatched_at = now()
+		     WHERE id IN (
+		               SELECT id
+		                 FROM outbox
+		                WHERE dispatched_at IS NULL
+		             ORDER BY id
+		                LIMIT $1
+		                  FOR UPDATE SKIP LOCKED
+		           )
+		 RETURNING id, published_sinks
+		), deliveries AS (
+		    INSERT INTO outbox_delivery (message_id, sink)
+		    SELECT d.id, s.sink
+		      FROM dispatched d
+		     CROSS JOIN unnest($2::TEXT[]) AS s(sink)
+		     WHERE NOT s.sink = ANY (COALESCE(d.published_sinks, '{}'))
+		        ON CONFLICT DO NOTHING
+		)
+		SELECT count(*)
+		  FROM dispatched`
+
+	var count int
+	err := r.db.QueryRow(ctx, q, limit, sinks).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return count, nil
+}
+
+// ClaimDue leases the next due delivery to the sink, so that other relays skip it until the lease expires.
+// It returns inerrors.ErrNotFound if nothing is due.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, sink string, lease time.Duration) (service_models.OutboxDelivery, error) {
+	const q = `
+		UPDATE outbox_delivery d
+		   SET next_attempt_at = now() + make_interval(secs => $2)
+		  FROM outbox o
+		 WHERE o.id = d.message_id
+		   AND (d.message_id, d.sink) = (
+		           SELECT message_id, sink
+		             FROM outbox_delivery
+		            WHERE sink = $1
+		              AND published_at IS NULL
+		              AND dead_at IS NULL
+		              AND next_attempt_at <= now()
+		         ORDER BY message_id
+		            LIMIT 1
+		              FOR UPDATE SKIP LOCKED
+		       )
+	 RETURNING d.message_id, d.sink, o.event_id, o.event_type, o.occurred_at, o.data, d.attempts`
+
+	var delivery service_models.OutboxDelivery
+	err := r.db.QueryRow(ctx, q, sink, lease.Seconds()).Scan(
+		&delivery.MessageID,
+		&delivery.Sink,
+		&delivery.Event.ID,
+		&delivery.Event.Type,
+		&delivery.Event.OccurredAt,
+		&delivery.Event.Data,
+		&delivery.Attempts,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return service_models.OutboxDelivery{}, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return service_models.OutboxDelivery{}, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, messageID int64, sink string) error {
+	const q = `
+		UPDATE outbox_delivery SET
+attempts     = attempts + 1,
+last_error   = '',
+published_at = now()
+		 WHERE message_id = $1
+		   AND sink = $2`
+
+	_, err := r.db.Exec(ctx, q, messageID, sink)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, messageID int64, sink string, lastError string, nextAttemptAt time.Time) error {
+	const q = `
+		UPDATE outbox_delivery SET
+attempts        = attempts + 1,
+last_error      = $3,
+next_attempt_at = $4
+		 WHERE message_id = $1
+		   AND sink = $2`
+
+	_, err := r.db.Exec(ctx, q, messageID, sink, lastError, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead stops retrying the delivery, it stays in the table for investigation.
+func (r *OutboxRepository) MarkDead(ctx context.Context, messageID int64, sink string, lastError string) error {
+	const q = `
+		UPDATE outbox_delivery SET
+attempts   = attempts + 1,
+last_error = $3,
+dead_at    = now()
+		 WHERE message_id = $1
+		   AND sink = $2`
+
+	_, err := r.db.Exec(ctx, q, messageID, sink, lastError)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
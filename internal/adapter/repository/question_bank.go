@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type QuestionBankRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewQuestionBankRepository(db *pgxpool.Pool) *QuestionBankRepository {
+	return &QuestionBankRepository{
+		db: db,
+	}
+}
+
+var bankQuestionColumns = []string{
+	"id",
+	"type",
+	"content",
+	"reference",
+	"time_limit",
+	"rubric",
+	"options",
+	"numeric_answer",
+	"numeric_tolerance",
+	"code_language",
+	"test_cases",
+	"tags",
+	"created_at",
+}
+
+func (r *QuestionBankRepository) CreateBankQuestion(ctx context.Context, question dto_models.CreateBankQuestionRequest) (int64, error) {
+	rubric, err := marshalRubric(question.Rubric)
+	if err != nil {
+		return 0, err
+	}
+	options, err := marshalOptions(question.Options)
+	if err != nil {
+		return 0, err
+	}
+	testCases, err := marshalTestCases(question.TestCases)
+	if err != nil {
+		return 0, err
+	}
+
+	const q = `
+		INSERT INTO bank_question (
+type,
+content,
+reference,
+time_limit,
+rubric,
+options,
+numeric_answer,
+numeric_tolerance,
+code_language,
+test_cases,
+tags
+)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	 RETURNING id`
+
+	var id int64
+	err = r.db.QueryRow(ctx, q,
+		question.Type,
+		question.Content,
+		question.Reference,
+		question.TimeLimit,
+		rubric,
+		options,
+		question.NumericAnswer,
+		question.NumericTolerance,
+		question.CodeLanguage,
+		testCases,
+		question.Tags,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *QuestionBankRepository) GetBankQuestion(ctx context.Context, id int64) (entity.BankQuestion, error) {
+	questions, err := r.GetBankQuestionsByIDs(ctx, []int64{id})
+	if err != nil {
+		return entity.BankQuestion{}, err
+	}
+	if len(questions) == 0 {
+		return entity.BankQuestion{}, inerrors.ErrNotFound
+	}
+
+	return questions[0], nil
+}
+
+// GetBankQuestionsByIDs skips missing questions, the order isn't kept.
+func (r *QuestionBankRepository) GetBankQuestionsByIDs(ctx context.Context, ids []int64) ([]entity.BankQuestion, error) {
+	return r.find(ctx, psql.Select(bankQuestionColumns...).
+		From("bank_question").
+		Where(sq.Expr("id = ANY(?)", ids)))
+}
+
+func (r *QuestionBankRepository) FindBankQuestions(ctx context.Context, filter service_models.BankQuestionFilter) ([]entity.BankQuestion, error) {
+	qb := psql.Select(bankQuestionColumns...).
+		From("bank_question").
+		OrderBy("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset)
+
+	if len(filter.Tags) > 0 {
+		qb = qb.Where(sq.Expr("tags @> ?", filter.Tags))
+	}
+	if filter.Query != "" {
+		qb = qb.Where(sq.Expr("strpos(lower(content), lower(?)) > 0", filter.Query))
+	}
+	if filter.Type != "" {
+		qb = qb.Where(sq.Eq{"type": filter.Type})
+	}
+
+	return r.find(ctx, qb)
+}
+
+func (r *QuestionBankRepository) find(ctx context.Context, qb sq.SelectBuilder) ([]entity.BankQuestion, error) {
+	q, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("can't build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	questions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.BankQuestion])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return questions, nil
+}
+
+// DeleteBankQuestion keeps the copies of the question in vacancies.
+func (r *QuestionBankRepository) DeleteBankQuestion(ctx context.Context, id int64) error {
+	const q = `DELETE FROM bank_question
+                     WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return inerrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// DrawBankQuestions returns ids of up to count random bank questions with the tag, leaving out the ones
+// the vacancy already asks everyone and the ones already drawn for the candidate.
+func (r *QuestionBankRepository) DrawBankQuestions(ctx context.Context, candidateID int64, vacancyID uuid.UUID, tag string, count int) ([]int64, error) {
+	const q = `
+		SELECT id
+		  FROM bank_question
+		 WHERE $3 = ANY (tags)
+		   AND id NOT IN (
+		       SELECT bank_question_id
+		         FROM question
+		        WHERE vacancy_id = $2
+		          AND bank_question_id IS NOT NULL
+		          AND (candidate_id IS NULL OR candidate_id = $1)
+		   )
+	  ORDER BY random()
+		 LIMIT $4`
+
+	rows, err := executor(ctx, r.db).Query(ctx, q, candidateID, vacancyID, tag, count)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type RecruiterRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecruiterRepository(db *pgxpool.Pool) *RecruiterRepository {
+	return &RecruiterRepository{
+		db: db,
+	}
+}
+
+const recruiterSubscriptionColumns = `
+email,
+vacancy_ids,
+daily_digest,
+instant_alerts,
+alert_min_score,
+last_digest_at,
+updated_at`
+
+func (r *RecruiterRepository) GetSubscriptions(ctx context.Context) ([]entity.RecruiterSubscription, error) {
+	const q = `
+		SELECT` + recruiterSubscriptionColumns + `
+		  FROM recruiter_subscription
+	  ORDER BY email`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	subscriptions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.RecruiterSubscription])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *RecruiterRepository) GetSubscription(ctx context.Context, email string) (entity.RecruiterSubscription, error) {
+	const q = `
+		SELECT` + recruiterSubscriptionColumns + `
+		  FROM recruiter_subscription
+		 WHERE email = $1`
+
+	rows, err := r.db.Query(ctx, q, email)
+	if err != nil {
+		return entity.RecruiterSubscription{}, fmt.Errorf("can't query: %w", err)
+	}
+
+	subscription, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entity.RecruiterSubscription])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.RecruiterSubscription{}, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return entity.RecruiterSubscription{}, fmt.Errorf("can't collect row: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (r *RecruiterRepository) UpsertSubscription(ctx context.Context, subscription entity.RecruiterSubscription) error {
+	const q = `
+		INSERT INTO recruiter_subscription (
+email,
+vacancy_ids,
+daily_digest,
+instant_alerts,
+alert_min_score,
+updated_at
+)
+		VALUES ($1, $2, $3, $4, $5, now())
+   ON CONFLICT (email)
+	 DO UPDATE
+		   SET
+vacancy_ids     = EXCLUDED.vacancy_ids,
+daily_digest    = EXCLUDED.daily_digest,
+instant_alerts  = EXCLUDED.instant_alerts,
+alert_min_score = EXCLUDED.alert_min_score,
+updated_at      = now()`
+
+	_, err := r.db.Exec(ctx, q,
+		subscription.Email,
+		subscription.VacancyIDs,
+		subscription.DailyDigest,
+		subscription.InstantAlerts,
+		subscription.AlertMinScore,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecruiterRepository) MarkDigestSent(ctx context.Context, email string, sentAt time.Time) error {
+	const q = `
+		UPDATE recruiter_subscription
+		   SET last_digest_at = $2
+		 WHERE email = $1`
+
+	_, err := r.db.Exec(ctx, q, email, sentAt)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecruiterRepository) GetAlertedEmails(ctx context.Context, eventID uuid.UUID) ([]string, error) {
+	const q = `
+		SELECT email
+		  FROM recruiter_alert
+		 WHERE event_id = $1`
+
+	rows, err := r.db.Query(ctx, q, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	emails, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return emails, nil
+}
+
+func (r *RecruiterRepository) MarkAlertSent(ctx context.Context, eventID uuid.UUID, email string) error {
+	const q = `
+		INSERT INTO recruiter_alert (event_id, email)
+		VALUES ($1, $2)
+		    ON CONFLICT DO NOTHING`
+
+	_, err := r.db.Exec(ctx, q, eventID, email)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
@@ -37,11 +37,55 @@ func (r *VacancyRepository) CreateVacancy(ctx context.Context, vacancy dto_model
 		vacancy.KeyRequirements,
 	}
 
-	placeholders := make([]string, 0, len(vacancy.Questions))
-	for i, q := range vacancy.Questions {
-		args = append(args, i+1, q.Content, q.Reference, q.TimeLimit)
-		placeholders = append(placeholders, fmt.Sprintf("($%d::int, $%d::text, $%d::text, $%d::int)",
+	roundPlaceholders := make([]string, 0, len(vacancy.Rounds))
+	questionPlaceholders := make([]string, 0)
+	position := 0
+	for i, round := range vacancy.Rounds {
+		bankDraws, err := marshalBankDraws(round.BankDraws)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		args = append(args, i+1, round.Title, round.Threshold, bankDraws)
+		roundPlaceholders = append(roundPlaceholders, fmt.Sprintf("($%d::int, $%d::text, $%d::int, $%d::jsonb)",
 			len(args)-3, len(args)-2, len(args)-1, len(args)))
+
+		for _, q := range round.Questions {
+			rubric, err := marshalRubric(q.Rubric)
+			if err != nil {
+				return uuid.UUID{}, err
+			}
+			options, err := marshalOptions(q.Options)
+			if err != nil {
+				return uuid.UUID{}, err
+			}
+			testCases, err := marshalTestCases(q.TestCases)
+			if err != nil {
+				return uuid.UUID{}, err
+			}
+
+			position++
+			args = append(args, position, i+1, q.Type, q.Content, q.Reference, q.TimeLimit, rubric,
+				options, q.NumericAnswer, q.NumericTolerance, q.CodeLanguage, testCases)
+			questionPlaceholders = append(questionPlaceholders, fmt.Sprintf(
+				"($%d::int, $%d::int, $%d::text, $%d::text, $%d::text, $%d::int, $%d::jsonb, $%d::jsonb, $%d::float8, $%d::float8, $%d::text, $%d::jsonb)",
+				len(args)-11, len(args)-10, len(args)-9, len(args)-8, len(args)-7, len(args)-6,
+				len(args)-5, len(args)-4, len(args)-3, len(args)-2, len(args)-1, len(args)))
+		}
+	}
+
+	// rounds made only of bank draws have no questions of their own
+	questionsInsert := ""
+	if len(questionPlaceholders) > 0 {
+		questionsInsert = fmt.Sprintf(`,
+        questions_insert AS (
+            INSERT INTO question (vacancy_id, position, round, type, content, reference, time_limit, rubric,
+                                  options, numeric_answer, numeric_tolerance, code_language, test_cases)
+            SELECT $1, position, round, type, content, reference, time_limit, rubric,
+                   options, numeric_answer, numeric_tolerance, code_language, test_cases
+            FROM (VALUES %s) AS t(position, round, type, content, reference, time_limit, rubric,
+                                  options, numeric_answer, numeric_tolerance, code_language, test_cases)
+        )`, strings.Join(questionPlaceholders, ","))
 	}
 
 	q := fmt.Sprintf(`
@@ -50,13 +94,13 @@ func (r *VacancyRepository) CreateVacancy(ctx context.Context, vacancy dto_model
             VALUES ($1, $2, $3)
           RETURNING id
         ),
-        questions_insert AS (
-            INSERT INTO question (vacancy_id, position, content, reference, time_limit)
-            SELECT $1, position, content, reference, time_limit
-            FROM (VALUES %s) AS t(position, content, reference, time_limit)
-        )
+        rounds_insert AS (
+            INSERT INTO interview_round (vacancy_id, number, title, threshold, bank_draws)
+            SELECT $1, number, title, threshold, bank_draws
+            FROM (VALUES %s) AS r(number, title, threshold, bank_draws)
+        )%s
 		SELECT id FROM vacancy_insert
-    `, strings.Join(placeholders, ","))
+    `, strings.Join(roundPlaceholders, ","), questionsInsert)
 
 	var id uuid.UUID
 	err := r.db.QueryRow(ctx, q, args...).Scan(&id)
@@ -110,14 +154,35 @@ func (r *VacancyRepository) CreateQuestions(ctx context.Context, vacancyID uuid.
 	insertBuilder := psql.Insert("question").
 		Columns(
 			"vacancy_id",
+			"type",
 			"content",
 			"reference",
 			"time_limit",
 			"position",
+			"rubric",
+			"options",
+			"numeric_answer",
+			"numeric_tolerance",
+			"code_language",
+			"test_cases",
 		)
 
 	for i, question := range questions {
-		insertBuilder.Values(vacancyID, question.Content, question.Reference, question.TimeLimit, i+1)
+		rubric, err := marshalRubric(question.Rubric)
+		if err != nil {
+			return err
+		}
+		options, err := marshalOptions(question.Options)
+		if err != nil {
+			return err
+		}
+		testCases, err := marshalTestCases(question.TestCases)
+		if err != nil {
+			return err
+		}
+
+		insertBuilder = insertBuilder.Values(vacancyID, question.Type, question.Content, question.Reference, question.TimeLimit, i+1, rubric,
+			options, question.NumericAnswer, question.NumericTolerance, question.CodeLanguage, testCases)
 	}
 
 	q, args, err := insertBuilder.ToSql()
@@ -138,10 +203,20 @@ func (r *VacancyRepository) GetQuestionByID(ctx context.Context, id int64) (enti
 		SELECT
 id,
 vacancy_id,
+type,
 content,
 reference,
 time_limit,
 position,
+round,
+rubric,
+options,
+numeric_answer,
+numeric_tolerance,
+code_language,
+test_cases,
+bank_question_id,
+candidate_id,
 created_at
           FROM question
           WHERE id = $1`
@@ -150,10 +225,20 @@ created_at
 	err := r.db.QueryRow(ctx, q, id).Scan(
 		&question.ID,
 		&question.VacancyID,
+		&question.Type,
 		&question.Content,
 		&question.Reference,
 		&question.TimeLimit,
 		&question.Position,
+		&question.Round,
+		&question.Rubric,
+		&question.Options,
+		&question.NumericAnswer,
+		&question.NumericTolerance,
+		&question.CodeLanguage,
+		&question.TestCases,
+		&question.BankQuestionID,
+		&question.CandidateID,
 		&question.CreatedAt,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -173,9 +258,12 @@ candidate_id,
 question_id,
 content,
 score,
-time_taken
+time_taken,
+explanation,
+missing_points,
+voice
 )
-		VALUES ($1, $2, $3, $4, $5)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	 RETURNING id`
 
 	var id int64
@@ -185,6 +273,9 @@ time_taken
 		answer.Content,
 		answer.Score,
 		answer.TimeTaken,
+		answer.Explanation,
+		answer.MissingPoints,
+		answer.Voice,
 	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("can't exec query: %w", err)
@@ -193,22 +284,36 @@ time_taken
 	return id, nil
 }
 
-func (r *VacancyRepository) GetQuestionsByVacancyID(ctx context.Context, vacancyID uuid.UUID) ([]entity.Question, error) {
+// GetQuestionsByRound returns the questions asked to everyone and the ones drawn for the candidate,
+// candidateID 0 gives only the former.
+func (r *VacancyRepository) GetQuestionsByRound(ctx context.Context, vacancyID uuid.UUID, round int, candidateID int64) ([]entity.Question, error) {
 	const q = `
 		SELECT
 id,
 vacancy_id,
+type,
 content,
 reference,
 time_limit,
 "position",
+round,
+rubric,
+options,
+numeric_answer,
+numeric_tolerance,
+code_language,
+test_cases,
+bank_question_id,
+candidate_id,
 created_at
           FROM question
 		 WHERE vacancy_id = $1
+		   AND round = $2
+		   AND (candidate_id IS NULL OR candidate_id = $3)
 	  ORDER BY position
          `
 
-	rows, err := r.db.Query(ctx, q, vacancyID)
+	rows, err := r.db.Query(ctx, q, vacancyID, round, candidateID)
 	if err != nil {
 		return nil, fmt.Errorf("can't query: %w", err)
 	}
@@ -221,7 +326,8 @@ created_at
 	return questions, nil
 }
 
-func (r *VacancyRepository) GetAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.Answer, error) {
+// GetAnswers returns the candidate answers to the questions of the vacancy interview round.
+func (r *VacancyRepository) GetAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID, round int) ([]entity.Answer, error) {
 	const q = `
 		SELECT
 answer.id,
@@ -230,15 +336,19 @@ answer.question_id,
 answer.content,
 answer.score,
 answer.time_taken,
+answer.explanation,
+answer.missing_points,
+answer.voice,
 answer.created_at
           FROM answer 
           JOIN question 
             ON answer.question_id = question.id
 		 WHERE candidate_id = $1
 		   AND question.vacancy_id = $2
+		   AND question.round = $3
          `
 
-	rows, err := r.db.Query(ctx, q, candidateID, vacancyID)
+	rows, err := r.db.Query(ctx, q, candidateID, vacancyID, round)
 	if err != nil {
 		return nil, fmt.Errorf("can't query: %w", err)
 	}
@@ -258,21 +368,24 @@ candidate_id,
 vacancy_id,
 interview_score,		                                    
 status,      
+current_round,
 updated_at
 )
-		VALUES ($1, $2, $3, $4, now())
+		VALUES ($1, $2, $3, $4, $5, now())
    ON CONFLICT (candidate_id, vacancy_id)
 	 DO UPDATE
 		   SET 
 interview_score = EXCLUDED.interview_score,
 status          = EXCLUDED.status,
+current_round   = EXCLUDED.current_round,
 updated_at      = now();`
 
-	_, err := r.db.Exec(ctx, upsertMetaQuery,
+	_, err := executor(ctx, r.db).Exec(ctx, upsertMetaQuery,
 		candidateID,
 		vacancyID,
 		result.Score,
 		result.Status,
+		result.CurrentRound,
 	)
 	if err != nil {
 		return fmt.Errorf("can't exec query: %w", err)
@@ -293,16 +406,36 @@ json_agg(
 	jsonb_build_object(
 		'id', q.id,
 		'vacancy_id', q.vacancy_id,
+		'type', q.type,
 		'content', q.content,
 		'reference', q.reference,
 		'time_limit', q.time_limit,
 		'position', q.position,
+		'round', q.round,
+		'rubric', q.rubric,
+		'options', q.options,
+		'numeric_answer', q.numeric_answer,
+		'numeric_tolerance', q.numeric_tolerance,
+		'code_language', q.code_language,
+		'test_cases', q.test_cases,
+		'bank_question_id', q.bank_question_id,
 		'created_at', q.created_at
 	) ORDER BY q.position),
 	'[]'::json
-) AS questions
+) AS questions,
+(SELECT COALESCE(json_agg(
+	jsonb_build_object(
+		'vacancy_id', r.vacancy_id,
+		'number', r.number,
+		'title', r.title,
+		'threshold', r.threshold,
+		'bank_draws', r.bank_draws
+	) ORDER BY r.number), '[]'::json)
+   FROM interview_round r
+  WHERE r.vacancy_id = v.id
+) AS rounds
      FROM vacancy v
-LEFT JOIN question q ON q.vacancy_id = v.id
+LEFT JOIN question q ON q.vacancy_id = v.id AND q.candidate_id IS NULL
  GROUP BY v.id, v.title, v.key_requirements, v.created_at
  ORDER BY v.created_at DESC`
 
@@ -314,8 +447,8 @@ LEFT JOIN question q ON q.vacancy_id = v.id
 
 	for rows.Next() {
 		var v entity.VacancyWithQuestion
-		var questionsJSON []byte
-		err = rows.Scan(&v.ID, &v.Title, &v.KeyRequirements, &v.CreatedAt, &questionsJSON)
+		var questionsJSON, roundsJSON []byte
+		err = rows.Scan(&v.ID, &v.Title, &v.KeyRequirements, &v.CreatedAt, &questionsJSON, &roundsJSON)
 		if err != nil {
 			return nil, fmt.Errorf("can't scan vacancy: %w", err)
 		}
@@ -323,6 +456,10 @@ LEFT JOIN question q ON q.vacancy_id = v.id
 		if err != nil {
 			return nil, fmt.Errorf("can't unmarshal questions: %w", err)
 		}
+		err = json.Unmarshal(roundsJSON, &v.Rounds)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarshal rounds: %w", err)
+		}
 
 		vacancies = append(vacancies, v)
 	}
@@ -342,16 +479,36 @@ json_agg(
 	jsonb_build_object(
 		'id', q.id,
 		'vacancy_id', q.vacancy_id,
+		'type', q.type,
 		'content', q.content,
 		'reference', q.reference,
 		'time_limit', q.time_limit,
 		'position', q.position,
+		'round', q.round,
+		'rubric', q.rubric,
+		'options', q.options,
+		'numeric_answer', q.numeric_answer,
+		'numeric_tolerance', q.numeric_tolerance,
+		'code_language', q.code_language,
+		'test_cases', q.test_cases,
+		'bank_question_id', q.bank_question_id,
 		'created_at', q.created_at
 	) ORDER BY q.position),
 	'[]'::json
-) AS questions
+) AS questions,
+(SELECT COALESCE(json_agg(
+	jsonb_build_object(
+		'vacancy_id', r.vacancy_id,
+		'number', r.number,
+		'title', r.title,
+		'threshold', r.threshold,
+		'bank_draws', r.bank_draws
+	) ORDER BY r.number), '[]'::json)
+   FROM interview_round r
+  WHERE r.vacancy_id = v.id
+) AS rounds
      FROM vacancy v
-LEFT JOIN question q ON q.vacancy_id = v.id
+LEFT JOIN question q ON q.vacancy_id = v.id AND q.candidate_id IS NULL
     WHERE v.id = $1
  GROUP BY v.id, v.title, v.key_requirements, v.created_at
  ORDER BY v.created_at DESC`
@@ -359,8 +516,8 @@ LEFT JOIN question q ON q.vacancy_id = v.id
 	row := r.db.QueryRow(ctx, q, vacancyID)
 
 	var v entity.VacancyWithQuestion
-	var questionsJSON []byte
-	err := row.Scan(&v.ID, &v.Title, &v.KeyRequirements, &v.CreatedAt, &questionsJSON)
+	var questionsJSON, roundsJSON []byte
+	err := row.Scan(&v.ID, &v.Title, &v.KeyRequirements, &v.CreatedAt, &questionsJSON, &roundsJSON)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return entity.VacancyWithQuestion{}, inerrors.ErrNotFound
 	}
@@ -372,6 +529,10 @@ LEFT JOIN question q ON q.vacancy_id = v.id
 	if err != nil {
 		return entity.VacancyWithQuestion{}, fmt.Errorf("can't unmarshal questions: %w", err)
 	}
+	err = json.Unmarshal(roundsJSON, &v.Rounds)
+	if err != nil {
+		return entity.VacancyWithQuestion{}, fmt.Errorf("can't unmarshal rounds: %w", err)
+	}
 
 	return v, nil
 }
@@ -387,3 +548,235 @@ func (r *VacancyRepository) DeleteVacancy(ctx context.Context, vacancyID uuid.UU
 
 	return nil
 }
+
+func (r *VacancyRepository) GetRounds(ctx context.Context, vacancyID uuid.UUID) ([]entity.InterviewRound, error) {
+	const q = `
+		SELECT
+vacancy_id,
+number,
+title,
+threshold,
+bank_draws
+		  FROM interview_round
+		 WHERE vacancy_id = $1
+	  ORDER BY number`
+
+	rows, err := r.db.Query(ctx, q, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	rounds, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.InterviewRound])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return rounds, nil
+}
+
+func (r *VacancyRepository) CreateRoundResult(ctx context.Context, result entity.RoundResult) error {
+	const q = `
+		INSERT INTO interview_round_result (
+candidate_id,
+vacancy_id,
+round,
+score,
+passed
+)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := executor(ctx, r.db).Exec(ctx, q,
+		result.CandidateID,
+		result.VacancyID,
+		result.Round,
+		result.Score,
+		result.Passed,
+	)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%w: round %d is already scored", inerrors.ErrConflict, result.Round)
+	}
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// CopyBankQuestions appends copies of the bank questions to the vacancy round in the given order,
+// candidateID limits them to a single candidate.
+func (r *VacancyRepository) CopyBankQuestions(ctx context.Context, vacancyID uuid.UUID, round int, candidateID *int64, bankQuestionIDs []int64) error {
+	tx, err := executor(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("can't begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// the copies are appended after the last question, concurrent draws for the vacancy wait for each other
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('question_position:' || $1::text))`, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't lock question positions: %w", err)
+	}
+
+	const q = `
+		INSERT INTO question (
+vacancy_id,
+position,
+round,
+type,
+content,
+reference,
+time_limit,
+rubric,
+options,
+numeric_answer,
+numeric_tolerance,
+code_language,
+test_cases,
+bank_question_id,
+candidate_id
+)
+		SELECT
+$1,
+(SELECT COALESCE(MAX(position), 0) FROM question WHERE vacancy_id = $1) + t.ord,
+$2,
+b.type,
+b.content,
+b.reference,
+b.time_limit,
+b.rubric,
+b.options,
+b.numeric_answer,
+b.numeric_tolerance,
+b.code_language,
+b.test_cases,
+b.id,
+$3
+		  FROM unnest($4::bigint[]) WITH ORDINALITY AS t(id, ord)
+		  JOIN bank_question b ON b.id = t.id`
+
+	_, err = tx.Exec(ctx, q, vacancyID, round, candidateID, bankQuestionIDs)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("can't commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// MarkQuestionsDrawn returns false if the questions of the round are already drawn for the candidate.
+func (r *VacancyRepository) MarkQuestionsDrawn(ctx context.Context, candidateID int64, vacancyID uuid.UUID, round int) (bool, error) {
+	const q = `
+		INSERT INTO bank_question_draw (
+candidate_id,
+vacancy_id,
+round
+)
+		VALUES ($1, $2, $3)
+   ON CONFLICT DO NOTHING`
+
+	tag, err := executor(ctx, r.db).Exec(ctx, q, candidateID, vacancyID, round)
+	if err != nil {
+		return false, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *VacancyRepository) GetRoundResults(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.RoundResult, error) {
+	const q = `
+		SELECT
+candidate_id,
+vacancy_id,
+round,
+score,
+passed,
+created_at
+		  FROM interview_round_result
+		 WHERE candidate_id = $1
+		   AND vacancy_id = $2
+	  ORDER BY round`
+
+	rows, err := r.db.Query(ctx, q, candidateID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	results, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.RoundResult])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return results, nil
+}
+
+func marshalRubric(rubric []dto_models.RubricPointRequest) ([]byte, error) {
+	points := make([]entity.RubricPoint, 0, len(rubric))
+	for _, point := range rubric {
+		points = append(points, entity.RubricPoint{
+			Point:  point.Point,
+			Weight: point.Weight,
+		})
+	}
+
+	data, err := json.Marshal(points)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal rubric: %w", err)
+	}
+
+	return data, nil
+}
+
+func marshalOptions(options []dto_models.ChoiceOptionRequest) ([]byte, error) {
+	choices := make([]entity.ChoiceOption, 0, len(options))
+	for _, option := range options {
+		choices = append(choices, entity.ChoiceOption{
+			Text:    option.Text,
+			Correct: option.Correct,
+		})
+	}
+
+	data, err := json.Marshal(choices)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal options: %w", err)
+	}
+
+	return data, nil
+}
+
+func marshalTestCases(testCases []dto_models.TestCaseRequest) ([]byte, error) {
+	cases := make([]entity.TestCase, 0, len(testCases))
+	for _, testCase := range testCases {
+		cases = append(cases, entity.TestCase{
+			Input:          testCase.Input,
+			ExpectedOutput: testCase.ExpectedOutput,
+		})
+	}
+
+	data, err := json.Marshal(cases)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal test cases: %w", err)
+	}
+
+	return data, nil
+}
+
+func marshalBankDraws(bankDraws []dto_models.BankDrawRequest) ([]byte, error) {
+	draws := make([]entity.BankDraw, 0, len(bankDraws))
+	for _, draw := range bankDraws {
+		draws = append(draws, entity.BankDraw{
+			Tag:   draw.Tag,
+			Count: draw.Count,
+		})
+	}
+
+	data, err := json.Marshal(draws)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal bank draws: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (int64, error) {
+	const q = `
+		INSERT INTO webhook_subscription (
+url,
+secret,
+event_types
+)
+		VALUES ($1, $2, $3)
+	 RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, q,
+		subscription.URL,
+		subscription.Secret,
+		subscription.EventTypes,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	const q = `
+		SELECT
+id,
+url,
+secret,
+event_types,
+is_active,
+created_at
+		  FROM webhook_subscription
+	  ORDER BY id`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	subscriptions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookSubscription])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	const q = `
+		DELETE FROM webhook_subscription
+		 WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, q, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return inerrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateDeliveries fans the event out to every active subscription interested in its type,
+// a subscription which already has a delivery of the event is skipped.
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, event entity.Event, payload []byte) error {
+	const q = `
+		INSERT INTO webhook_delivery (
+subscription_id,
+event_id,
+event_type,
+payload,
+status
+)
+		SELECT id, $1, $2, $3, $4
+		  FROM webhook_subscription
+		 WHERE is_active
+		   AND $2 = ANY (event_types)
+		    ON CONFLICT (subscription_id, event_id) WHERE redelivery_of IS NULL DO NOTHING`
+
+	_, err := r.db.Exec(ctx, q,
+		event.ID,
+		event.Type,
+		payload,
+		entity.WebhookDeliveryStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases pending deliveries whose time has come, so that other workers skip them until the lease expires.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]service_models.WebhookDeliveryJob, error) {
+	const q = `
+		UPDATE webhook_delivery d
+		   SET next_attempt_at = now() + make_interval(secs => $2)
+		  FROM webhook_subscription s
+		 WHERE d.subscription_id = s.id
+		   AND d.id IN (
+		           SELECT id
+		             FROM webhook_delivery
+		            WHERE status = $3
+		              AND next_attempt_at <= now()
+		         ORDER BY next_attempt_at
+		            LIMIT $1
+		              FOR UPDATE SKIP LOCKED
+		       )
+	 RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret`
+
+	rows, err := r.db.Query(ctx, q, limit, lease.Seconds(), entity.WebhookDeliveryStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []service_models.WebhookDeliveryJob
+	for rows.Next() {
+		var job service_models.WebhookDeliveryJob
+		err = rows.Scan(
+			&job.ID,
+			&job.EventID,
+			&job.EventType,
+			&job.Payload,
+			&job.Attempts,
+			&job.URL,
+			&job.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan row: %w", err)
+		}
+
+		jobs = append(jobs, job)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't read rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (r *WebhookRepository) MarkDeliverySucceeded(ctx context.Context, deliveryID int64, statusCode int) error {
+	const q = `
+		UPDATE webhook_delivery SET
+status           = $2,
+attempts         = attempts + 1,
+last_status_code = $3,
+last_error       = '',
+delivered_at     = now()
+		 WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, deliveryID, entity.WebhookDeliveryStatusSucceeded, statusCode)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDeliveryFailed schedules the next attempt or, if nextAttemptAt is nil, gives up on the delivery.
+func (r *WebhookRepository) MarkDeliveryFailed(ctx context.Context, deliveryID int64, statusCode *int, lastError string, nextAttemptAt *time.Time) error {
+	const q = `
+		UPDATE webhook_delivery SET
+status           = CASE WHEN $4::timestamptz IS NULL THEN $5 ELSE status END,
+attempts         = attempts + 1,
+last_status_code = $2,
+last_error       = $3,
+next_attempt_at  = COALESCE($4, next_attempt_at)
+		 WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, q, deliveryID, statusCode, lastError, nextAttemptAt, entity.WebhookDeliveryStatusFailed)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error) {
+	const q = `
+		SELECT
+id,
+subscription_id,
+event_id,
+event_type,
+payload,
+status,
+attempts,
+next_attempt_at,
+last_status_code,
+last_error,
+created_at,
+delivered_at
+		  FROM webhook_delivery
+		 WHERE subscription_id = $1
+	  ORDER BY id DESC
+		 LIMIT $2`
+
+	rows, err := r.db.Query(ctx, q, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't query: %w", err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookDelivery])
+	if err != nil {
+		return nil, fmt.Errorf("can't collect rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver copies the delivery into a new pending one, the original stays in the log untouched.
+func (r *WebhookRepository) Redeliver(ctx context.Context, deliveryID int64) (int64, error) {
+	const q = `
+		INSERT INTO webhook_delivery (
+subscription_id,
+event_id,
+event_type,
+payload,
+status,
+redelivery_of
+)
+		SELECT subscription_id, event_id, event_type, payload, $2, id
+		  FROM webhook_delivery
+		 WHERE id = $1
+	 RETURNING id`
+
+	var id int64
+	err := r.db.QueryRow(ctx, q, deliveryID, entity.WebhookDeliveryStatusPending).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, inerrors.ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,80 @@
+package stt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"hr-helper/internal/inerrors"
+)
+
+const speechKitRecognizeURL = "https://stt.api.cloud.yandex.net/speech/v1/stt:recognize"
+
+type SpeechKitConfig struct {
+	APIKey   string
+	FolderID string
+	Lang     string
+}
+
+// SpeechKit uses synchronous recognition, which accepts OGG/Opus audio up to 30 seconds and 1 MB.
+type SpeechKit struct {
+	cfg    SpeechKitConfig
+	client *http.Client
+}
+
+func NewSpeechKit(cfg SpeechKitConfig) *SpeechKit {
+	return &SpeechKit{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+type speechKitResponse struct {
+	Result string `json:"result"`
+}
+
+func (s *SpeechKit) Transcribe(ctx context.Context, audio []byte, contentType string) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "audio/ogg" && mediaType != "audio/opus" {
+		return "", fmt.Errorf("%w: unsupported audio format %q, want audio/ogg", inerrors.ErrInvalidInput, contentType)
+	}
+
+	query := url.Values{}
+	query.Set("folderId", s.cfg.FolderID)
+	query.Set("lang", s.cfg.Lang)
+	query.Set("format", "oggopus")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, speechKitRecognizeURL+"?"+query.Encode(), bytes.NewReader(audio))
+	if err != nil {
+		return "", fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Api-Key "+s.cfg.APIKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("can't read body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("non-2xx status: %s\nbody: %s\n", resp.Status, string(body))
+	}
+
+	var result speechKitResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return "", fmt.Errorf("can't unmarshal result: %w", err)
+	}
+
+	return result.Result, nil
+}
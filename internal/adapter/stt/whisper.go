@@ -0,0 +1,98 @@
+package stt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+type WhisperConfig struct {
+	URL   string
+	Model string
+	Lang  string
+}
+
+// Whisper talks to a self-hosted Whisper server through the OpenAI-compatible transcription API.
+type Whisper struct {
+	cfg    WhisperConfig
+	client *http.Client
+}
+
+func NewWhisper(cfg WhisperConfig) *Whisper {
+	return &Whisper{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+type whisperResponse struct {
+	Text string `json:"text"`
+}
+
+func (w *Whisper) Transcribe(ctx context.Context, audio []byte, contentType string) (string, error) {
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="answer"`)
+	header.Set("Content-Type", contentType)
+	file, err := form.CreatePart(header)
+	if err != nil {
+		return "", fmt.Errorf("can't create file part: %w", err)
+	}
+	_, err = file.Write(audio)
+	if err != nil {
+		return "", fmt.Errorf("can't write audio: %w", err)
+	}
+
+	fields := map[string]string{
+		"model":           w.cfg.Model,
+		"language":        w.cfg.Lang,
+		"response_format": "json",
+	}
+	for name, value := range fields {
+		err = form.WriteField(name, value)
+		if err != nil {
+			return "", fmt.Errorf("can't write field %s: %w", name, err)
+		}
+	}
+
+	err = form.Close()
+	if err != nil {
+		return "", fmt.Errorf("can't close form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL+"/v1/audio/transcriptions", body)
+	if err != nil {
+		return "", fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("can't read body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("non-2xx status: %s\nbody: %s\n", resp.Status, string(respBody))
+	}
+
+	var result whisperResponse
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return "", fmt.Errorf("can't unmarshal result: %w", err)
+	}
+
+	return result.Text, nil
+}
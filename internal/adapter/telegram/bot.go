@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const baseURL = "https://api.telegram.org"
+
+// APIError is an error reported by the Bot API. RetryAfter is set when Telegram asks to slow down.
+type APIError struct {
+	Code        int
+	Description string
+	RetryAfter  time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram error %d: %s", e.Code, e.Description)
+}
+
+// Permanent reports whether resending the same message is pointless, e.g. the bot is blocked by the user.
+func (e *APIError) Permanent() bool {
+	return e.Code == http.StatusBadRequest || e.Code == http.StatusForbidden
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type sendMessageRequest struct {
+	ChatID    int64  `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+type Bot struct {
+	token      string
+	httpClient HTTPClient
+}
+
+func NewBot(token string, httpClient HTTPClient) *Bot {
+	return &Bot{
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// SendMessage sends an HTML-formatted message to the chat.
+func (b *Bot) SendMessage(ctx context.Context, chatID int64, text string) error {
+	payload, err := json.Marshal(sendMessageRequest{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: "HTML",
+	})
+	if err != nil {
+		return fmt.Errorf("can't marshal json: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/bot"+b.token+"/sendMessage", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		// *url.Error contains the url with the token, keep only the cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	err = json.NewDecoder(resp.Body).Decode(&apiResp)
+	if err != nil {
+		return fmt.Errorf("can't decode resp: %w", err)
+	}
+
+	if !apiResp.OK {
+		return &APIError{
+			Code:        apiResp.ErrorCode,
+			Description: apiResp.Description,
+			RetryAfter:  time.Duration(apiResp.Parameters.RetryAfter) * time.Second,
+		}
+	}
+
+	return nil
+}
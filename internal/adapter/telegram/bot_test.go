@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestBotSendMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		resp          string
+		doErr         error
+		wantErr       bool
+		wantAPIErr    *APIError
+		wantPermanent bool
+	}{
+		{
+			name: "sent",
+			resp: `{"ok": true, "result": {}}`,
+		},
+		{
+			name:          "blocked by user",
+			resp:          `{"ok": false, "error_code": 403, "description": "Forbidden: bot was blocked by the user"}`,
+			wantErr:       true,
+			wantAPIErr:    &APIError{Code: 403, Description: "Forbidden: bot was blocked by the user"},
+			wantPermanent: true,
+		},
+		{
+			name:       "too many requests",
+			resp:       `{"ok": false, "error_code": 429, "description": "Too Many Requests", "parameters": {"retry_after": 5}}`,
+			wantErr:    true,
+			wantAPIErr: &APIError{Code: 429, Description: "Too Many Requests", RetryAfter: 5 * time.Second},
+		},
+		{
+			name:    "transport error",
+			doErr:   &url.Error{Op: "Post", URL: baseURL + "/botsecret-token/sendMessage", Err: errors.New("connection refused")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid response",
+			resp:    `<html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sendMessageRequest
+			bot := NewBot("secret-token", httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.String() != baseURL+"/botsecret-token/sendMessage" {
+					t.Errorf("url = %s", req.URL)
+				}
+				if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+					t.Errorf("can't decode request: %v", err)
+				}
+				if tt.doErr != nil {
+					return nil, tt.doErr
+				}
+				return jsonResponse(tt.resp), nil
+			}))
+
+			err := bot.SendMessage(context.Background(), 42, "<b>hi</b>")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != (sendMessageRequest{ChatID: 42, Text: "<b>hi</b>", ParseMode: "HTML"}) {
+				t.Errorf("request = %+v", got)
+			}
+			if err != nil && strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("error leaks the token: %v", err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				if tt.wantAPIErr != nil {
+					t.Fatalf("SendMessage() error = %v, want %v", err, tt.wantAPIErr)
+				}
+				return
+			}
+			if *apiErr != *tt.wantAPIErr {
+				t.Errorf("SendMessage() error = %+v, want %+v", apiErr, tt.wantAPIErr)
+			}
+			if apiErr.Permanent() != tt.wantPermanent {
+				t.Errorf("Permanent() = %v, want %v", apiErr.Permanent(), tt.wantPermanent)
+			}
+		})
+	}
+}
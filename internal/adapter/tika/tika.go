@@ -0,0 +1,47 @@
+package tika
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+func NewClient(url string) *Client {
+	return &Client{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+func (c *Client) ExtractText(ctx context.Context, data []byte, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url+"/tika", bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("can't create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("can't read body: %w", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("non-2xx status: %s\nbody: %s\n", resp.Status, string(body))
+	}
+
+	return string(body), nil
+}
@@ -10,18 +10,36 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"go.uber.org/multierr"
 
+	"hr-helper/internal/adapter/coderunner"
 	"hr-helper/internal/adapter/llm"
+	"hr-helper/internal/adapter/mail"
 	"hr-helper/internal/adapter/objstorage"
 	"hr-helper/internal/adapter/repository"
+	"hr-helper/internal/adapter/stt"
+	"hr-helper/internal/adapter/telegram"
+	"hr-helper/internal/adapter/tika"
 	"hr-helper/internal/handler/httpapi"
 	"hr-helper/internal/pkg/houston/closer"
 	"hr-helper/internal/pkg/houston/config"
 	"hr-helper/internal/pkg/houston/dobby"
 	"hr-helper/internal/pkg/houston/loggy"
 	"hr-helper/internal/pkg/houston/secret"
+	"hr-helper/internal/service/analytics"
+	"hr-helper/internal/service/audit"
 	"hr-helper/internal/service/auther"
 	"hr-helper/internal/service/candidate"
+	"hr-helper/internal/service/export"
+	"hr-helper/internal/service/integrity"
+	"hr-helper/internal/service/notifier"
+	"hr-helper/internal/service/outbox"
+	"hr-helper/internal/service/ranking"
+	"hr-helper/internal/service/recruiter"
+	"hr-helper/internal/service/report"
+	"hr-helper/internal/service/retention"
+	"hr-helper/internal/service/scheduling"
+	"hr-helper/internal/service/summary"
 	"hr-helper/internal/service/vacancy"
+	"hr-helper/internal/service/webhook"
 )
 
 type App struct {
@@ -67,16 +85,140 @@ func (a *App) Run(ctx context.Context) {
 	}
 
 	resumeStorage := objstorage.NewResumeStorage(config.String("s3.resume_bucket"), minioClient)
+	voiceStorage := objstorage.NewVoiceStorage(config.String("s3.resume_bucket"), minioClient)
 	candidateStorage := repository.NewCandidateRepository(pgPool)
 	vacancyStorage := repository.NewVacancyRepository(pgPool)
+	auditStorage := repository.NewAuditRepository(pgPool)
+	webhookStorage := repository.NewWebhookRepository(pgPool)
+	outboxStorage := repository.NewOutboxRepository(pgPool)
+	notificationStorage := repository.NewNotificationRepository(pgPool)
+	recruiterStorage := repository.NewRecruiterRepository(pgPool)
+	interviewSlotStorage := repository.NewInterviewSlotRepository(pgPool)
+	analyticsStorage := repository.NewAnalyticsRepository(pgPool)
+	exportStorage := repository.NewExportRepository(pgPool)
+	questionBankStorage := repository.NewQuestionBankRepository(pgPool)
+	transactor := dobby.NewPGXTransactor(pgPool)
 
 	yandexLLM := llm.NewYandex(llm.YandexConfig{
 		APIKey:   secret.GetString("YANDEX_LLM_API_KEY"),
 		FolderID: secret.GetString("YANDEX_FOLDER_ID"),
 	})
 
-	candidateService := candidate.NewService(config.String("tika.url"), candidateStorage, resumeStorage, vacancyStorage, yandexLLM)
-	vacancyService := vacancy.NewService(vacancyStorage, yandexLLM)
+	tikaClient := tika.NewClient(config.String("tika.url"))
+
+	err = a.cfg.STT.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid stt config: %v", err)
+	}
+	var recognizer vacancy.SpeechRecognizer
+	switch a.cfg.STT.Provider {
+	case STTProviderSpeechKit:
+		recognizer = stt.NewSpeechKit(stt.SpeechKitConfig{
+			APIKey:   secret.GetString("YANDEX_LLM_API_KEY"),
+			FolderID: secret.GetString("YANDEX_FOLDER_ID"),
+			Lang:     a.cfg.STT.Lang,
+		})
+	case STTProviderWhisper:
+		recognizer = stt.NewWhisper(stt.WhisperConfig{
+			URL:   a.cfg.STT.Whisper.URL,
+			Model: a.cfg.STT.Whisper.Model,
+			Lang:  a.cfg.STT.Lang,
+		})
+	}
+
+	var codeRunner vacancy.CodeRunner
+	if a.cfg.CodeRunner.URL != "" {
+		codeRunner = coderunner.NewPiston(coderunner.PistonConfig{
+			URL: a.cfg.CodeRunner.URL,
+		})
+	}
+
+	err = a.cfg.Webhooks.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid webhooks config: %v", err)
+	}
+	webhookService := webhook.NewService(a.cfg.Webhooks, webhookStorage, webhook.NewHTTPClient())
+	closer.AddNoErr(webhookService.Start(ctx))
+
+	err = a.cfg.Notifier.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid notifier config: %v", err)
+	}
+	telegramBot := telegram.NewBot(secret.GetString("TELEGRAM_BOT_TOKEN"), &http.Client{})
+	notifierService, err := notifier.NewService(a.cfg.Notifier, notificationStorage, candidateStorage, vacancyStorage, telegramBot)
+	if err != nil {
+		loggy.Fatalf("can't init notifier: %v", err)
+	}
+
+	err = a.cfg.Recruiter.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid recruiter config: %v", err)
+	}
+	err = a.cfg.SMTP.Validate(a.cfg.Outbox)
+	if err != nil {
+		loggy.Fatalf("invalid smtp config: %v", err)
+	}
+	mailer := mail.NewSMTPMailer(mail.SMTPConfig{
+		Addr:     a.cfg.SMTP.Addr,
+		Username: secret.GetString("SMTP_USERNAME"),
+		Password: secret.GetString("SMTP_PASSWORD"),
+		From:     a.cfg.SMTP.From,
+	})
+	recruiterService, err := recruiter.NewService(a.cfg.Recruiter, recruiterStorage, candidateStorage, mailer)
+	if err != nil {
+		loggy.Fatalf("can't init recruiter service: %v", err)
+	}
+	// digests go out only through a configured relay, alerts are guarded by SMTP.Validate
+	if a.cfg.SMTP.Addr != "" {
+		closer.AddNoErr(recruiterService.Start(ctx))
+	}
+
+	summaryService := summary.NewService(candidateStorage, resumeStorage, tikaClient, yandexLLM)
+	integrityService := integrity.NewService(candidateStorage, yandexLLM)
+
+	err = a.cfg.Outbox.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid outbox config: %v", err)
+	}
+	outboxService, err := outbox.NewService(a.cfg.Outbox, outboxStorage, map[string]outbox.Sink{
+		outbox.SinkWebhooks:  webhookService,
+		outbox.SinkLog:       outbox.LogSink{},
+		outbox.SinkTelegram:  notifierService,
+		outbox.SinkEmail:     recruiterService,
+		outbox.SinkSummary:   summaryService,
+		outbox.SinkIntegrity: integrityService,
+	})
+	if err != nil {
+		loggy.Fatalf("can't init outbox relay: %v", err)
+	}
+	closer.AddNoErr(outboxService.Start(ctx))
+
+	candidateService := candidate.NewService(tikaClient, candidateStorage, resumeStorage, voiceStorage, vacancyStorage, yandexLLM, outboxStorage, transactor, notificationStorage)
+	err = candidateService.MoveMergedFiles(ctx)
+	if err != nil {
+		// the rest is moved on the next start
+		loggy.Errorf("can't move files of merged candidates: %v", err)
+	}
+	vacancyService := vacancy.NewService(vacancyStorage, tikaClient, yandexLLM, candidateStorage, outboxStorage, transactor, voiceStorage, recognizer, codeRunner, questionBankStorage)
+
+	err = a.cfg.Retention.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid retention config: %v", err)
+	}
+	retentionService := retention.NewService(a.cfg.Retention, candidateStorage, resumeStorage, voiceStorage)
+	closer.AddNoErr(retentionService.Start(ctx))
+
+	auditService := audit.NewService(auditStorage)
+	err = a.cfg.Ranking.Validate()
+	if err != nil {
+		loggy.Fatalf("invalid ranking config: %v", err)
+	}
+	rankingService := ranking.NewService(a.cfg.Ranking, candidateStorage)
+	analyticsService := analytics.NewService(analyticsStorage)
+	exportService := export.NewService(exportStorage, vacancyStorage)
+	reportService := report.NewService(candidateStorage)
+
+	schedulingService := scheduling.NewService(interviewSlotStorage, candidateStorage, vacancyStorage, outboxStorage, transactor)
 
 	srv := httpapi.NewServer(
 		httpapi.ServerConfig{
@@ -85,9 +227,20 @@ func (a *App) Run(ctx context.Context) {
 			OAuthClientID:    os.Getenv("YANDEX_OAUTH_CLIENT_ID"),
 			OAuthSecret:      os.Getenv("YANDEX_OAUTH_CLIENT_SECRET"),
 			OAuthRedirectURL: config.String("oauth.redirect_url"),
+			AdminEmails:      a.cfg.Auth.AdminEmails,
 		},
 		candidateService,
 		vacancyService,
+		retentionService,
+		auditService,
+		webhookService,
+		notifierService,
+		recruiterService,
+		schedulingService,
+		rankingService,
+		analyticsService,
+		exportService,
+		reportService,
 	)
 	a.runHTTPServer(srv)
 
@@ -1,7 +1,99 @@
 package app
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"hr-helper/internal/service/notifier"
+	"hr-helper/internal/service/outbox"
+	"hr-helper/internal/service/ranking"
+	"hr-helper/internal/service/recruiter"
+	"hr-helper/internal/service/retention"
+	"hr-helper/internal/service/webhook"
+)
+
 type Config struct {
-	App Application `yaml:"app"`
+	App        Application      `yaml:"app"`
+	Retention  retention.Config `yaml:"retention"`
+	Webhooks   webhook.Config   `yaml:"webhooks"`
+	Outbox     outbox.Config    `yaml:"outbox"`
+	Notifier   notifier.Config  `yaml:"notifier"`
+	Recruiter  recruiter.Config `yaml:"recruiter"`
+	Ranking    ranking.Config   `yaml:"ranking"`
+	Auth       Auth             `yaml:"auth"`
+	STT        STT              `yaml:"stt"`
+	CodeRunner CodeRunner       `yaml:"code_runner"`
+	SMTP       SMTP             `yaml:"smtp"`
+}
+
+const (
+	STTProviderSpeechKit = "speechkit"
+	STTProviderWhisper   = "whisper"
+)
+
+// STT configures transcription of voice answers.
+type STT struct {
+	// Provider is either "speechkit" or "whisper", speechkit uses the Yandex LLM credentials.
+	Provider string  `yaml:"provider"`
+	Lang     string  `yaml:"lang"`
+	Whisper  Whisper `yaml:"whisper"`
+}
+
+// CodeRunner configures the Piston sandbox running code answers against test cases,
+// test cases are skipped when URL is empty.
+type CodeRunner struct {
+	URL string `yaml:"url"`
+}
+
+type Whisper struct {
+	URL   string `yaml:"url"`
+	Model string `yaml:"model"`
+}
+
+func (c STT) Validate() error {
+	switch c.Provider {
+	case STTProviderSpeechKit:
+	case STTProviderWhisper:
+		if c.Whisper.URL == "" {
+			return errors.New("whisper url is required")
+		}
+	default:
+		return fmt.Errorf("unknown provider %q", c.Provider)
+	}
+
+	if c.Lang == "" {
+		return errors.New("lang is required")
+	}
+
+	return nil
+}
+
+// SMTP configures the relay recruiter digests and alerts are sent through,
+// credentials come from SMTP_USERNAME and SMTP_PASSWORD.
+type SMTP struct {
+	Addr string `yaml:"addr"`
+	From string `yaml:"from"`
+}
+
+// Validate requires the relay only when emails can be sent, i.e. the email sink is enabled.
+func (c SMTP) Validate(outboxCfg outbox.Config) error {
+	if !slices.Contains(outboxCfg.Sinks, outbox.SinkEmail) {
+		return nil
+	}
+
+	if c.Addr == "" {
+		return fmt.Errorf("addr is required by the %q outbox sink", outbox.SinkEmail)
+	}
+	if c.From == "" {
+		return fmt.Errorf("from is required by the %q outbox sink", outbox.SinkEmail)
+	}
+
+	return nil
+}
+
+type Auth struct {
+	AdminEmails []string `yaml:"admin_emails"`
 }
 
 type Application struct{}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"testing"
+
+	"hr-helper/internal/service/outbox"
+)
+
+func TestSMTPValidate(t *testing.T) {
+	withEmail := outbox.Config{Sinks: []string{outbox.SinkLog, outbox.SinkEmail}}
+	withoutEmail := outbox.Config{Sinks: []string{outbox.SinkLog}}
+
+	tests := []struct {
+		name    string
+		smtp    SMTP
+		outbox  outbox.Config
+		wantErr bool
+	}{
+		{name: "configured", smtp: SMTP{Addr: "smtp:25", From: "hr@example.com"}, outbox: withEmail},
+		{name: "no addr", smtp: SMTP{From: "hr@example.com"}, outbox: withEmail, wantErr: true},
+		{name: "no from", smtp: SMTP{Addr: "smtp:25"}, outbox: withEmail, wantErr: true},
+		{name: "email sink disabled", outbox: withoutEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.smtp.Validate(tt.outbox); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
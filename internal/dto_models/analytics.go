@@ -0,0 +1,70 @@
+package dto_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FunnelStageResponse struct {
+	Name                   string  `json:"name"`
+	Count                  int     `json:"count"`
+	ConversionFromPrevious float64 `json:"conversion_from_previous"`
+	ConversionFromApplied  float64 `json:"conversion_from_applied"`
+}
+
+type StageDurationResponse struct {
+	Name          string   `json:"name"`
+	Samples       int      `json:"samples"`
+	MedianSeconds *float64 `json:"median_seconds"`
+}
+
+type HistogramBucketResponse struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+type QuestionDropOffResponse struct {
+	QuestionID  int64   `json:"question_id"`
+	Position    int     `json:"position"`
+	Content     string  `json:"content"`
+	Answered    int     `json:"answered"`
+	DropOff     int     `json:"drop_off"`
+	DropOffRate float64 `json:"drop_off_rate"`
+}
+
+type GetFunnelResponse struct {
+	VacancyID               uuid.UUID                 `json:"vacancy_id"`
+	From                    time.Time                 `json:"from"`
+	To                      time.Time                 `json:"to"`
+	Stages                  []FunnelStageResponse     `json:"stages"`
+	Durations               []StageDurationResponse   `json:"durations"`
+	ResumeScoreHistogram    []HistogramBucketResponse `json:"resume_score_histogram"`
+	InterviewScoreHistogram []HistogramBucketResponse `json:"interview_score_histogram"`
+	QuestionDropOffs        []QuestionDropOffResponse `json:"question_drop_offs"`
+}
+
+type QuestionStatsResponse struct {
+	QuestionID         int64    `json:"question_id"`
+	Position           int      `json:"position"`
+	Content            string   `json:"content"`
+	TimeLimit          int      `json:"time_limit"`
+	Answers            int      `json:"answers"`
+	MeanScore          float64  `json:"mean_score"`
+	MedianScore        float64  `json:"median_score"`
+	StdDevScore        float64  `json:"stddev_score"`
+	AvgTimeTaken       float64  `json:"avg_time_taken"`
+	AvgTimeShare       *float64 `json:"avg_time_share"`
+	TimeoutRate        *float64 `json:"timeout_rate"`
+	OutcomeCorrelation *float64 `json:"outcome_correlation"`
+	OutcomeSamples     int      `json:"outcome_samples"`
+	Flags              []string `json:"flags"`
+}
+
+type GetQuestionStatsResponse struct {
+	VacancyID uuid.UUID               `json:"vacancy_id"`
+	From      time.Time               `json:"from"`
+	To        time.Time               `json:"to"`
+	Questions []QuestionStatsResponse `json:"questions"`
+}
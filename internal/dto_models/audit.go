@@ -0,0 +1,23 @@
+package dto_models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type GetAuditEntryResponse struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	StatusCode int             `json:"status_code"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	RemoteAddr string          `json:"remote_addr"`
+	UserAgent  string          `json:"user_agent"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
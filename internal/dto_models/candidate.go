@@ -12,6 +12,59 @@ type CreateCandidateRequest struct {
 	FullName         string `json:"full_name"`
 	Phone            string `json:"phone"`
 	City             string `json:"city"`
+	Email            string `json:"email"`
+	PreferredContact string `json:"preferred_contact"`
+
+	Consents []CreateConsentRequest `json:"consents"`
+}
+
+type CreateConsentRequest struct {
+	Type          string `json:"type"`
+	PolicyVersion string `json:"policy_version"`
+	Channel       string `json:"channel"`
+}
+
+type WithdrawConsentRequest struct {
+	Type string `json:"type"`
+}
+
+type GetConsentResponse struct {
+	ID            int64      `json:"id"`
+	CandidateID   int64      `json:"candidate_id"`
+	Type          string     `json:"type"`
+	PolicyVersion string     `json:"policy_version"`
+	Channel       string     `json:"channel"`
+	GrantedAt     time.Time  `json:"granted_at"`
+	WithdrawnAt   *time.Time `json:"withdrawn_at"`
+}
+
+type UpdateCandidateRequest struct {
+	FullName         *string `json:"full_name"`
+	Phone            *string `json:"phone"`
+	City             *string `json:"city"`
+	Email            *string `json:"email"`
+	PreferredContact *string `json:"preferred_contact"`
+}
+
+type GetCandidateChangeResponse struct {
+	ID        int64     `json:"id"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MergeCandidatesRequest struct {
+	TargetID  int64   `json:"target_id"`
+	SourceIDs []int64 `json:"source_ids"`
+}
+
+type GetCandidateDuplicateResponse struct {
+	First          GetCandidateResponse `json:"first"`
+	Second         GetCandidateResponse `json:"second"`
+	Reasons        []string             `json:"reasons"`
+	NameSimilarity float64              `json:"name_similarity"`
 }
 
 type GetCandidateResponse struct {
@@ -21,6 +74,8 @@ type GetCandidateResponse struct {
 	FullName         string    `json:"full_name"`
 	Phone            string    `json:"phone"`
 	City             string    `json:"city"`
+	Email            string    `json:"email"`
+	PreferredContact string    `json:"preferred_contact"`
 	CreatedAt        time.Time `json:"created_at"`
 }
 
@@ -39,29 +94,81 @@ type GetMetaResponse struct {
 	VacancyID      uuid.UUID `json:"vacancy_id"`
 	InterviewScore *int      `json:"interview_score"`
 	Status         string    `json:"status"`
+	CurrentRound   int       `json:"current_round"`
 	IsArchived     bool      `json:"is_archived"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+type GetRoundResultResponse struct {
+	Round     int       `json:"round"`
+	Score     int       `json:"score"`
+	Passed    bool      `json:"passed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type GetCandidateVacancyInfoResponse struct {
 	Candidate       GetCandidateResponse       `json:"candidate"`
 	Vacancy         GetVacancyResponse         `json:"vacancy"`
 	Meta            GetMetaResponse            `json:"meta"`
 	ResumeScreening GetResumeScreeningResponse `json:"resume_screening"`
 	ResumeLink      string                     `json:"resume_link"`
+	// Summary is null until the interview is completed and summarized.
+	Summary      *GetCandidateSummaryResponse `json:"summary"`
+	RoundResults []GetRoundResultResponse     `json:"round_results"`
+}
+
+type GetCandidateSummaryResponse struct {
+	Strengths      []string  `json:"strengths"`
+	Weaknesses     []string  `json:"weaknesses"`
+	RedFlags       []string  `json:"red_flags"`
+	Recommendation string    `json:"recommendation"`
+	Rationale      string    `json:"rationale"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type GetAnswerResponse struct {
-	ID          int64     `json:"id"`
-	CandidateID int64     `json:"candidate_id"`
-	QuestionID  int64     `json:"question_id"`
-	Content     string    `json:"content"`
-	Score       int       `json:"score"`
-	TimeTaken   int64     `json:"time_taken"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64  `json:"id"`
+	CandidateID int64  `json:"candidate_id"`
+	QuestionID  int64  `json:"question_id"`
+	Content     string `json:"content"`
+	Score       int    `json:"score"`
+	TimeTaken   int64  `json:"time_taken"`
+	// Explanation justifies the score, MissingPoints lists what the answer lacks compared to the reference.
+	Explanation   string    `json:"explanation"`
+	MissingPoints []string  `json:"missing_points"`
+	Voice         bool      `json:"voice"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type GetCandidateQuestionAnswerResponse struct {
 	Question GetQuestionResponse `json:"question"`
 	Answer   GetAnswerResponse   `json:"answer"`
+	// Signals is null until the interview is completed and the signals are computed.
+	Signals *GetAnswerSignalsResponse `json:"signals"`
+}
+
+type GetAnswerSignalsResponse struct {
+	Risk                string   `json:"risk"`
+	Flags               []string `json:"flags"`
+	CharsPerMinute      float64  `json:"chars_per_minute"`
+	PeerSimilarity      float64  `json:"peer_similarity"`
+	SimilarAnswerID     *int64   `json:"similar_answer_id"`
+	ReferenceSimilarity float64  `json:"reference_similarity"`
+	// AILikelihood is null when the candidate hasn't consented to ai evaluation.
+	AILikelihood *float64  `json:"ai_likelihood"`
+	AIReason     string    `json:"ai_reason"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ErasePersonalDataRequest struct {
+	Reason string `json:"reason"`
+}
+
+type GetPersonalDataRequestResponse struct {
+	ID          int64      `json:"id"`
+	CandidateID int64      `json:"candidate_id"`
+	Type        string     `json:"type"`
+	Reason      string     `json:"reason"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
 }
@@ -0,0 +1,32 @@
+package dto_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateInterviewSlotRequest struct {
+	VacancyID *uuid.UUID `json:"vacancy_id"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	Location  string     `json:"location"`
+}
+
+type BookInterviewSlotRequest struct {
+	SlotID      int64     `json:"slot_id"`
+	CandidateID int64     `json:"candidate_id"`
+	VacancyID   uuid.UUID `json:"vacancy_id"`
+}
+
+type GetInterviewSlotResponse struct {
+	ID              int64      `json:"id"`
+	RecruiterEmail  string     `json:"recruiter_email"`
+	VacancyID       *uuid.UUID `json:"vacancy_id"`
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	Location        string     `json:"location"`
+	CandidateID     *int64     `json:"candidate_id"`
+	BookedVacancyID *uuid.UUID `json:"booked_vacancy_id"`
+	BookedAt        *time.Time `json:"booked_at"`
+}
@@ -0,0 +1,17 @@
+package dto_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetTelegramNotificationResponse struct {
+	ID        int64     `json:"id"`
+	EventID   uuid.UUID `json:"event_id"`
+	VacancyID uuid.UUID `json:"vacancy_id"`
+	Status    string    `json:"status"`
+	Text      string    `json:"text"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package dto_models
+
+import "time"
+
+type CreateBankQuestionRequest struct {
+	CreateQuestionRequest
+	// Tags are "kind:value" pairs where kind is skill, seniority or language.
+	Tags []string `json:"tags"`
+}
+
+type GetBankQuestionResponse struct {
+	ID               int64                  `json:"id"`
+	Type             string                 `json:"type"`
+	Content          string                 `json:"content"`
+	Reference        string                 `json:"reference"`
+	TimeLimit        int                    `json:"time_limit"`
+	Rubric           []RubricPointResponse  `json:"rubric"`
+	Options          []ChoiceOptionResponse `json:"options"`
+	NumericAnswer    *float64               `json:"numeric_answer"`
+	NumericTolerance float64                `json:"numeric_tolerance"`
+	CodeLanguage     string                 `json:"code_language"`
+	TestCases        []TestCaseResponse     `json:"test_cases"`
+	Tags             []string               `json:"tags"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+type AttachBankQuestionsRequest struct {
+	// Round is the vacancy round to append the questions to, the first one by default.
+	Round       int     `json:"round"`
+	QuestionIDs []int64 `json:"question_ids"`
+}
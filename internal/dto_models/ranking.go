@@ -0,0 +1,57 @@
+package dto_models
+
+import (
+	"github.com/google/uuid"
+)
+
+type RankingWeights struct {
+	Resume          float64           `json:"resume"`
+	Interview       float64           `json:"interview"`
+	Answers         float64           `json:"answers"`
+	Speed           float64           `json:"speed"`
+	QuestionWeights map[int64]float64 `json:"question_weights,omitempty"`
+}
+
+type RankedCandidateResponse struct {
+	Rank           int      `json:"rank"`
+	CandidateID    int64    `json:"candidate_id"`
+	FullName       string   `json:"full_name"`
+	City           string   `json:"city"`
+	Status         string   `json:"status"`
+	IsArchived     bool     `json:"is_archived"`
+	ResumeScore    *float64 `json:"resume_score"`
+	InterviewScore *float64 `json:"interview_score"`
+	AnswersScore   *float64 `json:"answers_score"`
+	SpeedScore     *float64 `json:"speed_score"`
+	Composite      float64  `json:"composite"`
+}
+
+type GetCandidateRankingResponse struct {
+	VacancyID  uuid.UUID                 `json:"vacancy_id"`
+	Weights    RankingWeights            `json:"weights"`
+	Candidates []RankedCandidateResponse `json:"candidates"`
+}
+
+type ComparedAnswerResponse struct {
+	CandidateID int64  `json:"candidate_id"`
+	Content     string `json:"content"`
+	Score       int    `json:"score"`
+	TimeTaken   int64  `json:"time_taken"`
+}
+
+type ComparedQuestionResponse struct {
+	QuestionID int64  `json:"question_id"`
+	Content    string `json:"content"`
+	Reference  string `json:"reference"`
+	TimeLimit  int    `json:"time_limit"`
+	Position   int    `json:"position"`
+	// Answers follow the order of candidates; nil means the candidate didn't answer.
+	Answers []*ComparedAnswerResponse `json:"answers"`
+}
+
+type GetCandidateComparisonResponse struct {
+	VacancyID  uuid.UUID                  `json:"vacancy_id"`
+	Weights    RankingWeights             `json:"weights"`
+	Candidates []RankedCandidateResponse  `json:"candidates"`
+	Questions  []ComparedQuestionResponse `json:"questions"`
+}
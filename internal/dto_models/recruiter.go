@@ -0,0 +1,23 @@
+package dto_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UpdateRecruiterSubscriptionRequest struct {
+	VacancyIDs    []uuid.UUID `json:"vacancy_ids"`
+	DailyDigest   bool        `json:"daily_digest"`
+	InstantAlerts bool        `json:"instant_alerts"`
+	AlertMinScore int         `json:"alert_min_score"`
+}
+
+type GetRecruiterSubscriptionResponse struct {
+	Email         string      `json:"email"`
+	VacancyIDs    []uuid.UUID `json:"vacancy_ids"`
+	DailyDigest   bool        `json:"daily_digest"`
+	InstantAlerts bool        `json:"instant_alerts"`
+	AlertMinScore int         `json:"alert_min_score"`
+	LastDigestAt  *time.Time  `json:"last_digest_at"`
+}
@@ -0,0 +1,21 @@
+package dto_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetRetentionRuleReportResponse struct {
+	Rule         string                            `json:"rule"`
+	Action       string                            `json:"action"`
+	Applications []GetRetentionApplicationResponse `json:"applications"`
+}
+
+type GetRetentionApplicationResponse struct {
+	CandidateID int64     `json:"candidate_id"`
+	VacancyID   uuid.UUID `json:"vacancy_id"`
+	Status      string    `json:"status"`
+	IsArchived  bool      `json:"is_archived"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -11,3 +11,12 @@ type ProcessInterviewRequest struct {
 	CandidateID int64     `json:"candidate_id"`
 	VacancyID   uuid.UUID `json:"vacancy_id"`
 }
+
+type ProcessInterviewResponse struct {
+	Round  int  `json:"round"`
+	Score  int  `json:"score"`
+	Passed bool `json:"passed"`
+	// NextRound is set when the candidate passed the round and may go on to the next one.
+	NextRound *int   `json:"next_round"`
+	Status    string `json:"status"`
+}
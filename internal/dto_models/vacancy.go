@@ -7,15 +7,71 @@ import (
 )
 
 type CreateVacancyRequest struct {
-	ID              uuid.UUID               `json:"id"`
-	Title           string                  `json:"title"`
-	KeyRequirements []string                `json:"key_requirements"`
-	Questions       []CreateQuestionRequest `json:"questions"`
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	KeyRequirements []string  `json:"key_requirements"`
+	// Questions make a single round vacancy, use either them or Rounds.
+	Questions []CreateQuestionRequest `json:"questions"`
+	Rounds    []CreateRoundRequest    `json:"rounds"`
+}
+
+type CreateRoundRequest struct {
+	Title     string                  `json:"title"`
+	Threshold int                     `json:"threshold"`
+	Questions []CreateQuestionRequest `json:"questions"`
+	// BankDraws add random bank questions to the round for each candidate on top of Questions.
+	BankDraws []BankDrawRequest `json:"bank_draws"`
 }
+
+type BankDrawRequest struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
 type CreateQuestionRequest struct {
+	// Type is one of text, single_choice, multiple_choice, numeric and code, text by default.
+	Type      string `json:"type"`
 	Content   string `json:"content"`
 	Reference string `json:"reference"`
 	TimeLimit int    `json:"time_limit"`
+	// Rubric is optional, answers are scored against it instead of the reference.
+	Rubric           []RubricPointRequest  `json:"rubric"`
+	Options          []ChoiceOptionRequest `json:"options"`
+	NumericAnswer    *float64              `json:"numeric_answer"`
+	NumericTolerance float64               `json:"numeric_tolerance"`
+	CodeLanguage     string                `json:"code_language"`
+	TestCases        []TestCaseRequest     `json:"test_cases"`
+}
+
+type ChoiceOptionRequest struct {
+	Text    string `json:"text"`
+	Correct bool   `json:"correct"`
+}
+
+type TestCaseRequest struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+type RubricPointRequest struct {
+	Point  string  `json:"point"`
+	Weight float64 `json:"weight"`
+}
+
+type ImportVacancyRequest struct {
+	Format    string     `json:"format"` // "text" | "html" | "hh"
+	Content   string     `json:"content"`
+	HHVacancy *HHVacancy `json:"hh_vacancy"`
+}
+
+type HHVacancy struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	KeySkills   []HHKeySkill `json:"key_skills"`
+}
+
+type HHKeySkill struct {
+	Name string `json:"name"`
 }
 
 type ArchiveVacancyRequest struct {
@@ -28,26 +84,101 @@ type CreateAnswerRequest struct {
 	QuestionID  int64  `json:"question_id"`
 	Content     string `json:"content"`
 	TimeTaken   int    `json:"time_taken"`
+	// SelectedOptions are indexes of the chosen options of a choice question, Content is ignored for them.
+	SelectedOptions []int `json:"selected_options"`
+}
+
+// CreateVoiceAnswerRequest holds the form fields sent along with the voice file.
+type CreateVoiceAnswerRequest struct {
+	CandidateID int64
+	QuestionID  int64
+	TimeTaken   int
 }
 
 type GetQuestionResponse struct {
-	ID        int64     `json:"id"`
-	VacancyID uuid.UUID `json:"vacancy_id"`
-	Content   string    `json:"content"`
-	Reference string    `json:"reference"`
-	TimeLimit int       `json:"time_limit"`
-	Position  int       `json:"position"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               int64                  `json:"id"`
+	VacancyID        uuid.UUID              `json:"vacancy_id"`
+	Type             string                 `json:"type"`
+	Content          string                 `json:"content"`
+	Reference        string                 `json:"reference"`
+	TimeLimit        int                    `json:"time_limit"`
+	Position         int                    `json:"position"`
+	Round            int                    `json:"round"`
+	Rubric           []RubricPointResponse  `json:"rubric"`
+	Options          []ChoiceOptionResponse `json:"options"`
+	NumericAnswer    *float64               `json:"numeric_answer"`
+	NumericTolerance float64                `json:"numeric_tolerance"`
+	CodeLanguage     string                 `json:"code_language"`
+	TestCases        []TestCaseResponse     `json:"test_cases"`
+	BankQuestionID   *int64                 `json:"bank_question_id"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+type ChoiceOptionResponse struct {
+	Text    string `json:"text"`
+	Correct bool   `json:"correct"`
+}
+
+type TestCaseResponse struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+type RubricPointResponse struct {
+	Point  string  `json:"point"`
+	Weight float64 `json:"weight"`
 }
 
 type GetVacancyWithQuestionsResponse struct {
 	ID              uuid.UUID             `json:"id"`
 	Title           string                `json:"title"`
 	KeyRequirements []string              `json:"key_requirements"`
+	Rounds          []GetRoundResponse    `json:"rounds"`
 	Questions       []GetQuestionResponse `json:"questions"`
 	CreatedAt       time.Time             `json:"created_at"`
 }
 
+type GetRoundResponse struct {
+	Number    int                `json:"number"`
+	Title     string             `json:"title"`
+	Threshold int                `json:"threshold"`
+	BankDraws []BankDrawResponse `json:"bank_draws"`
+}
+
+type BankDrawResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// GetCandidateQuestionResponse is a question as the bot shows it to candidates, without the answer key:
+// the reference, correct options, the numeric answer, expected outputs and the rubric.
+type GetCandidateQuestionResponse struct {
+	ID           int64                           `json:"id"`
+	VacancyID    uuid.UUID                       `json:"vacancy_id"`
+	Type         string                          `json:"type"`
+	Content      string                          `json:"content"`
+	TimeLimit    int                             `json:"time_limit"`
+	Position     int                             `json:"position"`
+	Round        int                             `json:"round"`
+	Options      []CandidateChoiceOptionResponse `json:"options"`
+	CodeLanguage string                          `json:"code_language"`
+	TestCases    []CandidateTestCaseResponse     `json:"test_cases"`
+	CreatedAt    time.Time                       `json:"created_at"`
+}
+
+type CandidateChoiceOptionResponse struct {
+	Text string `json:"text"`
+}
+
+type CandidateTestCaseResponse struct {
+	Input string `json:"input"`
+}
+
+type GetRoundQuestionsResponse struct {
+	Round     GetRoundResponse               `json:"round"`
+	Questions []GetCandidateQuestionResponse `json:"questions"`
+}
+
 type GetVacancyResponse struct {
 	ID              uuid.UUID `json:"id"`
 	Title           string    `json:"title"`
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEntry struct {
+	ID         int64           `db:"id"`
+	Actor      string          `db:"actor"`
+	Action     string          `db:"action"`
+	TargetType string          `db:"target_type"`
+	TargetID   string          `db:"target_id"`
+	Before     json.RawMessage `db:"before"`
+	After      json.RawMessage `db:"after"`
+	StatusCode int             `db:"status_code"`
+	Method     string          `db:"method"`
+	Path       string          `db:"path"`
+	RemoteAddr string          `db:"remote_addr"`
+	UserAgent  string          `db:"user_agent"`
+	RequestID  string          `db:"request_id"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
@@ -13,9 +13,32 @@ type Candidate struct {
 	FullName         string    `db:"full_name"`
 	Phone            string    `db:"phone"`
 	City             string    `db:"city"`
+	Email            string    `db:"email"`
+	PreferredContact string    `db:"preferred_contact"`
 	CreatedAt        time.Time `db:"created_at"`
 }
 
+const (
+	PreferredContactTelegram = "telegram"
+	PreferredContactPhone    = "phone"
+	PreferredContactEmail    = "email"
+)
+
+const (
+	CandidateChangeSourceBot = "bot"
+	CandidateChangeSourceHR  = "hr"
+)
+
+type CandidateChange struct {
+	ID          int64     `db:"id"`
+	CandidateID int64     `db:"candidate_id"`
+	Field       string    `db:"field"`
+	OldValue    string    `db:"old_value"`
+	NewValue    string    `db:"new_value"`
+	Source      string    `db:"source"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
 type CandidateDetail struct {
 	Candidate               Candidate
 	CandidateVacancyDetails []CandidateVacancyDetail
@@ -39,3 +62,45 @@ type ResumeScreening struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+type CandidateDuplicateReason string
+
+const (
+	CandidateDuplicateReasonTelegramID CandidateDuplicateReason = "telegram_id"
+	CandidateDuplicateReasonPhone      CandidateDuplicateReason = "phone"
+	CandidateDuplicateReasonFullName   CandidateDuplicateReason = "full_name"
+)
+
+type CandidateDuplicate struct {
+	First          Candidate
+	Second         Candidate
+	Reasons        []CandidateDuplicateReason
+	NameSimilarity float64
+}
+
+const (
+	PersonalDataRequestTypeExport  = "export"
+	PersonalDataRequestTypeErasure = "erasure"
+)
+
+type PersonalDataRequest struct {
+	ID          int64      `db:"id"`
+	CandidateID int64      `db:"candidate_id"`
+	Type        string     `db:"type"`
+	Reason      string     `db:"reason"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+type CandidatePersonalData struct {
+	Candidate     Candidate
+	Changes       []CandidateChange
+	Notifications []TelegramNotification
+	Vacancies     []CandidateVacancyPersonalData
+}
+
+type CandidateVacancyPersonalData struct {
+	Info    CandidateVacancyInfo
+	Answers []CandidateQuestionAnswer
+	Resume  []byte
+}
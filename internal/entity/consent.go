@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	ConsentTypePersonalData = "personal_data"
+	ConsentTypeAIEvaluation = "ai_evaluation"
+)
+
+const (
+	ConsentChannelTelegram = "telegram"
+	ConsentChannelWeb      = "web"
+)
+
+type Consent struct {
+	ID            int64      `db:"id"`
+	CandidateID   int64      `db:"candidate_id"`
+	Type          string     `db:"type"`
+	PolicyVersion string     `db:"policy_version"`
+	Channel       string     `db:"channel"`
+	GrantedAt     time.Time  `db:"granted_at"`
+	WithdrawnAt   *time.Time `db:"withdrawn_at"`
+}
+
+// HasActiveConsent reports whether the consent of the type is granted and not withdrawn.
+func HasActiveConsent(consents []Consent, consentType string) bool {
+	for _, consent := range consents {
+		if consent.Type == consentType && consent.WithdrawnAt == nil {
+			return true
+		}
+	}
+
+	return false
+}
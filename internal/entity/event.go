@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventTypeCandidateCreated   = "candidate.created"
+	EventTypeScreeningCompleted = "screening.completed"
+	EventTypeInterviewCompleted = "interview.completed"
+	EventTypeStatusChanged      = "status.changed"
+)
+
+var EventTypes = []string{
+	EventTypeCandidateCreated,
+	EventTypeScreeningCompleted,
+	EventTypeInterviewCompleted,
+	EventTypeStatusChanged,
+}
+
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// CandidateCreatedEvent carries no personal data: events outlive the erasure in the outbox and the delivery log.
+type CandidateCreatedEvent struct {
+	CandidateID int64 `json:"candidate_id"`
+}
+
+type ScreeningCompletedEvent struct {
+	CandidateID int64     `json:"candidate_id"`
+	VacancyID   uuid.UUID `json:"vacancy_id"`
+	Score       int       `json:"score"`
+	Status      string    `json:"status"`
+}
+
+type InterviewCompletedEvent struct {
+	CandidateID int64     `json:"candidate_id"`
+	VacancyID   uuid.UUID `json:"vacancy_id"`
+	Score       int       `json:"score"`
+	Status      string    `json:"status"`
+}
+
+type StatusChangedEvent struct {
+	CandidateID int64     `json:"candidate_id"`
+	VacancyID   uuid.UUID `json:"vacancy_id"`
+	OldStatus   string    `json:"old_status"`
+	NewStatus   string    `json:"new_status"`
+}
+
+// NewEvent wraps event data into an envelope with a fresh id.
+func NewEvent(eventType string, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	}, nil
+}
@@ -6,22 +6,96 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	QuestionTypeText           = "text"
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeNumeric        = "numeric"
+	QuestionTypeCode           = "code"
+)
+
 type Question struct {
 	ID        int64     `db:"id" json:"id"`
 	VacancyID uuid.UUID `db:"vacancy_id" json:"vacancy_id"`
-	Content   string    `db:"content" json:"content"`
-	Reference string    `db:"reference" json:"reference"`
-	TimeLimit int       `db:"time_limit" json:"time_limit"`
-	Position  int       `db:"position" json:"position"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Type defines how answers are scored: text and code ones by the LLM, choice and numeric ones deterministically.
+	Type      string `db:"type" json:"type"`
+	Content   string `db:"content" json:"content"`
+	Reference string `db:"reference" json:"reference"`
+	TimeLimit int    `db:"time_limit" json:"time_limit"`
+	Position  int    `db:"position" json:"position"`
+	// Round is the number of the interview round the question belongs to, starting from 1.
+	Round int `db:"round" json:"round"`
+	// Rubric replaces the reference in scoring when it's not empty.
+	Rubric []RubricPoint `db:"rubric" json:"rubric"`
+	// Options are set for choice questions.
+	Options []ChoiceOption `db:"options" json:"options"`
+	// NumericAnswer is set for numeric questions, answers within NumericTolerance of it are correct.
+	NumericAnswer    *float64 `db:"numeric_answer" json:"numeric_answer"`
+	NumericTolerance float64  `db:"numeric_tolerance" json:"numeric_tolerance"`
+	// CodeLanguage and TestCases are set for code questions, test cases are optional.
+	CodeLanguage string     `db:"code_language" json:"code_language"`
+	TestCases    []TestCase `db:"test_cases" json:"test_cases"`
+	// BankQuestionID is set for questions copied from the question bank.
+	BankQuestionID *int64 `db:"bank_question_id" json:"bank_question_id"`
+	// CandidateID is set for bank questions drawn for a single candidate, the rest are asked to everyone.
+	CandidateID *int64    `db:"candidate_id" json:"candidate_id"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 }
 
-type Answer struct {
-	ID          int64     `db:"id"`
+type ChoiceOption struct {
+	Text    string `json:"text"`
+	Correct bool   `json:"correct"`
+}
+
+// TestCase is passed to the submitted program as stdin, the trimmed stdout must match ExpectedOutput.
+type TestCase struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+// RubricPoint is a point expected in the answer, Weight is its share relative to the other points.
+type RubricPoint struct {
+	Point  string  `json:"point"`
+	Weight float64 `json:"weight"`
+}
+
+// InterviewRound is a stage of the vacancy interview with its own questions,
+// a candidate is offered the next round only after scoring at least Threshold in the previous one.
+type InterviewRound struct {
+	VacancyID uuid.UUID `db:"vacancy_id" json:"vacancy_id"`
+	Number    int       `db:"number" json:"number"`
+	Title     string    `db:"title" json:"title"`
+	Threshold int       `db:"threshold" json:"threshold"`
+	// BankDraws add random bank questions to the round, drawn separately for each candidate.
+	BankDraws []BankDraw `db:"bank_draws" json:"bank_draws"`
+}
+
+// BankDraw is a rule to draw Count random bank questions with the Tag.
+type BankDraw struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+type RoundResult struct {
 	CandidateID int64     `db:"candidate_id"`
-	QuestionID  int64     `db:"question_id"`
-	Content     string    `db:"content"`
+	VacancyID   uuid.UUID `db:"vacancy_id"`
+	Round       int       `db:"round"`
 	Score       int       `db:"score"`
-	TimeTaken   int64     `db:"time_taken"`
+	Passed      bool      `db:"passed"`
 	CreatedAt   time.Time `db:"created_at"`
 }
+
+type Answer struct {
+	ID          int64  `db:"id"`
+	CandidateID int64  `db:"candidate_id"`
+	QuestionID  int64  `db:"question_id"`
+	Content     string `db:"content"`
+	Score       int    `db:"score"`
+	TimeTaken   int64  `db:"time_taken"`
+	// Explanation justifies the score, MissingPoints lists what the answer lacks compared to the reference.
+	Explanation   string   `db:"explanation"`
+	MissingPoints []string `db:"missing_points"`
+	// Voice is set when the content is a transcript of a voice answer.
+	Voice     bool      `db:"voice"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewSlot struct {
+	ID              int64      `db:"id"`
+	RecruiterEmail  string     `db:"recruiter_email"`
+	VacancyID       *uuid.UUID `db:"vacancy_id"`
+	StartsAt        time.Time  `db:"starts_at"`
+	EndsAt          time.Time  `db:"ends_at"`
+	Location        string     `db:"location"`
+	CandidateID     *int64     `db:"candidate_id"`
+	BookedVacancyID *uuid.UUID `db:"booked_vacancy_id"`
+	BookedAt        *time.Time `db:"booked_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+func (s InterviewSlot) IsBooked() bool {
+	return s.CandidateID != nil
+}
@@ -16,13 +16,17 @@ const (
 	CandidateVacancyStatusScreeningFailed = "screening_failed"
 	CandidateVacancyStatusInterviewOk     = "interview_ok"
 	CandidateVacancyStatusInterviewFailed = "interview_failed"
+	// CandidateVacancyStatusInterviewScheduled means the candidate booked a live interview with a recruiter.
+	CandidateVacancyStatusInterviewScheduled = "interview_scheduled"
 )
 
 type Meta struct {
-	CandidateID    int64                  `db:"candidate_id"`
-	VacancyID      uuid.UUID              `db:"vacancy_id"`
+	CandidateID int64     `db:"candidate_id"`
+	VacancyID   uuid.UUID `db:"vacancy_id"`
+	// InterviewScore is the mean score of the completed interview rounds.
 	InterviewScore *int                   `db:"interview_score"`
 	Status         CandidateVacancyStatus `db:"status"`
+	CurrentRound   int                    `db:"current_round"`
 	UpdatedAt      time.Time              `db:"updated_at"`
 	IsArchived     bool                   `db:"is_archived"`
 }
@@ -34,9 +38,14 @@ type CandidateVacancyInfo struct {
 	ResumeScreening ResumeScreening
 	Questions       []Question
 	ResumeLink      string
+	// Summary is nil until the interview is completed and summarized.
+	Summary      *CandidateSummary
+	RoundResults []RoundResult
 }
 
 type CandidateQuestionAnswer struct {
 	Question Question
 	Answer   Answer
+	// Signals are set once the interview is completed and the signals are computed.
+	Signals *AnswerSignals
 }
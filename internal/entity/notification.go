@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TelegramNotification struct {
+	ID          int64     `db:"id"`
+	EventID     uuid.UUID `db:"event_id"`
+	CandidateID int64     `db:"candidate_id"`
+	VacancyID   uuid.UUID `db:"vacancy_id"`
+	TelegramID  int64     `db:"telegram_id"`
+	Status      string    `db:"status"`
+	Text        string    `db:"text"`
+	Error       string    `db:"error"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Tags of bank questions are "kind:value" pairs of these kinds.
+const (
+	TagKindSkill     = "skill"
+	TagKindSeniority = "seniority"
+	TagKindLanguage  = "language"
+)
+
+// BankQuestion is a question shared across vacancies, it's copied into a vacancy when attached or drawn.
+type BankQuestion struct {
+	ID               int64          `db:"id"`
+	Type             string         `db:"type"`
+	Content          string         `db:"content"`
+	Reference        string         `db:"reference"`
+	TimeLimit        int            `db:"time_limit"`
+	Rubric           []RubricPoint  `db:"rubric"`
+	Options          []ChoiceOption `db:"options"`
+	NumericAnswer    *float64       `db:"numeric_answer"`
+	NumericTolerance float64        `db:"numeric_tolerance"`
+	CodeLanguage     string         `db:"code_language"`
+	TestCases        []TestCase     `db:"test_cases"`
+	Tags             []string       `db:"tags"`
+	CreatedAt        time.Time      `db:"created_at"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecruiterSubscription holds email notification settings of a recruiter identified by their login email.
+type RecruiterSubscription struct {
+	Email         string      `db:"email"`
+	VacancyIDs    []uuid.UUID `db:"vacancy_ids"`
+	DailyDigest   bool        `db:"daily_digest"`
+	InstantAlerts bool        `db:"instant_alerts"`
+	AlertMinScore int         `db:"alert_min_score"`
+	LastDigestAt  *time.Time  `db:"last_digest_at"`
+	UpdatedAt     time.Time   `db:"updated_at"`
+}
+
+// Watches reports whether the recruiter is interested in the vacancy.
+func (s RecruiterSubscription) Watches(vacancyID uuid.UUID) bool {
+	if len(s.VacancyIDs) == 0 {
+		return true
+	}
+
+	for _, id := range s.VacancyIDs {
+		if id == vacancyID {
+			return true
+		}
+	}
+
+	return false
+}
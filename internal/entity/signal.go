@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	AnswerRiskLow    = "low"
+	AnswerRiskMedium = "medium"
+	AnswerRiskHigh   = "high"
+)
+
+const (
+	// AnswerFlagFastTyping means the answer was typed faster than a person can type, e.g. pasted.
+	AnswerFlagFastTyping = "fast_typing"
+	// AnswerFlagPeerCopy means the answer is close to another candidate's answer to the same question.
+	AnswerFlagPeerCopy = "peer_copy"
+	// AnswerFlagReferenceCopy means the answer is close to the reference text.
+	AnswerFlagReferenceCopy = "reference_copy"
+	// AnswerFlagAIGenerated means the LLM judged the answer likely to be AI-generated.
+	AnswerFlagAIGenerated = "ai_generated"
+)
+
+// AnswerSignals are anti-cheating signals computed for an answer after the interview is completed.
+type AnswerSignals struct {
+	AnswerID int64 `db:"answer_id"`
+	// CharsPerMinute is 0 for voice answers and answers without time taken.
+	CharsPerMinute float64 `db:"chars_per_minute"`
+	// PeerSimilarity is the estimated Jaccard similarity to the closest answer of another candidate, SimilarAnswerID.
+	PeerSimilarity      float64  `db:"peer_similarity"`
+	SimilarAnswerID     *int64   `db:"similar_answer_id"`
+	ReferenceSimilarity float64  `db:"reference_similarity"`
+	AILikelihood        *float64 `db:"ai_likelihood"`
+	AIReason            string   `db:"ai_reason"`
+	Flags               []string `db:"flags"`
+	// Risk is low without flags, medium with one flag and high with more.
+	Risk      string    `db:"risk"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
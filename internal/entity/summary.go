@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CandidateRecommendationHire   = "hire"
+	CandidateRecommendationNoHire = "no_hire"
+)
+
+// CandidateSummary is the LLM narrative over the resume and all interview answers of an application.
+type CandidateSummary struct {
+	CandidateID    int64     `db:"candidate_id"`
+	VacancyID      uuid.UUID `db:"vacancy_id"`
+	Strengths      []string  `db:"strengths"`
+	Weaknesses     []string  `db:"weaknesses"`
+	RedFlags       []string  `db:"red_flags"`
+	Recommendation string    `db:"recommendation"`
+	Rationale      string    `db:"rationale"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
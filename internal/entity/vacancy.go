@@ -17,6 +17,7 @@ type VacancyWithQuestion struct {
 	ID              uuid.UUID
 	Title           string
 	KeyRequirements []string
+	Rounds          []InterviewRound
 	Questions       []Question
 	CreatedAt       time.Time
 }
@@ -0,0 +1,185 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+// defaultAnalyticsPeriod is used when the request doesn't set the range start.
+const defaultAnalyticsPeriod = 30 * 24 * time.Hour
+
+func (s *Server) getFunnel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseAnalyticsFilter(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.analyticsService.Funnel(ctx, filter)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serviceFunnelReportToDTO(report))
+}
+
+func (s *Server) getQuestionStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseAnalyticsFilter(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stats, err := s.analyticsService.QuestionStats(ctx, filter)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	res := dto_models.GetQuestionStatsResponse{
+		VacancyID: filter.VacancyID,
+		From:      filter.From,
+		To:        filter.To,
+		Questions: make([]dto_models.QuestionStatsResponse, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		res.Questions = append(res.Questions, serviceQuestionStatsToDTO(stat))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// parseAnalyticsFilter reads vacancy_id and the optional RFC 3339 from and to, defaulting to the last 30 days.
+func parseAnalyticsFilter(r *http.Request) (service_models.AnalyticsFilter, error) {
+	vacancyID, err := uuid.Parse(r.URL.Query().Get("vacancy_id"))
+	if err != nil {
+		return service_models.AnalyticsFilter{}, errors.New("invalid vacancy id")
+	}
+
+	from, err := parseTimeQuery(r, "from")
+	if err != nil {
+		return service_models.AnalyticsFilter{}, err
+	}
+	to, err := parseTimeQuery(r, "to")
+	if err != nil {
+		return service_models.AnalyticsFilter{}, err
+	}
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultAnalyticsPeriod)
+	}
+
+	return service_models.AnalyticsFilter{
+		VacancyID: vacancyID,
+		From:      from,
+		To:        to,
+	}, nil
+}
+
+func serviceFunnelReportToDTO(report service_models.FunnelReport) dto_models.GetFunnelResponse {
+	res := dto_models.GetFunnelResponse{
+		VacancyID:               report.Filter.VacancyID,
+		From:                    report.Filter.From,
+		To:                      report.Filter.To,
+		Stages:                  make([]dto_models.FunnelStageResponse, 0, len(report.Stages)),
+		Durations:               make([]dto_models.StageDurationResponse, 0, len(report.Durations)),
+		ResumeScoreHistogram:    serviceHistogramToDTO(report.ResumeScoreHistogram),
+		InterviewScoreHistogram: serviceHistogramToDTO(report.InterviewScoreHistogram),
+		QuestionDropOffs:        make([]dto_models.QuestionDropOffResponse, 0, len(report.QuestionDropOffs)),
+	}
+
+	for _, stage := range report.Stages {
+		res.Stages = append(res.Stages, dto_models.FunnelStageResponse{
+			Name:                   stage.Name,
+			Count:                  stage.Count,
+			ConversionFromPrevious: stage.ConversionFromPrevious,
+			ConversionFromApplied:  stage.ConversionFromApplied,
+		})
+	}
+
+	for _, duration := range report.Durations {
+		res.Durations = append(res.Durations, dto_models.StageDurationResponse{
+			Name:          duration.Name,
+			Samples:       duration.Samples,
+			MedianSeconds: duration.MedianSeconds,
+		})
+	}
+
+	for _, dropOff := range report.QuestionDropOffs {
+		res.QuestionDropOffs = append(res.QuestionDropOffs, dto_models.QuestionDropOffResponse{
+			QuestionID:  dropOff.QuestionID,
+			Position:    dropOff.Position,
+			Content:     dropOff.Content,
+			Answered:    dropOff.Answered,
+			DropOff:     dropOff.DropOff,
+			DropOffRate: dropOff.DropOffRate,
+		})
+	}
+
+	return res
+}
+
+func serviceHistogramToDTO(buckets []service_models.HistogramBucket) []dto_models.HistogramBucketResponse {
+	res := make([]dto_models.HistogramBucketResponse, 0, len(buckets))
+	for _, bucket := range buckets {
+		res = append(res, dto_models.HistogramBucketResponse{
+			From:  bucket.From,
+			To:    bucket.To,
+			Count: bucket.Count,
+		})
+	}
+
+	return res
+}
+
+func serviceQuestionStatsToDTO(stat service_models.QuestionStats) dto_models.QuestionStatsResponse {
+	flags := stat.Flags
+	if flags == nil {
+		flags = []string{}
+	}
+
+	return dto_models.QuestionStatsResponse{
+		QuestionID:         stat.Question.ID,
+		Position:           stat.Question.Position,
+		Content:            stat.Question.Content,
+		TimeLimit:          stat.Question.TimeLimit,
+		Answers:            stat.Answers,
+		MeanScore:          stat.MeanScore,
+		MedianScore:        stat.MedianScore,
+		StdDevScore:        stat.StdDevScore,
+		AvgTimeTaken:       stat.AvgTimeTaken,
+		AvgTimeShare:       stat.AvgTimeShare,
+		TimeoutRate:        stat.TimeoutRate,
+		OutcomeCorrelation: stat.OutcomeCorrelation,
+		OutcomeSamples:     stat.OutcomeSamples,
+		Flags:              flags,
+	}
+}
@@ -0,0 +1,241 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service/auther"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	anonymousActor = "anonymous"
+)
+
+type auditRecordKey struct{}
+
+// auditRecord is filled by handlers with details known only after the request is handled.
+type auditRecord struct {
+	targetType string
+	targetID   string
+	before     any
+	after      any
+}
+
+// candidateAuditState keeps personal data out of the append-only audit log: a candidate is recorded
+// by id and the names of the changed fields only.
+type candidateAuditState struct {
+	ID            int64    `json:"id"`
+	ChangedFields []string `json:"changed_fields,omitempty"`
+}
+
+func changedCandidateFields(before entity.Candidate, after entity.Candidate) []string {
+	var fields []string
+	for _, f := range []struct {
+		name          string
+		before, after string
+	}{
+		{"telegram_username", before.TelegramUsername, after.TelegramUsername},
+		{"full_name", before.FullName, after.FullName},
+		{"phone", before.Phone, after.Phone},
+		{"city", before.City, after.City},
+		{"email", before.Email, after.Email},
+		{"preferred_contact", before.PreferredContact, after.PreferredContact},
+	} {
+		if f.before != f.after {
+			fields = append(fields, f.name)
+		}
+	}
+
+	return fields
+}
+
+func setAuditTarget(ctx context.Context, targetType string, targetID string) {
+	if rec, ok := ctx.Value(auditRecordKey{}).(*auditRecord); ok {
+		rec.targetType = targetType
+		rec.targetID = targetID
+	}
+}
+
+func setAuditChange(ctx context.Context, before any, after any) {
+	if rec, ok := ctx.Value(auditRecordKey{}).(*auditRecord); ok {
+		rec.before = before
+		rec.after = after
+	}
+}
+
+// audit writes the request to the audit log after it is handled, successful or not.
+func (s *Server) audit(action string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &auditRecord{}
+			ctx := context.WithValue(r.Context(), auditRecordKey{}, rec)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			entry := entity.AuditEntry{
+				Actor:      anonymousActor,
+				Action:     action,
+				TargetType: rec.targetType,
+				TargetID:   rec.targetID,
+				Before:     marshalAuditState(rec.before),
+				After:      marshalAuditState(rec.after),
+				StatusCode: ww.Status(),
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				RemoteAddr: r.RemoteAddr,
+				UserAgent:  r.UserAgent(),
+				RequestID:  middleware.GetReqID(ctx),
+			}
+			if email, err := actorEmail(r); err == nil {
+				entry.Actor = email
+			}
+
+			err := s.auditService.Record(context.WithoutCancel(ctx), entry)
+			if err != nil {
+				loggy.Errorf("can't record audit entry for %s: %v", action, err)
+			}
+		})
+	}
+}
+
+// requireUser lets through any signed-in user: vacancies have no owners, so recruiters share their data.
+func (s *Server) requireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := actorEmail(r)
+		if err != nil {
+			httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email, err := actorEmail(r)
+		if err != nil {
+			httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+			return
+		}
+		if !s.adminEmails[email] {
+			httpErrorf(w, http.StatusForbidden, "%s is not an admin", email)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func actorEmail(r *http.Request) (string, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		cookie, err := r.Cookie("jwt_token")
+		if err != nil {
+			return "", err
+		}
+		token = cookie.Value
+	}
+
+	return auther.ParseJWTEmail(token)
+}
+
+func marshalAuditState(state any) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		loggy.Errorf("can't marshal audit state: %v", err)
+		return nil
+	}
+
+	return data
+}
+
+func (s *Server) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	filter := service_models.AuditFilter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+	}
+
+	for key, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := query.Get(key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				httpErrorf(w, http.StatusBadRequest, "invalid %s: %v", key, err)
+				return
+			}
+			*dst = &t
+		}
+	}
+
+	for key, dst := range map[string]*uint64{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if v := query.Get(key); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				httpErrorf(w, http.StatusBadRequest, "invalid %s: %v", key, err)
+				return
+			}
+			*dst = n
+		}
+	}
+
+	entries, err := s.auditService.Find(ctx, filter)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityAuditEntriesToDTO(entries))
+}
+
+func entityAuditEntriesToDTO(es []entity.AuditEntry) []dto_models.GetAuditEntryResponse {
+	res := make([]dto_models.GetAuditEntryResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetAuditEntryResponse{
+			ID:         e.ID,
+			Actor:      e.Actor,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			Before:     e.Before,
+			After:      e.After,
+			StatusCode: e.StatusCode,
+			Method:     e.Method,
+			Path:       e.Path,
+			RemoteAddr: e.RemoteAddr,
+			UserAgent:  e.UserAgent,
+			RequestID:  e.RequestID,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+func (s *Server) grantConsents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	var in []dto_models.CreateConsentRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	err = s.candidateService.GrantConsents(ctx, candidateID, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) withdrawConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	var in dto_models.WithdrawConsentRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	err = s.candidateService.WithdrawConsent(ctx, candidateID, in.Type)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpErrorf(w, http.StatusNotFound, "no active %s consent: %v", in.Type, err)
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle withdrawal: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getConsents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	consents, err := s.candidateService.GetConsents(ctx, candidateID)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityConsentsToDTO(consents))
+}
+
+func entityConsentsToDTO(es []entity.Consent) []dto_models.GetConsentResponse {
+	res := make([]dto_models.GetConsentResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetConsentResponse{
+			ID:            e.ID,
+			CandidateID:   e.CandidateID,
+			Type:          e.Type,
+			PolicyVersion: e.PolicyVersion,
+			Channel:       e.Channel,
+			GrantedAt:     e.GrantedAt,
+			WithdrawnAt:   e.WithdrawnAt,
+		})
+	}
+
+	return res
+}
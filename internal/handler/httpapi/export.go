@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service/export"
+	"hr-helper/internal/service_models"
+)
+
+func (s *Server) exportCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := service_models.CandidateExportFilter{
+		Status:          r.URL.Query().Get("status"),
+		IncludeArchived: r.URL.Query().Get("include_archived") == "true",
+	}
+	if v := r.URL.Query().Get("vacancy_id"); v != "" {
+		vacancyID, err := uuid.Parse(v)
+		if err != nil {
+			httpError(w, http.StatusBadRequest, "invalid vacancy id")
+			return
+		}
+		filter.VacancyID = &vacancyID
+	}
+	setAuditChange(ctx, nil, r.URL.Query())
+
+	format := exportFormat(r)
+	aw, err := newAttachmentWriter(w, format, fmt.Sprintf("candidates-%s", time.Now().Format(time.DateOnly)))
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = s.exportService.ExportCandidates(ctx, aw, format, filter)
+	aw.handleError(err)
+}
+
+func (s *Server) exportAnswerMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpError(w, http.StatusBadRequest, "invalid vacancy id")
+		return
+	}
+	setAuditTarget(ctx, "vacancy", vacancyID.String())
+
+	format := exportFormat(r)
+	aw, err := newAttachmentWriter(w, format, fmt.Sprintf("answers-%s", vacancyID))
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = s.exportService.ExportAnswerMatrix(ctx, aw, format, vacancyID)
+	aw.handleError(err)
+}
+
+// exportFormat reads the format query parameter, CSV by default.
+func exportFormat(r *http.Request) string {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return export.FormatCSV
+	}
+
+	return format
+}
+
+// attachmentWriter sends the file headers with the first written bytes, so that errors
+// which happen before the export starts still get a regular JSON response.
+type attachmentWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func newAttachmentWriter(w http.ResponseWriter, format string, name string) (*attachmentWriter, error) {
+	contentType, err := export.ContentType(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachmentWriter{
+		w:           w,
+		contentType: contentType,
+		filename:    name + "." + format,
+	}, nil
+}
+
+func (aw *attachmentWriter) Write(p []byte) (int, error) {
+	if !aw.started {
+		aw.started = true
+		aw.w.Header().Set("Content-Type", aw.contentType)
+		aw.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, aw.filename))
+		aw.w.WriteHeader(http.StatusOK)
+	}
+
+	return aw.w.Write(p)
+}
+
+func (aw *attachmentWriter) handleError(err error) {
+	switch {
+	case err == nil:
+	case aw.started:
+		// the status is already sent, the client gets a truncated file
+		loggy.Errorf("can't finish export %s: %v", aw.filename, err)
+	case errors.Is(err, inerrors.ErrNotFound):
+		httpError(aw.w, http.StatusNotFound, err.Error())
+	case errors.Is(err, inerrors.ErrInvalidInput):
+		httpError(aw.w, http.StatusBadRequest, err.Error())
+	default:
+		httpErrorf(aw.w, http.StatusInternalServerError, "can't handle export: %v", err)
+	}
+}
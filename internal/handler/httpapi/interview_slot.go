@@ -0,0 +1,313 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+func (s *Server) createInterviewSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email, err := actorEmail(r)
+	if err != nil {
+		httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+		return
+	}
+
+	var in dto_models.CreateInterviewSlotRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	slot, err := s.schedulingService.CreateSlot(ctx, email, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "interview_slot", strconv.FormatInt(slot.ID, 10))
+	setAuditChange(ctx, nil, entityInterviewSlotToDTO(slot))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(entityInterviewSlotToDTO(slot))
+}
+
+func (s *Server) getInterviewSlots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email, err := actorEmail(r)
+	if err != nil {
+		httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+		return
+	}
+
+	from, err := parseTimeQuery(r, "from")
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseTimeQuery(r, "to")
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	slots, err := s.schedulingService.GetRecruiterSlots(ctx, email, from, to)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityInterviewSlotsToDTO(slots))
+}
+
+func (s *Server) deleteInterviewSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email, err := actorEmail(r)
+	if err != nil {
+		httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+		return
+	}
+
+	slotID, err := strconv.ParseInt(chi.URLParam(r, "slot-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid slot id: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "interview_slot", strconv.FormatInt(slotID, 10))
+
+	err = s.schedulingService.DeleteSlot(ctx, slotID, email)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle deletion: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getAvailableInterviewSlots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, vacancyID, ok := parseCandidateVacancyIDs(w, r)
+	if !ok {
+		return
+	}
+
+	slots, err := s.schedulingService.GetAvailableSlots(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityInterviewSlotsToDTO(slots))
+}
+
+func (s *Server) getBookedInterviewSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, vacancyID, ok := parseCandidateVacancyIDs(w, r)
+	if !ok {
+		return
+	}
+
+	slot, err := s.schedulingService.GetBookedSlot(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, "no booked slot")
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityInterviewSlotToDTO(slot))
+}
+
+func (s *Server) bookInterviewSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var in dto_models.BookInterviewSlotRequest
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	slot, err := s.schedulingService.BookSlot(ctx, in)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle booking: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityInterviewSlotToDTO(slot))
+}
+
+func (s *Server) getInterviewSlotCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	slotID, err := strconv.ParseInt(chi.URLParam(r, "slot-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid slot id: %v", err)
+		return
+	}
+
+	calendar, err := s.schedulingService.GetSlotCalendar(ctx, slotID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	writeCalendar(w, slotID, calendar)
+}
+
+func (s *Server) getBookedInterviewSlotCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	slotID, err := strconv.ParseInt(chi.URLParam(r, "slot-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid slot id: %v", err)
+		return
+	}
+	candidateID, vacancyID, ok := parseCandidateVacancyIDs(w, r)
+	if !ok {
+		return
+	}
+
+	calendar, err := s.schedulingService.GetBookedSlotCalendar(ctx, slotID, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	writeCalendar(w, slotID, calendar)
+}
+
+func writeCalendar(w http.ResponseWriter, slotID int64, calendar []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8; method=REQUEST")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, slotID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(calendar)
+}
+
+func parseCandidateVacancyIDs(w http.ResponseWriter, r *http.Request) (int64, uuid.UUID, bool) {
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return 0, uuid.Nil, false
+	}
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid vacancy id")
+		return 0, uuid.Nil, false
+	}
+
+	return candidateID, vacancyID, true
+}
+
+// parseTimeQuery parses an optional RFC 3339 query parameter, returning zero time if it's absent.
+func parseTimeQuery(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+func entityInterviewSlotToDTO(e entity.InterviewSlot) dto_models.GetInterviewSlotResponse {
+	return dto_models.GetInterviewSlotResponse{
+		ID:              e.ID,
+		RecruiterEmail:  e.RecruiterEmail,
+		VacancyID:       e.VacancyID,
+		StartsAt:        e.StartsAt,
+		EndsAt:          e.EndsAt,
+		Location:        e.Location,
+		CandidateID:     e.CandidateID,
+		BookedVacancyID: e.BookedVacancyID,
+		BookedAt:        e.BookedAt,
+	}
+}
+
+func entityInterviewSlotsToDTO(es []entity.InterviewSlot) []dto_models.GetInterviewSlotResponse {
+	res := make([]dto_models.GetInterviewSlotResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, entityInterviewSlotToDTO(e))
+	}
+
+	return res
+}
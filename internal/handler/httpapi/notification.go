@@ -0,0 +1,50 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+)
+
+func (s *Server) getCandidateNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	notifications, err := s.notifierService.GetTelegramNotifications(ctx, candidateID)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityTelegramNotificationsToDTO(notifications))
+}
+
+func entityTelegramNotificationsToDTO(es []entity.TelegramNotification) []dto_models.GetTelegramNotificationResponse {
+	res := make([]dto_models.GetTelegramNotificationResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetTelegramNotificationResponse{
+			ID:        e.ID,
+			EventID:   e.EventID,
+			VacancyID: e.VacancyID,
+			Status:    e.Status,
+			Text:      e.Text,
+			Error:     e.Error,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,181 @@
+package httpapi
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+)
+
+func (s *Server) exportPersonalData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	data, err := s.candidateService.ExportPersonalData(ctx, candidateID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle export: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="candidate-%d.zip"`, candidateID))
+	w.WriteHeader(http.StatusOK)
+
+	err = writePersonalDataZIP(w, data)
+	if err != nil {
+		loggy.Errorf("can't write personal data zip: %v", err)
+	}
+}
+
+func (s *Server) erasePersonalData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	var in dto_models.ErasePersonalDataRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	setAuditTarget(ctx, "candidate", strconv.FormatInt(candidateID, 10))
+	setAuditChange(ctx, nil, in)
+
+	err = s.candidateService.ErasePersonalData(ctx, candidateID, in.Reason)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle erasure: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getPersonalDataRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	requests, err := s.candidateService.GetPersonalDataRequests(ctx, candidateID)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityPersonalDataRequestsToDTO(requests))
+}
+
+func writePersonalDataZIP(w io.Writer, data entity.CandidatePersonalData) error {
+	zw := zip.NewWriter(w)
+
+	err := writeZIPJSON(zw, "profile.json", entityCandidateToDTO(data.Candidate))
+	if err != nil {
+		return err
+	}
+
+	err = writeZIPJSON(zw, "changes.json", entityCandidateChangesToDTO(data.Changes))
+	if err != nil {
+		return err
+	}
+
+	err = writeZIPJSON(zw, "notifications.json", entityTelegramNotificationsToDTO(data.Notifications))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range data.Vacancies {
+		dir := fmt.Sprintf("vacancies/%s/", v.Info.Vacancy.ID)
+
+		err = writeZIPJSON(zw, dir+"screening.json", entityCandidateVacancyInfoToDTO(v.Info))
+		if err != nil {
+			return err
+		}
+
+		err = writeZIPJSON(zw, dir+"answers.json", entityCandidateQuestionAnswersToDTO(v.Answers))
+		if err != nil {
+			return err
+		}
+
+		if len(v.Resume) == 0 {
+			continue
+		}
+
+		f, err := zw.Create(dir + "resume.pdf")
+		if err != nil {
+			return fmt.Errorf("can't create resume file: %w", err)
+		}
+		_, err = f.Write(v.Resume)
+		if err != nil {
+			return fmt.Errorf("can't write resume: %w", err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeZIPJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("can't create %s: %w", name, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(v)
+	if err != nil {
+		return fmt.Errorf("can't write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func entityPersonalDataRequestsToDTO(es []entity.PersonalDataRequest) []dto_models.GetPersonalDataRequestResponse {
+	res := make([]dto_models.GetPersonalDataRequestResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetPersonalDataRequestResponse{
+			ID:          e.ID,
+			CandidateID: e.CandidateID,
+			Type:        e.Type,
+			Reason:      e.Reason,
+			CreatedAt:   e.CreatedAt,
+			CompletedAt: e.CompletedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,201 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+func (s *Server) createBankQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var in dto_models.CreateBankQuestionRequest
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	id, err := s.vacancyService.CreateBankQuestion(ctx, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
+		return
+	}
+	setAuditTarget(ctx, "bank_question", strconv.FormatInt(id, 10))
+	setAuditChange(ctx, nil, in)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id": id,
+	})
+}
+
+// findBankQuestions accepts repeated tag params, a question must have all of them.
+func (s *Server) findBankQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	filter := service_models.BankQuestionFilter{
+		Tags:  query["tag"],
+		Query: query.Get("q"),
+		Type:  query.Get("type"),
+	}
+
+	for key, dst := range map[string]*uint64{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if v := query.Get(key); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				httpErrorf(w, http.StatusBadRequest, "invalid %s: %v", key, err)
+				return
+			}
+			*dst = n
+		}
+	}
+
+	questions, err := s.vacancyService.FindBankQuestions(ctx, filter)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	res := make([]dto_models.GetBankQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		res = append(res, entityBankQuestionToDTO(question))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) getBankQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	questionID, err := strconv.ParseInt(chi.URLParam(r, "question-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid question id: %v", err)
+		return
+	}
+
+	question, err := s.vacancyService.GetBankQuestion(ctx, questionID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityBankQuestionToDTO(question))
+}
+
+func (s *Server) deleteBankQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	questionID, err := strconv.ParseInt(chi.URLParam(r, "question-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid question id: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "bank_question", strconv.FormatInt(questionID, 10))
+	before, err := s.vacancyService.GetBankQuestion(ctx, questionID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't get bank question: %v", err)
+		return
+	}
+	setAuditChange(ctx, entityBankQuestionToDTO(before), nil)
+
+	err = s.vacancyService.DeleteBankQuestion(ctx, questionID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle deletion: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) attachBankQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid vacancy id")
+		return
+	}
+
+	var in dto_models.AttachBankQuestionsRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	setAuditTarget(ctx, "vacancy", vacancyID.String())
+	setAuditChange(ctx, nil, in)
+
+	err = s.vacancyService.AttachBankQuestions(ctx, vacancyID, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle attach: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func entityBankQuestionToDTO(e entity.BankQuestion) dto_models.GetBankQuestionResponse {
+	return dto_models.GetBankQuestionResponse{
+		ID:               e.ID,
+		Type:             e.Type,
+		Content:          e.Content,
+		Reference:        e.Reference,
+		TimeLimit:        e.TimeLimit,
+		Rubric:           entityRubricToDTO(e.Rubric),
+		Options:          entityOptionsToDTO(e.Options),
+		NumericAnswer:    e.NumericAnswer,
+		NumericTolerance: e.NumericTolerance,
+		CodeLanguage:     e.CodeLanguage,
+		TestCases:        entityTestCasesToDTO(e.TestCases),
+		Tags:             e.Tags,
+		CreatedAt:        e.CreatedAt,
+	}
+}
@@ -0,0 +1,238 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+func (s *Server) getCandidateRanking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid vacancy id")
+		return
+	}
+
+	weights, err := s.parseRankingWeights(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := service_models.RankingFilter{
+		Weights:         weights,
+		IncludeArchived: r.URL.Query().Get("include_archived") == "true",
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			httpErrorf(w, http.StatusBadRequest, "invalid limit: %v", err)
+			return
+		}
+	}
+
+	ranking, err := s.rankingService.RankCandidates(ctx, vacancyID, filter)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(dto_models.GetCandidateRankingResponse{
+		VacancyID:  vacancyID,
+		Weights:    serviceRankingWeightsToDTO(ranking.Weights),
+		Candidates: serviceRankedCandidatesToDTO(ranking.Candidates),
+	})
+}
+
+func (s *Server) getCandidateComparison(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid vacancy id")
+		return
+	}
+
+	var candidateIDs []int64
+	for _, rawID := range strings.Split(r.URL.Query().Get("candidate_ids"), ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(rawID), 10, 64)
+		if err != nil {
+			httpErrorf(w, http.StatusBadRequest, "invalid candidate id %q", rawID)
+			return
+		}
+		candidateIDs = append(candidateIDs, id)
+	}
+
+	weights, err := s.parseRankingWeights(r)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comparison, err := s.rankingService.CompareCandidates(ctx, vacancyID, candidateIDs, weights)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serviceCandidateComparisonToDTO(vacancyID, comparison))
+}
+
+// parseRankingWeights overrides the default weights with w_resume, w_interview, w_answers, w_speed
+// and question_weights=<question id>:<weight>,... query parameters.
+func (s *Server) parseRankingWeights(r *http.Request) (service_models.RankingWeights, error) {
+	weights := s.rankingService.DefaultWeights()
+	query := r.URL.Query()
+
+	for name, weight := range map[string]*float64{
+		"w_resume":    &weights.Resume,
+		"w_interview": &weights.Interview,
+		"w_answers":   &weights.Answers,
+		"w_speed":     &weights.Speed,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := parseWeight(value)
+		if err != nil {
+			return service_models.RankingWeights{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		*weight = parsed
+	}
+
+	if value := query.Get("question_weights"); value != "" {
+		weights.QuestionWeights = make(map[int64]float64)
+		for _, pair := range strings.Split(value, ",") {
+			rawID, rawWeight, ok := strings.Cut(pair, ":")
+			if !ok {
+				return service_models.RankingWeights{}, fmt.Errorf("invalid question weight %q", pair)
+			}
+
+			questionID, err := strconv.ParseInt(rawID, 10, 64)
+			if err != nil {
+				return service_models.RankingWeights{}, fmt.Errorf("invalid question id %q", rawID)
+			}
+			weight, err := parseWeight(rawWeight)
+			if err != nil {
+				return service_models.RankingWeights{}, fmt.Errorf("invalid weight of question %d", questionID)
+			}
+			weights.QuestionWeights[questionID] = weight
+		}
+	}
+
+	return weights, nil
+}
+
+func parseWeight(value string) (float64, error) {
+	weight, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", value)
+	}
+
+	return weight, nil
+}
+
+func serviceRankingWeightsToDTO(weights service_models.RankingWeights) dto_models.RankingWeights {
+	return dto_models.RankingWeights{
+		Resume:          weights.Resume,
+		Interview:       weights.Interview,
+		Answers:         weights.Answers,
+		Speed:           weights.Speed,
+		QuestionWeights: weights.QuestionWeights,
+	}
+}
+
+func serviceRankedCandidatesToDTO(candidates []service_models.RankedCandidate) []dto_models.RankedCandidateResponse {
+	res := make([]dto_models.RankedCandidateResponse, 0, len(candidates))
+
+	for _, c := range candidates {
+		res = append(res, dto_models.RankedCandidateResponse{
+			Rank:           c.Rank,
+			CandidateID:    c.Candidate.ID,
+			FullName:       c.Candidate.FullName,
+			City:           c.Candidate.City,
+			Status:         string(c.Meta.Status),
+			IsArchived:     c.Meta.IsArchived,
+			ResumeScore:    c.ResumeScore,
+			InterviewScore: c.InterviewScore,
+			AnswersScore:   c.AnswersScore,
+			SpeedScore:     c.SpeedScore,
+			Composite:      c.Composite,
+		})
+	}
+
+	return res
+}
+
+func serviceCandidateComparisonToDTO(vacancyID uuid.UUID, comparison service_models.CandidateComparison) dto_models.GetCandidateComparisonResponse {
+	res := dto_models.GetCandidateComparisonResponse{
+		VacancyID:  vacancyID,
+		Weights:    serviceRankingWeightsToDTO(comparison.Weights),
+		Candidates: serviceRankedCandidatesToDTO(comparison.Candidates),
+		Questions:  make([]dto_models.ComparedQuestionResponse, 0, len(comparison.Questions)),
+	}
+
+	for _, q := range comparison.Questions {
+		question := dto_models.ComparedQuestionResponse{
+			QuestionID: q.Question.ID,
+			Content:    q.Question.Content,
+			Reference:  q.Question.Reference,
+			TimeLimit:  q.Question.TimeLimit,
+			Position:   q.Question.Position,
+			Answers:    make([]*dto_models.ComparedAnswerResponse, 0, len(comparison.Candidates)),
+		}
+
+		for _, c := range comparison.Candidates {
+			answer, ok := q.Answers[c.Candidate.ID]
+			if !ok {
+				question.Answers = append(question.Answers, nil)
+				continue
+			}
+
+			question.Answers = append(question.Answers, &dto_models.ComparedAnswerResponse{
+				CandidateID: c.Candidate.ID,
+				Content:     answer.Content,
+				Score:       answer.Score,
+				TimeTaken:   answer.TimeTaken,
+			})
+		}
+
+		res.Questions = append(res.Questions, question)
+	}
+
+	return res
+}
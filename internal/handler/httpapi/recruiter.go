@@ -0,0 +1,81 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+func (s *Server) getRecruiterSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email, err := actorEmail(r)
+	if err != nil {
+		httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+		return
+	}
+
+	subscription, err := s.recruiterService.GetSubscription(ctx, email)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityRecruiterSubscriptionToDTO(subscription))
+}
+
+func (s *Server) updateRecruiterSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email, err := actorEmail(r)
+	if err != nil {
+		httpErrorf(w, http.StatusUnauthorized, "unauthorized: %v", err)
+		return
+	}
+
+	var in dto_models.UpdateRecruiterSubscriptionRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	setAuditTarget(ctx, "recruiter_subscription", email)
+	before, err := s.recruiterService.GetSubscription(ctx, email)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't get subscription: %v", err)
+		return
+	}
+
+	subscription, err := s.recruiterService.UpdateSubscription(ctx, email, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle update: %v", err)
+		return
+	}
+	setAuditChange(ctx, entityRecruiterSubscriptionToDTO(before), entityRecruiterSubscriptionToDTO(subscription))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityRecruiterSubscriptionToDTO(subscription))
+}
+
+func entityRecruiterSubscriptionToDTO(e entity.RecruiterSubscription) dto_models.GetRecruiterSubscriptionResponse {
+	return dto_models.GetRecruiterSubscriptionResponse{
+		Email:         e.Email,
+		VacancyIDs:    e.VacancyIDs,
+		DailyDigest:   e.DailyDigest,
+		InstantAlerts: e.InstantAlerts,
+		AlertMinScore: e.AlertMinScore,
+		LastDigestAt:  e.LastDigestAt,
+	}
+}
@@ -0,0 +1,35 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"hr-helper/internal/inerrors"
+)
+
+func (s *Server) getCandidateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, vacancyID, ok := parseCandidateVacancyIDs(w, r)
+	if !ok {
+		return
+	}
+	setAuditTarget(ctx, "candidate", strconv.FormatInt(candidateID, 10))
+
+	doc, err := s.reportService.CandidateReport(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle report: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="candidate-%d-%s.pdf"`, candidateID, vacancyID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
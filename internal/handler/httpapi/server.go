@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,18 +20,49 @@ import (
 	"hr-helper/internal/entity"
 	"hr-helper/internal/inerrors"
 	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service/analytics"
+	"hr-helper/internal/service/audit"
 	"hr-helper/internal/service/auther"
 	"hr-helper/internal/service/candidate"
+	"hr-helper/internal/service/export"
+	"hr-helper/internal/service/notifier"
+	"hr-helper/internal/service/ranking"
+	"hr-helper/internal/service/recruiter"
+	"hr-helper/internal/service/report"
+	"hr-helper/internal/service/retention"
+	"hr-helper/internal/service/scheduling"
 	"hr-helper/internal/service/vacancy"
+	"hr-helper/internal/service/webhook"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	maxImportDocumentSize = 10 << 20
+	maxVoiceAnswerSize    = 20 << 20
+
+	// maxUploadFormOverhead leaves room for the multipart headers and the other form fields.
+	maxUploadFormOverhead = 1 << 20
+	maxUploadMemory       = 32 << 20
 )
 
 type Server struct {
 	httpServer  *http.Server
 	oauthConf   *oauth2.Config
 	frontendURL string
-
-	candidateService *candidate.Service
-	vacancyService   *vacancy.Service
+	adminEmails map[string]bool
+
+	candidateService  *candidate.Service
+	vacancyService    *vacancy.Service
+	retentionService  *retention.Service
+	auditService      *audit.Service
+	webhookService    *webhook.Service
+	notifierService   *notifier.Service
+	recruiterService  *recruiter.Service
+	schedulingService *scheduling.Service
+	rankingService    *ranking.Service
+	analyticsService  *analytics.Service
+	exportService     *export.Service
+	reportService     *report.Service
 }
 
 type ServerConfig struct {
@@ -39,9 +71,29 @@ type ServerConfig struct {
 	OAuthClientID    string
 	OAuthSecret      string
 	OAuthRedirectURL string
+	AdminEmails      []string
 }
 
-func NewServer(cfg ServerConfig, candidateService *candidate.Service, vacancyService *vacancy.Service) *Server {
+func NewServer(
+	cfg ServerConfig,
+	candidateService *candidate.Service,
+	vacancyService *vacancy.Service,
+	retentionService *retention.Service,
+	auditService *audit.Service,
+	webhookService *webhook.Service,
+	notifierService *notifier.Service,
+	recruiterService *recruiter.Service,
+	schedulingService *scheduling.Service,
+	rankingService *ranking.Service,
+	analyticsService *analytics.Service,
+	exportService *export.Service,
+	reportService *report.Service,
+) *Server {
+	adminEmails := make(map[string]bool, len(cfg.AdminEmails))
+	for _, email := range cfg.AdminEmails {
+		adminEmails[email] = true
+	}
+
 	s := &Server{
 		httpServer: &http.Server{
 			Addr: cfg.Addr,
@@ -56,9 +108,20 @@ func NewServer(cfg ServerConfig, candidateService *candidate.Service, vacancySer
 			},
 			Scopes: []string{"login:email"},
 		},
-		frontendURL:      cfg.FrontendURL,
-		candidateService: candidateService,
-		vacancyService:   vacancyService,
+		frontendURL:       cfg.FrontendURL,
+		adminEmails:       adminEmails,
+		candidateService:  candidateService,
+		vacancyService:    vacancyService,
+		retentionService:  retentionService,
+		auditService:      auditService,
+		webhookService:    webhookService,
+		notifierService:   notifierService,
+		recruiterService:  recruiterService,
+		schedulingService: schedulingService,
+		rankingService:    rankingService,
+		analyticsService:  analyticsService,
+		exportService:     exportService,
+		reportService:     reportService,
 	}
 	s.initHandlers()
 
@@ -70,34 +133,83 @@ func (s *Server) initHandlers() {
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"}, // разрешённые домены
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300, // кэширование preflight запроса в секундах
 	}))
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 
 	r.Post("/api/bot/v1/candidate", s.createCandidate)
+	r.Patch("/api/bot/v1/candidate/{candidate-id}", s.updateCandidateByBot)
+	r.Post("/api/bot/v1/candidate/{candidate-id}/consents", s.grantConsents)
+	r.Post("/api/bot/v1/candidate/{candidate-id}/consents/withdraw", s.withdrawConsent)
 	r.Get("/api/bot/v1/candidates/by-tg-id/{telegram-id}", s.getCandidateByTelegramID)
 	r.Post("/api/bot/v1/screening/process", s.processResume)
 	r.Get("/api/bot/v1/questions/{vacancy-id}", s.getQuestionsByVacancyID)
 	r.Post("/api/bot/v1/answer", s.createAnswer)
+	r.Post("/api/bot/v1/answer/voice", s.createVoiceAnswer)
 	r.Post("/api/bot/v1/interview/process", s.processInterview)
+	r.Get("/api/bot/v1/interview/{candidate-id}/{vacancy-id}/questions", s.getRoundQuestions)
 	r.Get("/api/bot/v1/meta/{candidate-id}/{vacancy-id}", s.getMeta)
+	r.Get("/api/bot/v1/interview-slots/available/{candidate-id}/{vacancy-id}", s.getAvailableInterviewSlots)
+	r.Get("/api/bot/v1/interview-slots/booked/{candidate-id}/{vacancy-id}", s.getBookedInterviewSlot)
+	r.Post("/api/bot/v1/interview-slots/book", s.bookInterviewSlot)
+	r.Get("/api/bot/v1/interview-slots/{slot-id}/ics/{candidate-id}/{vacancy-id}", s.getBookedInterviewSlotCalendar)
+
+	r.With(s.audit("vacancy.create")).Post("/api/v1/vacancy", s.createVacancy)
+	r.With(s.audit("application.archive")).Post("/api/v1/vacancy/archive", s.archiveVacancy)
+	r.With(s.audit("vacancy.import")).Post("/api/v1/vacancy/import", s.importVacancy)
+	r.With(s.audit("vacancy.attach_bank_questions")).Post("/api/v1/vacancy/{vacancy-id}/bank-questions", s.attachBankQuestions)
+
+	r.With(s.audit("bank_question.create")).Post("/api/v1/question-bank", s.createBankQuestion)
+	r.Get("/api/v1/question-bank", s.findBankQuestions)
+	r.Get("/api/v1/question-bank/{question-id}", s.getBankQuestion)
+	r.With(s.audit("bank_question.delete")).Delete("/api/v1/question-bank/{question-id}", s.deleteBankQuestion)
 
-	r.Post("/api/v1/vacancy", s.createVacancy)
-	r.Post("/api/v1/vacancy/archive", s.archiveVacancy)
+	r.With(s.audit("candidate.merge")).Post("/api/v1/candidates/merge", s.mergeCandidates)
+	r.With(s.audit("candidate.update")).Patch("/api/v1/candidate/{candidate-id}", s.updateCandidateByHR)
+	r.With(s.audit("candidate.erase_personal_data")).Post("/api/v1/candidate/{candidate-id}/personal-data/erase", s.erasePersonalData)
 
-	r.Delete("/api/v1/vacancy/{vacancy-id}", s.deleteVacancy)
-	r.Delete("/api/_private/v1/candidate/{candidate-id}", s.deleteCandidate)
+	r.With(s.audit("vacancy.delete")).Delete("/api/v1/vacancy/{vacancy-id}", s.deleteVacancy)
+	r.With(s.audit("candidate.delete")).Delete("/api/_private/v1/candidate/{candidate-id}", s.deleteCandidate)
 
 	r.Get("/api/v1/screening/result/{candidate-id}/{vacancy-id}", s.getScreeningResult)
 	r.Get("/api/v1/candidate-vacancy-infos", s.getCandidateVacancyInfos)
+	r.Get("/api/v1/candidates/duplicates", s.getCandidateDuplicates)
+	r.Get("/api/v1/candidate/{candidate-id}/history", s.getCandidateHistory)
+	r.Get("/api/v1/candidate/{candidate-id}/consents", s.getConsents)
+	r.Get("/api/v1/candidate/{candidate-id}/personal-data/export", s.exportPersonalData)
+	r.Get("/api/v1/candidate/{candidate-id}/personal-data/requests", s.getPersonalDataRequests)
+	r.Get("/api/v1/candidate/{candidate-id}/notifications", s.getCandidateNotifications)
 	r.Get("/api/v1/vacancies", s.getVacancies)
 	r.Get("/api/v1/vacancy/{vacancy-id}", s.getVacancyWithQuestionsByID)
+	r.Get("/api/v1/vacancy/{vacancy-id}/ranking", s.getCandidateRanking)
+	r.Get("/api/v1/vacancy/{vacancy-id}/comparison", s.getCandidateComparison)
 	r.Get("/api/v1/candidate-vacancy-info/{candidate-id}/{vacancy-id}", s.getCandidateVacancyInfo)
+	r.With(s.audit("candidate.report")).Get("/api/v1/candidate-vacancy-info/{candidate-id}/{vacancy-id}/report", s.getCandidateReport)
 	r.Get("/api/v1/candidate/answers/{candidate-id}/{vacancy-id}", s.getCandidateAnswers)
+	r.With(s.audit("candidate.export")).Get("/api/v1/export/candidates", s.exportCandidates)
+	r.With(s.audit("vacancy.export_answers")).Get("/api/v1/export/vacancy/{vacancy-id}/answers", s.exportAnswerMatrix)
+
+	r.Get("/api/v1/retention/dry-run", s.getRetentionDryRun)
+	r.Get("/api/v1/analytics/funnel", s.getFunnel)
+	r.With(s.requireUser).Get("/api/v1/analytics/questions", s.getQuestionStats)
+	r.Get("/api/v1/recruiter/subscription", s.getRecruiterSubscription)
+	r.With(s.audit("recruiter_subscription.update")).Put("/api/v1/recruiter/subscription", s.updateRecruiterSubscription)
+	r.With(s.audit("interview_slot.create")).Post("/api/v1/interview-slots", s.createInterviewSlot)
+	r.Get("/api/v1/interview-slots", s.getInterviewSlots)
+	r.With(s.audit("interview_slot.delete")).Delete("/api/v1/interview-slots/{slot-id}", s.deleteInterviewSlot)
+	r.Get("/api/v1/interview-slots/{slot-id}/ics", s.getInterviewSlotCalendar)
+	r.With(s.requireAdmin).Get("/api/v1/audit", s.getAuditLog)
+
+	r.With(s.requireAdmin, s.audit("webhook.create")).Post("/api/v1/webhooks", s.createWebhookSubscription)
+	r.With(s.requireAdmin).Get("/api/v1/webhooks", s.getWebhookSubscriptions)
+	r.With(s.requireAdmin, s.audit("webhook.delete")).Delete("/api/v1/webhooks/{webhook-id}", s.deleteWebhookSubscription)
+	r.With(s.requireAdmin).Get("/api/v1/webhooks/{webhook-id}/deliveries", s.getWebhookDeliveries)
+	r.With(s.requireAdmin, s.audit("webhook.redeliver")).Post("/api/v1/webhooks/deliveries/{delivery-id}/redeliver", s.redeliverWebhook)
 
 	r.Get("/api/v1/login", s.login)
 	r.Get("/api/v1/auth", s.auth)
@@ -244,7 +356,20 @@ func (s *Server) createCandidate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := s.candidateService.Create(ctx, in)
+	var id int64
+	if r.URL.Query().Get("upsert") == "true" {
+		id, err = s.candidateService.Upsert(ctx, in)
+	} else {
+		id, err = s.candidateService.Create(ctx, in)
+	}
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrAlreadyExists) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
 		return
@@ -257,6 +382,130 @@ func (s *Server) createCandidate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) updateCandidateByBot(w http.ResponseWriter, r *http.Request) {
+	s.updateCandidate(w, r, entity.CandidateChangeSourceBot)
+}
+
+func (s *Server) updateCandidateByHR(w http.ResponseWriter, r *http.Request) {
+	s.updateCandidate(w, r, entity.CandidateChangeSourceHR)
+}
+
+func (s *Server) updateCandidate(w http.ResponseWriter, r *http.Request, source string) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	var in dto_models.UpdateCandidateRequest
+	err = json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	setAuditTarget(ctx, "candidate", strconv.FormatInt(candidateID, 10))
+	before, err := s.candidateService.GetByID(ctx, candidateID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't get candidate: %v", err)
+		return
+	}
+
+	candidate, err := s.candidateService.Update(ctx, candidateID, in, source)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrAlreadyExists) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle update: %v", err)
+		return
+	}
+	setAuditChange(ctx, nil, candidateAuditState{ID: candidateID, ChangedFields: changedCandidateFields(before, candidate)})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityCandidateToDTO(candidate))
+}
+
+func (s *Server) getCandidateHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	changes, err := s.candidateService.GetChanges(ctx, candidateID)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityCandidateChangesToDTO(changes))
+}
+
+func (s *Server) getCandidateDuplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	duplicates, err := s.candidateService.FindDuplicates(ctx)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityCandidateDuplicatesToDTO(duplicates))
+}
+
+func (s *Server) mergeCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var in dto_models.MergeCandidatesRequest
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	setAuditTarget(ctx, "candidate", strconv.FormatInt(in.TargetID, 10))
+	setAuditChange(ctx, nil, in)
+
+	err = s.candidateService.Merge(ctx, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle merge: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
 func (s *Server) deleteCandidate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -266,6 +515,16 @@ func (s *Server) deleteCandidate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setAuditTarget(ctx, "candidate", strconv.FormatInt(candidateID, 10))
+	before, err := s.candidateService.GetByID(ctx, candidateID)
+	if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+		httpErrorf(w, http.StatusInternalServerError, "can't get candidate: %v", err)
+		return
+	}
+	if err == nil {
+		setAuditChange(ctx, candidateAuditState{ID: before.ID}, nil)
+	}
+
 	err = s.candidateService.Delete(ctx, candidateID)
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle delete: %v", err)
@@ -287,10 +546,16 @@ func (s *Server) createVacancy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id, err := s.vacancyService.CreateVacancy(ctx, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
 		return
 	}
+	setAuditTarget(ctx, "vacancy", id.String())
+	setAuditChange(ctx, nil, in)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -299,6 +564,55 @@ func (s *Server) createVacancy(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) importVacancy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var (
+		draft service_models.VacancyDraft
+		err   error
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if !parseUpload(w, r, maxImportDocumentSize) {
+			return
+		}
+
+		file, header, fileErr := r.FormFile("file")
+		if fileErr != nil {
+			httpErrorf(w, http.StatusBadRequest, "invalid file: %v", fileErr)
+			return
+		}
+		defer file.Close()
+
+		document, ok := readUpload(w, file, maxImportDocumentSize)
+		if !ok {
+			return
+		}
+
+		draft, err = s.vacancyService.ImportVacancyDocument(ctx, document, header.Header.Get("Content-Type"))
+	} else {
+		var in dto_models.ImportVacancyRequest
+		err = json.NewDecoder(r.Body).Decode(&in)
+		if err != nil {
+			httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+			return
+		}
+
+		draft, err = s.vacancyService.ImportVacancy(ctx, in)
+	}
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle import: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serviceVacancyDraftToDTO(draft))
+}
+
 func (s *Server) archiveVacancy(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -309,11 +623,21 @@ func (s *Server) archiveVacancy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setAuditTarget(ctx, "application", fmt.Sprintf("%d/%s", in.CandidateID, in.VacancyID))
+	before, err := s.candidateService.GetMeta(ctx, in.CandidateID, in.VacancyID)
+	if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+		httpErrorf(w, http.StatusInternalServerError, "can't get meta: %v", err)
+		return
+	}
+
 	err = s.vacancyService.ArchiveVacancy(ctx, in.CandidateID, in.VacancyID)
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle archive: %v", err)
 		return
 	}
+	after := before
+	after.IsArchived = true
+	setAuditChange(ctx, entityMetaToDTO(before), entityMetaToDTO(after))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -330,6 +654,18 @@ func (s *Server) createAnswer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id, err := s.vacancyService.CreateAnswer(ctx, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
 		return
@@ -342,6 +678,71 @@ func (s *Server) createAnswer(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) createVoiceAnswer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !parseUpload(w, r, maxVoiceAnswerSize) {
+		return
+	}
+
+	var (
+		in  dto_models.CreateVoiceAnswerRequest
+		err error
+	)
+	in.CandidateID, err = strconv.ParseInt(r.FormValue("candidate_id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+	in.QuestionID, err = strconv.ParseInt(r.FormValue("question_id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid question id: %v", err)
+		return
+	}
+	in.TimeTaken, err = strconv.Atoi(r.FormValue("time_taken"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid time taken: %v", err)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid file: %v", err)
+		return
+	}
+	defer file.Close()
+
+	audio, ok := readUpload(w, file, maxVoiceAnswerSize)
+	if !ok {
+		return
+	}
+
+	id, transcript, err := s.vacancyService.CreateVoiceAnswer(ctx, in, audio, header.Header.Get("Content-Type"))
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":         id,
+		"transcript": transcript,
+	})
+}
+
 func (s *Server) getCandidateByTelegramID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -353,7 +754,7 @@ func (s *Server) getCandidateByTelegramID(w http.ResponseWriter, r *http.Request
 
 	candidate, err := s.candidateService.GetByTelegramID(ctx, telegramID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -384,7 +785,7 @@ func (s *Server) getMeta(w http.ResponseWriter, r *http.Request) {
 
 	resumeScreeningResult, err := s.candidateService.GetMeta(ctx, candidateID, vacancyID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -415,7 +816,7 @@ func (s *Server) getScreeningResult(w http.ResponseWriter, r *http.Request) {
 
 	resumeScreeningResult, err := s.candidateService.GetResumeScreening(ctx, candidateID, vacancyID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -447,9 +848,9 @@ func (s *Server) getQuestionsByVacancyID(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	var resp []dto_models.GetQuestionResponse
+	var resp []dto_models.GetCandidateQuestionResponse
 	for _, question := range questions {
-		resp = append(resp, entityQuestionToDTO(question))
+		resp = append(resp, entityQuestionToCandidateDTO(question))
 	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
@@ -465,8 +866,12 @@ func (s *Server) processResume(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = s.candidateService.ScoreCandidateResume(ctx, in)
+	if errors.Is(err, inerrors.ErrConsentRequired) {
+		httpError(w, http.StatusForbidden, err.Error())
+		return
+	}
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -488,9 +893,17 @@ func (s *Server) processInterview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = s.vacancyService.ScoreCandidateInterview(ctx, in)
+	res, err := s.vacancyService.ScoreCandidateInterview(ctx, in)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
 		return
 	}
 	if err != nil {
@@ -500,6 +913,55 @@ func (s *Server) processInterview(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(dto_models.ProcessInterviewResponse{
+		Round:     res.Round,
+		Score:     res.Score,
+		Passed:    res.Passed,
+		NextRound: res.NextRound,
+		Status:    string(res.Status),
+	})
+}
+
+func (s *Server) getRoundQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	candidateID, err := strconv.ParseInt(chi.URLParam(r, "candidate-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid candidate id: %v", err)
+		return
+	}
+
+	vacancyID, err := uuid.Parse(chi.URLParam(r, "vacancy-id"))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid vacancy id")
+		return
+	}
+
+	round, questions, err := s.vacancyService.GetRoundQuestions(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, inerrors.ErrConflict) {
+		httpError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	resp := dto_models.GetRoundQuestionsResponse{
+		Round:     entityRoundToDTO(round),
+		Questions: make([]dto_models.GetCandidateQuestionResponse, 0, len(questions)),
+	}
+	for _, question := range questions {
+		resp.Questions = append(resp.Questions, entityQuestionToCandidateDTO(question))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 func (s *Server) getCandidateVacancyInfos(w http.ResponseWriter, r *http.Request) {
@@ -528,7 +990,7 @@ func (s *Server) getVacancyWithQuestionsByID(w http.ResponseWriter, r *http.Requ
 
 	vacancy, err := s.vacancyService.GetVacancyWithQuestionsByID(ctx, vacancyID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -551,6 +1013,16 @@ func (s *Server) deleteVacancy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setAuditTarget(ctx, "vacancy", vacancyID.String())
+	before, err := s.vacancyService.GetVacancyWithQuestionsByID(ctx, vacancyID)
+	if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+		httpErrorf(w, http.StatusInternalServerError, "can't get vacancy: %v", err)
+		return
+	}
+	if err == nil {
+		setAuditChange(ctx, entityVacancyWithAnswersToDTO(before), nil)
+	}
+
 	err = s.vacancyService.DeleteVacancy(ctx, vacancyID)
 	if err != nil {
 		httpErrorf(w, http.StatusInternalServerError, "can't handle delete: %v", err)
@@ -579,7 +1051,7 @@ func (s *Server) getCandidateVacancyInfo(w http.ResponseWriter, r *http.Request)
 
 	candidate, err := s.candidateService.GetCandidateVacancyInfo(ctx, candidateID, vacancyID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -610,7 +1082,7 @@ func (s *Server) getCandidateAnswers(w http.ResponseWriter, r *http.Request) {
 
 	answers, err := s.candidateService.GetCandidateAnswers(ctx, candidateID, vacancyID)
 	if errors.Is(err, inerrors.ErrNotFound) {
-		httpErrorf(w, http.StatusNotFound, err.Error())
+		httpError(w, http.StatusNotFound, err.Error())
 		return
 	}
 	if err != nil {
@@ -637,6 +1109,54 @@ func (s *Server) getVacancies(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(entityVacanciesWithAnswersToDTO(vacancies))
 }
 
+func (s *Server) getRetentionDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reports, err := s.retentionService.DryRun(ctx)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle dry run: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serviceRetentionRuleReportsToDTO(reports))
+}
+
+// parseUpload limits the request body to the file limit plus the form overhead before parsing the form,
+// so that an oversized upload is rejected without being read in full.
+func parseUpload(w http.ResponseWriter, r *http.Request, limit int64) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, limit+maxUploadFormOverhead)
+
+	err := r.ParseMultipartForm(maxUploadMemory)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		httpErrorf(w, http.StatusRequestEntityTooLarge, "request is larger than %d bytes", tooLarge.Limit)
+		return false
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid form: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// readUpload reads one byte past the limit so an oversized file is rejected instead of being cut.
+func readUpload(w http.ResponseWriter, file io.Reader, limit int64) ([]byte, bool) {
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "can't read file: %v", err)
+		return nil, false
+	}
+	if int64(len(data)) > limit {
+		httpErrorf(w, http.StatusRequestEntityTooLarge, "file is larger than %d bytes", limit)
+		return nil, false
+	}
+
+	return data, true
+}
+
 func httpError(w http.ResponseWriter, code int, msg string) {
 	loggy.Errorf(msg)
 	w.Header().Set("Content-Type", "application/json")
@@ -672,22 +1192,187 @@ func entityCandidateToDTO(e entity.Candidate) dto_models.GetCandidateResponse {
 		FullName:         e.FullName,
 		Phone:            e.Phone,
 		City:             e.City,
+		Email:            e.Email,
+		PreferredContact: e.PreferredContact,
 		CreatedAt:        e.CreatedAt,
 	}
 }
 
+func entityCandidateChangesToDTO(es []entity.CandidateChange) []dto_models.GetCandidateChangeResponse {
+	res := make([]dto_models.GetCandidateChangeResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetCandidateChangeResponse{
+			ID:        e.ID,
+			Field:     e.Field,
+			OldValue:  e.OldValue,
+			NewValue:  e.NewValue,
+			Source:    e.Source,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+func entityCandidateDuplicatesToDTO(es []entity.CandidateDuplicate) []dto_models.GetCandidateDuplicateResponse {
+	res := make([]dto_models.GetCandidateDuplicateResponse, 0, len(es))
+
+	for _, e := range es {
+		reasons := make([]string, 0, len(e.Reasons))
+		for _, reason := range e.Reasons {
+			reasons = append(reasons, string(reason))
+		}
+
+		res = append(res, dto_models.GetCandidateDuplicateResponse{
+			First:          entityCandidateToDTO(e.First),
+			Second:         entityCandidateToDTO(e.Second),
+			Reasons:        reasons,
+			NameSimilarity: e.NameSimilarity,
+		})
+	}
+
+	return res
+}
+
+func serviceRetentionRuleReportsToDTO(rs []service_models.RetentionRuleReport) []dto_models.GetRetentionRuleReportResponse {
+	res := make([]dto_models.GetRetentionRuleReportResponse, 0, len(rs))
+
+	for _, r := range rs {
+		report := dto_models.GetRetentionRuleReportResponse{
+			Rule:         r.Rule,
+			Action:       r.Action,
+			Applications: make([]dto_models.GetRetentionApplicationResponse, 0, len(r.Applications)),
+		}
+		for _, a := range r.Applications {
+			report.Applications = append(report.Applications, dto_models.GetRetentionApplicationResponse{
+				CandidateID: a.CandidateID,
+				VacancyID:   a.VacancyID,
+				Status:      string(a.Status),
+				IsArchived:  a.IsArchived,
+				UpdatedAt:   a.UpdatedAt,
+			})
+		}
+
+		res = append(res, report)
+	}
+
+	return res
+}
+
+func serviceVacancyDraftToDTO(d service_models.VacancyDraft) dto_models.CreateVacancyRequest {
+	keyRequirements := d.KeyRequirements
+	if keyRequirements == nil {
+		keyRequirements = []string{}
+	}
+
+	return dto_models.CreateVacancyRequest{
+		ID:              uuid.New(),
+		Title:           d.Title,
+		KeyRequirements: keyRequirements,
+		Questions:       []dto_models.CreateQuestionRequest{},
+	}
+}
+
 func entityQuestionToDTO(e entity.Question) dto_models.GetQuestionResponse {
 	return dto_models.GetQuestionResponse{
-		ID:        e.ID,
-		VacancyID: e.VacancyID,
-		Content:   e.Content,
-		Reference: e.Reference,
-		TimeLimit: e.TimeLimit,
-		Position:  e.Position,
-		CreatedAt: e.CreatedAt,
+		ID:               e.ID,
+		VacancyID:        e.VacancyID,
+		Type:             e.Type,
+		Content:          e.Content,
+		Reference:        e.Reference,
+		TimeLimit:        e.TimeLimit,
+		Position:         e.Position,
+		Round:            e.Round,
+		Rubric:           entityRubricToDTO(e.Rubric),
+		Options:          entityOptionsToDTO(e.Options),
+		NumericAnswer:    e.NumericAnswer,
+		NumericTolerance: e.NumericTolerance,
+		CodeLanguage:     e.CodeLanguage,
+		TestCases:        entityTestCasesToDTO(e.TestCases),
+		BankQuestionID:   e.BankQuestionID,
+		CreatedAt:        e.CreatedAt,
 	}
 }
 
+// entityQuestionToCandidateDTO is used by the bot routes, so that candidates can't read the answer key.
+func entityQuestionToCandidateDTO(e entity.Question) dto_models.GetCandidateQuestionResponse {
+	res := dto_models.GetCandidateQuestionResponse{
+		ID:           e.ID,
+		VacancyID:    e.VacancyID,
+		Type:         e.Type,
+		Content:      e.Content,
+		TimeLimit:    e.TimeLimit,
+		Position:     e.Position,
+		Round:        e.Round,
+		Options:      make([]dto_models.CandidateChoiceOptionResponse, 0, len(e.Options)),
+		CodeLanguage: e.CodeLanguage,
+		TestCases:    make([]dto_models.CandidateTestCaseResponse, 0, len(e.TestCases)),
+		CreatedAt:    e.CreatedAt,
+	}
+	for _, option := range e.Options {
+		res.Options = append(res.Options, dto_models.CandidateChoiceOptionResponse{Text: option.Text})
+	}
+	for _, testCase := range e.TestCases {
+		res.TestCases = append(res.TestCases, dto_models.CandidateTestCaseResponse{Input: testCase.Input})
+	}
+
+	return res
+}
+
+func entityRoundToDTO(e entity.InterviewRound) dto_models.GetRoundResponse {
+	res := dto_models.GetRoundResponse{
+		Number:    e.Number,
+		Title:     e.Title,
+		Threshold: e.Threshold,
+		BankDraws: make([]dto_models.BankDrawResponse, 0, len(e.BankDraws)),
+	}
+	for _, draw := range e.BankDraws {
+		res.BankDraws = append(res.BankDraws, dto_models.BankDrawResponse{
+			Tag:   draw.Tag,
+			Count: draw.Count,
+		})
+	}
+
+	return res
+}
+
+func entityRubricToDTO(rubric []entity.RubricPoint) []dto_models.RubricPointResponse {
+	res := make([]dto_models.RubricPointResponse, 0, len(rubric))
+	for _, point := range rubric {
+		res = append(res, dto_models.RubricPointResponse{
+			Point:  point.Point,
+			Weight: point.Weight,
+		})
+	}
+
+	return res
+}
+
+func entityOptionsToDTO(options []entity.ChoiceOption) []dto_models.ChoiceOptionResponse {
+	res := make([]dto_models.ChoiceOptionResponse, 0, len(options))
+	for _, option := range options {
+		res = append(res, dto_models.ChoiceOptionResponse{
+			Text:    option.Text,
+			Correct: option.Correct,
+		})
+	}
+
+	return res
+}
+
+func entityTestCasesToDTO(testCases []entity.TestCase) []dto_models.TestCaseResponse {
+	res := make([]dto_models.TestCaseResponse, 0, len(testCases))
+	for _, testCase := range testCases {
+		res = append(res, dto_models.TestCaseResponse{
+			Input:          testCase.Input,
+			ExpectedOutput: testCase.ExpectedOutput,
+		})
+	}
+
+	return res
+}
+
 func entityResumeScreeningToDTO(e entity.ResumeScreening) dto_models.GetResumeScreeningResponse {
 	return dto_models.GetResumeScreeningResponse{
 		ID:          e.ID,
@@ -706,6 +1391,7 @@ func entityMetaToDTO(e entity.Meta) dto_models.GetMetaResponse {
 		VacancyID:      e.VacancyID,
 		InterviewScore: e.InterviewScore,
 		Status:         string(e.Status),
+		CurrentRound:   e.CurrentRound,
 		IsArchived:     e.IsArchived,
 		UpdatedAt:      e.UpdatedAt,
 	}
@@ -725,19 +1411,15 @@ func entityVacancyWithAnswersToDTO(e entity.VacancyWithQuestion) dto_models.GetV
 		ID:              e.ID,
 		Title:           e.Title,
 		KeyRequirements: e.KeyRequirements,
+		Rounds:          make([]dto_models.GetRoundResponse, 0, len(e.Rounds)),
 		Questions:       make([]dto_models.GetQuestionResponse, 0, len(e.Questions)),
 		CreatedAt:       e.CreatedAt,
 	}
+	for _, round := range e.Rounds {
+		v.Rounds = append(v.Rounds, entityRoundToDTO(round))
+	}
 	for _, q := range e.Questions {
-		v.Questions = append(v.Questions, dto_models.GetQuestionResponse{
-			ID:        q.ID,
-			VacancyID: q.VacancyID,
-			Content:   q.Content,
-			Reference: q.Reference,
-			TimeLimit: q.TimeLimit,
-			Position:  q.Position,
-			CreatedAt: q.CreatedAt,
-		})
+		v.Questions = append(v.Questions, entityQuestionToDTO(q))
 	}
 	return v
 }
@@ -753,16 +1435,30 @@ func entityCandidateVacancyInfosToDTO(es []entity.CandidateVacancyInfo) []dto_mo
 }
 
 func entityCandidateVacancyInfoToDTO(e entity.CandidateVacancyInfo) dto_models.GetCandidateVacancyInfoResponse {
+	var summary *dto_models.GetCandidateSummaryResponse
+	if e.Summary != nil {
+		summary = &dto_models.GetCandidateSummaryResponse{
+			Strengths:      e.Summary.Strengths,
+			Weaknesses:     e.Summary.Weaknesses,
+			RedFlags:       e.Summary.RedFlags,
+			Recommendation: e.Summary.Recommendation,
+			Rationale:      e.Summary.Rationale,
+			UpdatedAt:      e.Summary.UpdatedAt,
+		}
+	}
+
+	roundResults := make([]dto_models.GetRoundResultResponse, 0, len(e.RoundResults))
+	for _, result := range e.RoundResults {
+		roundResults = append(roundResults, dto_models.GetRoundResultResponse{
+			Round:     result.Round,
+			Score:     result.Score,
+			Passed:    result.Passed,
+			CreatedAt: result.CreatedAt,
+		})
+	}
+
 	return dto_models.GetCandidateVacancyInfoResponse{
-		Candidate: dto_models.GetCandidateResponse{
-			ID:               e.Candidate.ID,
-			TelegramID:       e.Candidate.TelegramID,
-			TelegramUsername: e.Candidate.TelegramUsername,
-			FullName:         e.Candidate.FullName,
-			Phone:            e.Candidate.Phone,
-			City:             e.Candidate.City,
-			CreatedAt:        e.Candidate.CreatedAt,
-		},
+		Candidate: entityCandidateToDTO(e.Candidate),
 		Vacancy: dto_models.GetVacancyResponse{
 			ID:              e.Vacancy.ID,
 			Title:           e.Vacancy.Title,
@@ -774,11 +1470,14 @@ func entityCandidateVacancyInfoToDTO(e entity.CandidateVacancyInfo) dto_models.G
 			VacancyID:      e.Vacancy.ID,
 			InterviewScore: e.Meta.InterviewScore,
 			Status:         string(e.Meta.Status),
+			CurrentRound:   e.Meta.CurrentRound,
 			IsArchived:     e.Meta.IsArchived,
 			UpdatedAt:      e.Meta.UpdatedAt,
 		},
 		ResumeScreening: entityResumeScreeningToDTO(e.ResumeScreening),
 		ResumeLink:      e.ResumeLink,
+		Summary:         summary,
+		RoundResults:    roundResults,
 	}
 }
 
@@ -787,26 +1486,40 @@ func entityCandidateQuestionAnswersToDTO(es []entity.CandidateQuestionAnswer) []
 
 	for _, e := range es {
 		res = append(res, dto_models.GetCandidateQuestionAnswerResponse{
-			Question: dto_models.GetQuestionResponse{
-				ID:        e.Question.ID,
-				VacancyID: e.Question.VacancyID,
-				Content:   e.Question.Content,
-				Reference: e.Question.Reference,
-				TimeLimit: e.Question.TimeLimit,
-				Position:  e.Question.Position,
-				CreatedAt: e.Question.CreatedAt,
-			},
+			Question: entityQuestionToDTO(e.Question),
 			Answer: dto_models.GetAnswerResponse{
-				ID:          e.Answer.ID,
-				CandidateID: e.Answer.CandidateID,
-				QuestionID:  e.Answer.QuestionID,
-				Content:     e.Answer.Content,
-				Score:       e.Answer.Score,
-				TimeTaken:   e.Answer.TimeTaken,
-				CreatedAt:   e.Answer.CreatedAt,
+				ID:            e.Answer.ID,
+				CandidateID:   e.Answer.CandidateID,
+				QuestionID:    e.Answer.QuestionID,
+				Content:       e.Answer.Content,
+				Score:         e.Answer.Score,
+				TimeTaken:     e.Answer.TimeTaken,
+				Explanation:   e.Answer.Explanation,
+				MissingPoints: e.Answer.MissingPoints,
+				Voice:         e.Answer.Voice,
+				CreatedAt:     e.Answer.CreatedAt,
 			},
+			Signals: entityAnswerSignalsToDTO(e.Signals),
 		})
 	}
 
 	return res
 }
+
+func entityAnswerSignalsToDTO(e *entity.AnswerSignals) *dto_models.GetAnswerSignalsResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &dto_models.GetAnswerSignalsResponse{
+		Risk:                e.Risk,
+		Flags:               e.Flags,
+		CharsPerMinute:      e.CharsPerMinute,
+		PeerSimilarity:      e.PeerSimilarity,
+		SimilarAnswerID:     e.SimilarAnswerID,
+		ReferenceSimilarity: e.ReferenceSimilarity,
+		AILikelihood:        e.AILikelihood,
+		AIReason:            e.AIReason,
+		UpdatedAt:           e.UpdatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+func (s *Server) createWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var in dto_models.CreateWebhookSubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid JSON: %v", err.Error())
+		return
+	}
+
+	subscription, err := s.webhookService.CreateSubscription(ctx, in)
+	if errors.Is(err, inerrors.ErrInvalidInput) {
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle creation: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "webhook", strconv.FormatInt(subscription.ID, 10))
+	// the secret must not end up in the audit log
+	setAuditChange(ctx, nil, entityWebhookSubscriptionToDTO(subscription, false))
+
+	// the secret is returned only once, on creation
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(entityWebhookSubscriptionToDTO(subscription, true))
+}
+
+func (s *Server) getWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptions, err := s.webhookService.GetSubscriptions(ctx)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	res := make([]dto_models.GetWebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		res = append(res, entityWebhookSubscriptionToDTO(subscription, false))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) deleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptionID, err := strconv.ParseInt(chi.URLParam(r, "webhook-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid webhook id: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "webhook", strconv.FormatInt(subscriptionID, 10))
+
+	err = s.webhookService.DeleteSubscription(ctx, subscriptionID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle deletion: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptionID, err := strconv.ParseInt(chi.URLParam(r, "webhook-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid webhook id: %v", err)
+		return
+	}
+
+	deliveries, err := s.webhookService.GetDeliveries(ctx, subscriptionID)
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle get: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(entityWebhookDeliveriesToDTO(deliveries))
+}
+
+func (s *Server) redeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	deliveryID, err := strconv.ParseInt(chi.URLParam(r, "delivery-id"), 10, 64)
+	if err != nil {
+		httpErrorf(w, http.StatusBadRequest, "invalid delivery id: %v", err)
+		return
+	}
+
+	setAuditTarget(ctx, "webhook_delivery", strconv.FormatInt(deliveryID, 10))
+
+	newDeliveryID, err := s.webhookService.Redeliver(ctx, deliveryID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		httpError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		httpErrorf(w, http.StatusInternalServerError, "can't handle redelivery: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id": newDeliveryID,
+	})
+}
+
+func entityWebhookSubscriptionToDTO(e entity.WebhookSubscription, withSecret bool) dto_models.GetWebhookSubscriptionResponse {
+	res := dto_models.GetWebhookSubscriptionResponse{
+		ID:         e.ID,
+		URL:        e.URL,
+		EventTypes: e.EventTypes,
+		IsActive:   e.IsActive,
+		CreatedAt:  e.CreatedAt,
+	}
+	if withSecret {
+		res.Secret = e.Secret
+	}
+
+	return res
+}
+
+func entityWebhookDeliveriesToDTO(es []entity.WebhookDelivery) []dto_models.GetWebhookDeliveryResponse {
+	res := make([]dto_models.GetWebhookDeliveryResponse, 0, len(es))
+
+	for _, e := range es {
+		res = append(res, dto_models.GetWebhookDeliveryResponse{
+			ID:             e.ID,
+			SubscriptionID: e.SubscriptionID,
+			EventID:        e.EventID,
+			EventType:      e.EventType,
+			Payload:        e.Payload,
+			Status:         e.Status,
+			Attempts:       e.Attempts,
+			NextAttemptAt:  e.NextAttemptAt,
+			LastStatusCode: e.LastStatusCode,
+			LastError:      e.LastError,
+			CreatedAt:      e.CreatedAt,
+			DeliveredAt:    e.DeliveredAt,
+		})
+	}
+
+	return res
+}
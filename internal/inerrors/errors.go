@@ -3,5 +3,9 @@ package inerrors
 import "errors"
 
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrConsentRequired = errors.New("consent required")
+	ErrConflict        = errors.New("conflict")
 )
@@ -0,0 +1,185 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	StageApplied            = "applied"
+	StageScreeningOk        = "screening_ok"
+	StageInterviewCompleted = "interview_completed"
+	StageInterviewOk        = "interview_ok"
+)
+
+const (
+	// DurationScreeningToInterview is the time between resume screening and the first answer.
+	DurationScreeningToInterview = "screening_to_interview"
+	// DurationInterview is the time between the first and the last answer.
+	DurationInterview = "interview"
+	// DurationInterviewToBooking is the time between the last answer and booking a live interview.
+	DurationInterviewToBooking = "interview_to_booking"
+)
+
+const histogramBucketWidth = 10
+
+// Funnel builds the hiring funnel for applications screened within the filter range.
+// There is no stage history, so stage moments are taken from screening, answers and slot booking times.
+func (s *Service) Funnel(ctx context.Context, filter service_models.AnalyticsFilter) (service_models.FunnelReport, error) {
+	err := validateFilter(filter)
+	if err != nil {
+		return service_models.FunnelReport{}, err
+	}
+
+	timelines, err := s.store.GetApplicationTimelines(ctx, filter)
+	if err != nil {
+		return service_models.FunnelReport{}, fmt.Errorf("can't get applications: %w", err)
+	}
+
+	reaches, err := s.store.GetQuestionReach(ctx, filter)
+	if err != nil {
+		return service_models.FunnelReport{}, fmt.Errorf("can't get question reach: %w", err)
+	}
+
+	var (
+		counts                                 [4]int
+		resumeScores, interviewScores          []int
+		toInterview, interviewTimes, toBooking []time.Duration
+	)
+	for _, t := range timelines {
+		counts[0]++
+		resumeScores = append(resumeScores, t.ResumeScore)
+
+		if t.Status != entity.CandidateVacancyStatusScreeningFailed {
+			counts[1]++
+		}
+		if t.InterviewScore != nil {
+			counts[2]++
+			interviewScores = append(interviewScores, *t.InterviewScore)
+		}
+		if t.Status == entity.CandidateVacancyStatusInterviewOk || t.Status == entity.CandidateVacancyStatusInterviewScheduled {
+			counts[3]++
+		}
+
+		if t.FirstAnswerAt != nil {
+			toInterview = append(toInterview, t.FirstAnswerAt.Sub(t.ScreenedAt))
+		}
+		if t.FirstAnswerAt != nil && t.InterviewScore != nil {
+			interviewTimes = append(interviewTimes, t.LastAnswerAt.Sub(*t.FirstAnswerAt))
+		}
+		if t.LastAnswerAt != nil && t.BookedAt != nil {
+			toBooking = append(toBooking, t.BookedAt.Sub(*t.LastAnswerAt))
+		}
+	}
+
+	return service_models.FunnelReport{
+		Filter: filter,
+		Stages: funnelStages(
+			[]string{StageApplied, StageScreeningOk, StageInterviewCompleted, StageInterviewOk},
+			counts[:],
+		),
+		Durations: []service_models.StageDuration{
+			stageDuration(DurationScreeningToInterview, toInterview),
+			stageDuration(DurationInterview, interviewTimes),
+			stageDuration(DurationInterviewToBooking, toBooking),
+		},
+		ResumeScoreHistogram:    histogram(resumeScores),
+		InterviewScoreHistogram: histogram(interviewScores),
+		QuestionDropOffs:        questionDropOffs(reaches),
+	}, nil
+}
+
+func funnelStages(names []string, counts []int) []service_models.FunnelStage {
+	stages := make([]service_models.FunnelStage, 0, len(names))
+	for i, name := range names {
+		stage := service_models.FunnelStage{
+			Name:                   name,
+			Count:                  counts[i],
+			ConversionFromPrevious: 1,
+			ConversionFromApplied:  1,
+		}
+		if i > 0 {
+			stage.ConversionFromPrevious = ratio(counts[i], counts[i-1])
+			stage.ConversionFromApplied = ratio(counts[i], counts[0])
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages
+}
+
+func stageDuration(name string, durations []time.Duration) service_models.StageDuration {
+	res := service_models.StageDuration{
+		Name:    name,
+		Samples: len(durations),
+	}
+	if len(durations) == 0 {
+		return res
+	}
+
+	slices.Sort(durations)
+	mid := len(durations) / 2
+	median := durations[mid]
+	if len(durations)%2 == 0 {
+		median = (durations[mid-1] + durations[mid]) / 2
+	}
+
+	seconds := median.Seconds()
+	res.MedianSeconds = &seconds
+
+	return res
+}
+
+// histogram counts scores in buckets [0, 10), [10, 20), ..., [90, 100]; the last bucket includes 100.
+func histogram(scores []int) []service_models.HistogramBucket {
+	buckets := make([]service_models.HistogramBucket, 0, 100/histogramBucketWidth)
+	for from := 0; from < 100; from += histogramBucketWidth {
+		buckets = append(buckets, service_models.HistogramBucket{
+			From: from,
+			To:   from + histogramBucketWidth,
+		})
+	}
+
+	for _, score := range scores {
+		idx := min(max(score, 0)/histogramBucketWidth, len(buckets)-1)
+		buckets[idx].Count++
+	}
+
+	return buckets
+}
+
+func questionDropOffs(reaches []service_models.QuestionReach) []service_models.QuestionDropOff {
+	dropOffs := make([]service_models.QuestionDropOff, 0, len(reaches))
+
+	for i, reach := range reaches {
+		dropOff := service_models.QuestionDropOff{
+			QuestionID: reach.QuestionID,
+			Position:   reach.Position,
+			Content:    reach.Content,
+			Answered:   reach.Answered,
+		}
+		if i > 0 {
+			prev := reaches[i-1].Answered
+			dropOff.DropOff = max(prev-reach.Answered, 0)
+			dropOff.DropOffRate = ratio(dropOff.DropOff, prev)
+		}
+
+		dropOffs = append(dropOffs, dropOff)
+	}
+
+	return dropOffs
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+
+	return float64(a) / float64(b)
+}
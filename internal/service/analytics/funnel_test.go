@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"hr-helper/internal/service_models"
+)
+
+func TestHistogram(t *testing.T) {
+	buckets := histogram([]int{0, 9, 10, 55, 59, 90, 99, 100, -5, 120})
+
+	if len(buckets) != 10 {
+		t.Fatalf("histogram() has %d buckets, want 10", len(buckets))
+	}
+	if buckets[0].From != 0 || buckets[0].To != 10 || buckets[9].From != 90 || buckets[9].To != 100 {
+		t.Errorf("histogram() bounds = [%d, %d) ... [%d, %d], want [0, 10) ... [90, 100]",
+			buckets[0].From, buckets[0].To, buckets[9].From, buckets[9].To)
+	}
+
+	counts := make([]int, 0, len(buckets))
+	for _, bucket := range buckets {
+		counts = append(counts, bucket.Count)
+	}
+	// out of range scores fall into the edge buckets
+	want := []int{3, 1, 0, 0, 0, 2, 0, 0, 0, 4}
+	if !slices.Equal(counts, want) {
+		t.Errorf("histogram() counts = %v, want %v", counts, want)
+	}
+}
+
+func TestStageDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      *float64
+	}{
+		{
+			name: "no samples",
+		},
+		{
+			name:      "odd number of samples",
+			durations: []time.Duration{time.Hour, time.Minute, 3 * time.Minute},
+			want:      ptr(180.0),
+		},
+		{
+			name:      "even number of samples",
+			durations: []time.Duration{4 * time.Minute, time.Minute, 2 * time.Minute, time.Hour},
+			want:      ptr(180.0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stageDuration(DurationInterview, tt.durations)
+			if got.Name != DurationInterview || got.Samples != len(tt.durations) {
+				t.Errorf("stageDuration() = %+v, want name %s and %d samples", got, DurationInterview, len(tt.durations))
+			}
+			if (got.MedianSeconds == nil) != (tt.want == nil) ||
+				got.MedianSeconds != nil && !almostEqual(*got.MedianSeconds, *tt.want) {
+				t.Errorf("stageDuration() median = %v, want %v", deref(got.MedianSeconds), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestFunnelStages(t *testing.T) {
+	stages := funnelStages(
+		[]string{StageApplied, StageScreeningOk, StageInterviewCompleted, StageInterviewOk},
+		[]int{10, 5, 0, 0},
+	)
+
+	want := []service_models.FunnelStage{
+		{Name: StageApplied, Count: 10, ConversionFromPrevious: 1, ConversionFromApplied: 1},
+		{Name: StageScreeningOk, Count: 5, ConversionFromPrevious: 0.5, ConversionFromApplied: 0.5},
+		{Name: StageInterviewCompleted, Count: 0, ConversionFromPrevious: 0, ConversionFromApplied: 0},
+		// an empty previous stage doesn't divide by zero
+		{Name: StageInterviewOk, Count: 0, ConversionFromPrevious: 0, ConversionFromApplied: 0},
+	}
+	if !slices.EqualFunc(stages, want, func(a service_models.FunnelStage, b service_models.FunnelStage) bool {
+		return a.Name == b.Name && a.Count == b.Count &&
+			almostEqual(a.ConversionFromPrevious, b.ConversionFromPrevious) &&
+			almostEqual(a.ConversionFromApplied, b.ConversionFromApplied)
+	}) {
+		t.Errorf("funnelStages() = %+v, want %+v", stages, want)
+	}
+}
+
+func TestQuestionDropOffs(t *testing.T) {
+	dropOffs := questionDropOffs([]service_models.QuestionReach{
+		{QuestionID: 1, Position: 1, Answered: 20},
+		{QuestionID: 2, Position: 2, Answered: 15},
+		{QuestionID: 3, Position: 3, Answered: 0},
+		// answered by more candidates than the previous one
+		{QuestionID: 4, Position: 4, Answered: 3},
+	})
+
+	want := []service_models.QuestionDropOff{
+		{QuestionID: 1, Position: 1, Answered: 20},
+		{QuestionID: 2, Position: 2, Answered: 15, DropOff: 5, DropOffRate: 0.25},
+		{QuestionID: 3, Position: 3, Answered: 0, DropOff: 15, DropOffRate: 1},
+		{QuestionID: 4, Position: 4, Answered: 3},
+	}
+	if !slices.EqualFunc(dropOffs, want, func(a service_models.QuestionDropOff, b service_models.QuestionDropOff) bool {
+		return a.QuestionID == b.QuestionID && a.Position == b.Position && a.Answered == b.Answered &&
+			a.DropOff == b.DropOff && almostEqual(a.DropOffRate, b.DropOffRate)
+	}) {
+		t.Errorf("questionDropOffs() = %+v, want %+v", dropOffs, want)
+	}
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
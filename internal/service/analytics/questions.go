@@ -0,0 +1,177 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	// QuestionFlagTooEasy marks questions almost everyone answers well.
+	QuestionFlagTooEasy = "too_easy"
+	// QuestionFlagTooHard marks questions almost everyone fails.
+	QuestionFlagTooHard = "too_hard"
+	// QuestionFlagLowSpread marks questions whose scores barely differ between candidates.
+	QuestionFlagLowSpread = "low_spread"
+	// QuestionFlagNotPredictive marks questions whose score doesn't move with the interview outcome.
+	QuestionFlagNotPredictive = "not_predictive"
+)
+
+const (
+	// minFlagSamples is the number of answers below which flags are too noisy to set.
+	minFlagSamples           = 10
+	tooEasyMeanScore         = 90
+	tooHardMeanScore         = 20
+	lowSpreadStdDev          = 5
+	notPredictiveCorrelation = 0.1
+)
+
+// QuestionStats computes per-question score and timing statistics for answers given within the filter range.
+func (s *Service) QuestionStats(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.QuestionStats, error) {
+	err := validateFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes, err := s.store.GetAnswerOutcomes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("can't get answers: %w", err)
+	}
+
+	// outcomes are ordered by question position
+	var stats []service_models.QuestionStats
+	for start := 0; start < len(outcomes); {
+		end := start
+		for end < len(outcomes) && outcomes[end].Question.ID == outcomes[start].Question.ID {
+			end++
+		}
+
+		stats = append(stats, questionStats(outcomes[start:end]))
+		start = end
+	}
+
+	return stats, nil
+}
+
+func questionStats(outcomes []service_models.AnswerOutcome) service_models.QuestionStats {
+	question := outcomes[0].Question
+	res := service_models.QuestionStats{
+		Question: question,
+		Answers:  len(outcomes),
+	}
+
+	scores := make([]float64, 0, len(outcomes))
+	var timeSum float64
+	var passed, scored []float64
+	for _, o := range outcomes {
+		scores = append(scores, float64(o.Score))
+		timeSum += float64(o.TimeTaken)
+
+		switch o.Status {
+		case entity.CandidateVacancyStatusInterviewOk, entity.CandidateVacancyStatusInterviewScheduled:
+			passed = append(passed, 1)
+			scored = append(scored, float64(o.Score))
+		case entity.CandidateVacancyStatusInterviewFailed:
+			passed = append(passed, 0)
+			scored = append(scored, float64(o.Score))
+		}
+	}
+
+	res.MeanScore = mean(scores)
+	res.MedianScore = median(scores)
+	res.StdDevScore = stdDev(scores, res.MeanScore)
+	res.AvgTimeTaken = timeSum / float64(len(outcomes))
+
+	if question.TimeLimit > 0 {
+		var shareSum float64
+		var timeouts int
+		for _, o := range outcomes {
+			shareSum += float64(o.TimeTaken) / float64(question.TimeLimit)
+			if o.TimeTaken >= int64(question.TimeLimit) {
+				timeouts++
+			}
+		}
+
+		avgShare := shareSum / float64(len(outcomes))
+		timeoutRate := float64(timeouts) / float64(len(outcomes))
+		res.AvgTimeShare = &avgShare
+		res.TimeoutRate = &timeoutRate
+	}
+
+	res.OutcomeSamples = len(passed)
+	res.OutcomeCorrelation = correlation(scored, passed)
+
+	if res.Answers >= minFlagSamples {
+		if res.MeanScore >= tooEasyMeanScore {
+			res.Flags = append(res.Flags, QuestionFlagTooEasy)
+		}
+		if res.MeanScore <= tooHardMeanScore {
+			res.Flags = append(res.Flags, QuestionFlagTooHard)
+		}
+		if res.StdDevScore < lowSpreadStdDev {
+			res.Flags = append(res.Flags, QuestionFlagLowSpread)
+		}
+	}
+	if res.OutcomeSamples >= minFlagSamples && res.OutcomeCorrelation != nil && math.Abs(*res.OutcomeCorrelation) < notPredictiveCorrelation {
+		res.Flags = append(res.Flags, QuestionFlagNotPredictive)
+	}
+
+	return res
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// stdDev is the population standard deviation.
+func stdDev(values []float64, mean float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// correlation is the Pearson correlation coefficient; it's undefined for fewer than two samples or constant series.
+func correlation(xs, ys []float64) *float64 {
+	if len(xs) < 2 {
+		return nil
+	}
+
+	meanX, meanY := mean(xs), mean(ys)
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	res := cov / math.Sqrt(varX*varY)
+	return &res
+}
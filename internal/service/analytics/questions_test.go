@@ -0,0 +1,200 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type storageStub struct {
+	Storage
+	outcomes []service_models.AnswerOutcome
+}
+
+func (s storageStub) GetAnswerOutcomes(context.Context, service_models.AnalyticsFilter) ([]service_models.AnswerOutcome, error) {
+	return s.outcomes, nil
+}
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{values: []float64{5}, want: 5},
+		{values: []float64{9, 1, 5}, want: 5},
+		{values: []float64{10, 0, 4, 6}, want: 5},
+	}
+
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestStdDev(t *testing.T) {
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	if got := stdDev(values, mean(values)); !almostEqual(got, 2) {
+		t.Errorf("stdDev() = %v, want 2", got)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		ys   []float64
+		want *float64
+	}{
+		{name: "single sample", xs: []float64{1}, ys: []float64{1}},
+		{name: "constant series", xs: []float64{1, 2, 3}, ys: []float64{1, 1, 1}},
+		{name: "positive", xs: []float64{1, 2, 3}, ys: []float64{2, 4, 6}, want: ptr(1.0)},
+		{name: "negative", xs: []float64{1, 2, 3}, ys: []float64{3, 2, 1}, want: ptr(-1.0)},
+		{name: "uncorrelated", xs: []float64{1, 2, 3, 4}, ys: []float64{1, 0, 0, 1}, want: ptr(0.0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := correlation(tt.xs, tt.ys)
+			if (got == nil) != (tt.want == nil) || (got != nil && !almostEqual(*got, *tt.want)) {
+				t.Errorf("correlation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func outcomes(question entity.Question, n int, score func(i int) int, status func(i int) string) []service_models.AnswerOutcome {
+	res := make([]service_models.AnswerOutcome, 0, n)
+	for i := range n {
+		res = append(res, service_models.AnswerOutcome{
+			Question:  question,
+			Score:     score(i),
+			TimeTaken: int64(10 * (i + 1)),
+			Status:    status(i),
+		})
+	}
+
+	return res
+}
+
+func TestQuestionStats(t *testing.T) {
+	question := entity.Question{ID: 1, TimeLimit: 40}
+	passedIfEven := func(i int) string {
+		if i%2 == 0 {
+			return entity.CandidateVacancyStatusInterviewOk
+		}
+		return entity.CandidateVacancyStatusInterviewFailed
+	}
+
+	t.Run("timing and outcomes", func(t *testing.T) {
+		stats := questionStats([]service_models.AnswerOutcome{
+			{Question: question, Score: 100, TimeTaken: 10, Status: entity.CandidateVacancyStatusInterviewScheduled},
+			{Question: question, Score: 20, TimeTaken: 40, Status: entity.CandidateVacancyStatusInterviewFailed},
+			{Question: question, Score: 60, TimeTaken: 30, Status: entity.CandidateVacancyStatusScreeningOk},
+		})
+
+		if stats.Answers != 3 || !almostEqual(stats.MeanScore, 60) || stats.MedianScore != 60 {
+			t.Errorf("stats = %+v, want 3 answers with mean and median 60", stats)
+		}
+		if !almostEqual(stats.AvgTimeTaken, 80.0/3) {
+			t.Errorf("AvgTimeTaken = %v, want %v", stats.AvgTimeTaken, 80.0/3)
+		}
+		if stats.AvgTimeShare == nil || !almostEqual(*stats.AvgTimeShare, 2.0/3) {
+			t.Errorf("AvgTimeShare = %v, want %v", stats.AvgTimeShare, 2.0/3)
+		}
+		if stats.TimeoutRate == nil || !almostEqual(*stats.TimeoutRate, 1.0/3) {
+			t.Errorf("TimeoutRate = %v, want %v", stats.TimeoutRate, 1.0/3)
+		}
+		// the application without a final interview result isn't an outcome sample
+		if stats.OutcomeSamples != 2 || stats.OutcomeCorrelation == nil || !almostEqual(*stats.OutcomeCorrelation, 1) {
+			t.Errorf("outcome = %d samples, correlation %v, want 2 samples with correlation 1", stats.OutcomeSamples, stats.OutcomeCorrelation)
+		}
+		if len(stats.Flags) != 0 {
+			t.Errorf("Flags = %v, want none below %d answers", stats.Flags, minFlagSamples)
+		}
+	})
+
+	t.Run("no time limit", func(t *testing.T) {
+		stats := questionStats([]service_models.AnswerOutcome{{Question: entity.Question{ID: 2}, Score: 50, TimeTaken: 10}})
+		if stats.AvgTimeShare != nil || stats.TimeoutRate != nil {
+			t.Errorf("time shares = %v, %v, want nil without a time limit", stats.AvgTimeShare, stats.TimeoutRate)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		outcomes  []service_models.AnswerOutcome
+		wantFlags []string
+	}{
+		{
+			name:      "too easy",
+			outcomes:  outcomes(question, 10, func(int) int { return 100 }, passedIfEven),
+			wantFlags: []string{QuestionFlagTooEasy, QuestionFlagLowSpread},
+		},
+		{
+			name:      "too hard",
+			outcomes:  outcomes(question, 10, func(i int) int { return 10 * (i % 3) }, passedIfEven),
+			wantFlags: []string{QuestionFlagTooHard},
+		},
+		{
+			name: "not predictive",
+			outcomes: outcomes(question, 12, func(i int) int {
+				return []int{20, 80, 80, 20}[i%4]
+			}, passedIfEven),
+			wantFlags: []string{QuestionFlagNotPredictive},
+		},
+		{
+			name:     "predictive",
+			outcomes: outcomes(question, 10, func(i int) int { return 80 - 60*(i%2) }, passedIfEven),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := questionStats(tt.outcomes).Flags; !slices.Equal(got, tt.wantFlags) {
+				t.Errorf("Flags = %v, want %v", got, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestServiceQuestionStats(t *testing.T) {
+	first := entity.Question{ID: 1, Position: 1}
+	second := entity.Question{ID: 2, Position: 2}
+	s := NewService(storageStub{outcomes: []service_models.AnswerOutcome{
+		{Question: first, Score: 10},
+		{Question: first, Score: 30},
+		{Question: second, Score: 50},
+	}})
+	to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	stats, err := s.QuestionStats(context.Background(), service_models.AnalyticsFilter{VacancyID: uuid.New(), From: to.AddDate(0, 0, -30), To: to})
+	if err != nil {
+		t.Fatalf("QuestionStats() error = %v", err)
+	}
+	if len(stats) != 2 || stats[0].Question.ID != 1 || stats[0].Answers != 2 || stats[1].Question.ID != 2 || stats[1].Answers != 1 {
+		t.Errorf("stats = %+v, want 2 answers to question 1 and 1 to question 2", stats)
+	}
+
+	_, err = s.QuestionStats(context.Background(), service_models.AnalyticsFilter{From: to, To: to})
+	if !errors.Is(err, inerrors.ErrInvalidInput) {
+		t.Errorf("QuestionStats() with an empty range error = %v, want invalid input", err)
+	}
+}
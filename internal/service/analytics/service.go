@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+// maxRange bounds reports so that a single request can't scan the whole history.
+const maxRange = 366 * 24 * time.Hour
+
+type Storage interface {
+	GetApplicationTimelines(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.ApplicationTimeline, error)
+	GetQuestionReach(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.QuestionReach, error)
+	GetAnswerOutcomes(ctx context.Context, filter service_models.AnalyticsFilter) ([]service_models.AnswerOutcome, error)
+}
+
+type Service struct {
+	store Storage
+}
+
+func NewService(store Storage) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+func validateFilter(filter service_models.AnalyticsFilter) error {
+	if !filter.To.After(filter.From) {
+		return fmt.Errorf("%w: range end must be after its start", inerrors.ErrInvalidInput)
+	}
+	if filter.To.Sub(filter.From) > maxRange {
+		return fmt.Errorf("%w: range can't be longer than %s", inerrors.ErrInvalidInput, maxRange)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type Storage interface {
+	Create(ctx context.Context, entry entity.AuditEntry) error
+	Find(ctx context.Context, filter service_models.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type Service struct {
+	store Storage
+}
+
+func NewService(store Storage) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+func (s *Service) Record(ctx context.Context, entry entity.AuditEntry) error {
+	return s.store.Create(ctx, entry)
+}
+
+func (s *Service) Find(ctx context.Context, filter service_models.AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", inerrors.ErrInvalidInput, maxLimit)
+	}
+
+	return s.store.Find(ctx, filter)
+}
@@ -1,6 +1,8 @@
 package auther
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -30,3 +32,27 @@ func GenerateJWTWithEmail(email string) (string, error) {
 
 	return tokenString, nil
 }
+
+func ParseJWTEmail(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secretInstance), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("can't parse token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("invalid token")
+	}
+
+	email, ok := claims["email"].(string)
+	if !ok || email == "" {
+		return "", errors.New("no email in token")
+	}
+
+	return email, nil
+}
@@ -0,0 +1,134 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+// screeningConsents are required to score the candidate's resume via llm.
+var screeningConsents = []string{
+	entity.ConsentTypePersonalData,
+	entity.ConsentTypeAIEvaluation,
+}
+
+func validateConsents(consents []dto_models.CreateConsentRequest) error {
+	for _, consent := range consents {
+		if consent.Type != entity.ConsentTypePersonalData && consent.Type != entity.ConsentTypeAIEvaluation {
+			return fmt.Errorf("%w: unknown consent type %q", inerrors.ErrInvalidInput, consent.Type)
+		}
+		if consent.Channel != entity.ConsentChannelTelegram && consent.Channel != entity.ConsentChannelWeb {
+			return fmt.Errorf("%w: unknown consent channel %q", inerrors.ErrInvalidInput, consent.Channel)
+		}
+		if strings.TrimSpace(consent.PolicyVersion) == "" {
+			return fmt.Errorf("%w: policy version of %s consent is empty", inerrors.ErrInvalidInput, consent.Type)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) GrantConsents(ctx context.Context, candidateID int64, consents []dto_models.CreateConsentRequest) error {
+	if len(consents) == 0 {
+		return fmt.Errorf("%w: no consents", inerrors.ErrInvalidInput)
+	}
+
+	err := validateConsents(consents)
+	if err != nil {
+		return err
+	}
+
+	return s.store.CreateConsents(ctx, candidateID, consents)
+}
+
+// WithdrawConsent withdraws the consent. Withdrawal of personal data processing consent erases the candidate's data,
+// withdrawal of AI evaluation consent only blocks further screenings.
+// The erasure request is logged together with the withdrawal, so if the erasure fails, withdrawing the consent
+// again retries it.
+func (s *Service) WithdrawConsent(ctx context.Context, candidateID int64, consentType string) error {
+	if consentType != entity.ConsentTypePersonalData {
+		err := s.store.WithdrawConsent(ctx, candidateID, consentType)
+		if err != nil {
+			return fmt.Errorf("can't withdraw consent: %w", err)
+		}
+
+		return nil
+	}
+
+	var requestID int64
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.store.WithdrawConsent(ctx, candidateID, consentType)
+		if errors.Is(err, inerrors.ErrNotFound) {
+			requestID, err = s.pendingErasureRequestID(ctx, candidateID)
+			if err != nil {
+				return fmt.Errorf("can't withdraw consent: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("can't withdraw consent: %w", err)
+		}
+
+		requestID, err = s.store.CreatePersonalDataRequest(ctx, entity.PersonalDataRequest{
+			CandidateID: candidateID,
+			Type:        entity.PersonalDataRequestTypeErasure,
+			Reason:      "personal data processing consent withdrawn",
+		})
+		if err != nil {
+			return fmt.Errorf("can't log erasure request: %w", err)
+		}
+
+		return nil
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	err = s.erasePersonalData(ctx, candidateID, requestID)
+	if err != nil {
+		return fmt.Errorf("can't erase personal data: %w", err)
+	}
+
+	return nil
+}
+
+// pendingErasureRequestID returns inerrors.ErrNotFound if there is no erasure left unfinished.
+func (s *Service) pendingErasureRequestID(ctx context.Context, candidateID int64) (int64, error) {
+	requests, err := s.store.GetPersonalDataRequests(ctx, candidateID)
+	if err != nil {
+		return 0, fmt.Errorf("can't get personal data requests: %w", err)
+	}
+
+	for _, request := range requests {
+		if request.Type == entity.PersonalDataRequestTypeErasure && request.CompletedAt == nil {
+			return request.ID, nil
+		}
+	}
+
+	return 0, inerrors.ErrNotFound
+}
+
+func (s *Service) GetConsents(ctx context.Context, candidateID int64) ([]entity.Consent, error) {
+	return s.store.GetConsents(ctx, candidateID)
+}
+
+func (s *Service) checkScreeningConsents(ctx context.Context, candidateID int64) error {
+	consents, err := s.store.GetConsents(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't get consents: %w", err)
+	}
+
+	for _, consentType := range screeningConsents {
+		if !entity.HasActiveConsent(consents, consentType) {
+			return fmt.Errorf("%w: %s", inerrors.ErrConsentRequired, consentType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package candidate
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+type contacts struct {
+	FullName         string
+	Phone            string
+	City             string
+	Email            string
+	PreferredContact string
+	HasTelegram      bool
+}
+
+// normalizeContacts trims all fields, brings phone to E.164 and checks that the preferred contact is reachable.
+func normalizeContacts(c contacts) (contacts, error) {
+	c.FullName = strings.Join(strings.Fields(c.FullName), " ")
+	c.City = strings.TrimSpace(c.City)
+	c.Email = strings.TrimSpace(c.Email)
+	c.PreferredContact = strings.TrimSpace(c.PreferredContact)
+
+	if c.Phone = strings.TrimSpace(c.Phone); c.Phone != "" {
+		phone, ok := normalizePhone(c.Phone)
+		if !ok {
+			return contacts{}, fmt.Errorf("%w: invalid phone %q", inerrors.ErrInvalidInput, c.Phone)
+		}
+		c.Phone = phone
+	}
+
+	if c.Email != "" {
+		addr, err := mail.ParseAddress(c.Email)
+		if err != nil || addr.Address != c.Email {
+			return contacts{}, fmt.Errorf("%w: invalid email %q", inerrors.ErrInvalidInput, c.Email)
+		}
+		c.Email = strings.ToLower(c.Email)
+	}
+
+	switch c.PreferredContact {
+	case "":
+	case entity.PreferredContactTelegram:
+		if !c.HasTelegram {
+			return contacts{}, fmt.Errorf("%w: preferred contact is telegram, but telegram is not set", inerrors.ErrInvalidInput)
+		}
+	case entity.PreferredContactPhone:
+		if c.Phone == "" {
+			return contacts{}, fmt.Errorf("%w: preferred contact is phone, but phone is not set", inerrors.ErrInvalidInput)
+		}
+	case entity.PreferredContactEmail:
+		if c.Email == "" {
+			return contacts{}, fmt.Errorf("%w: preferred contact is email, but email is not set", inerrors.ErrInvalidInput)
+		}
+	default:
+		return contacts{}, fmt.Errorf("%w: unknown preferred contact %q", inerrors.ErrInvalidInput, c.PreferredContact)
+	}
+
+	return c, nil
+}
+
+func normalizeCreateRequest(req dto_models.CreateCandidateRequest) (dto_models.CreateCandidateRequest, error) {
+	err := validateConsents(req.Consents)
+	if err != nil {
+		return dto_models.CreateCandidateRequest{}, err
+	}
+
+	c, err := normalizeContacts(contacts{
+		FullName:         req.FullName,
+		Phone:            req.Phone,
+		City:             req.City,
+		Email:            req.Email,
+		PreferredContact: req.PreferredContact,
+		HasTelegram:      req.TelegramID != 0,
+	})
+	if err != nil {
+		return dto_models.CreateCandidateRequest{}, err
+	}
+
+	req.FullName = c.FullName
+	req.Phone = c.Phone
+	req.City = c.City
+	req.Email = c.Email
+	req.PreferredContact = c.PreferredContact
+
+	return req, nil
+}
+
+// upsertToUpdate turns the non-empty fields of the upsert request into an update.
+func upsertToUpdate(req dto_models.CreateCandidateRequest) dto_models.UpdateCandidateRequest {
+	nonEmpty := func(s string) *string {
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		return &s
+	}
+
+	return dto_models.UpdateCandidateRequest{
+		FullName:         nonEmpty(req.FullName),
+		Phone:            nonEmpty(req.Phone),
+		City:             nonEmpty(req.City),
+		Email:            nonEmpty(req.Email),
+		PreferredContact: nonEmpty(req.PreferredContact),
+	}
+}
+
+func (s *Service) Update(ctx context.Context, candidateID int64, req dto_models.UpdateCandidateRequest, source string) (entity.Candidate, error) {
+	var candidate entity.Candidate
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.store.GetByIDForUpdate(ctx, candidateID)
+		if err != nil {
+			return fmt.Errorf("can't get candidate: %w", err)
+		}
+
+		candidate, err = s.update(ctx, current, req, "", source)
+		return err
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return entity.Candidate{}, err
+	}
+
+	return candidate, nil
+}
+
+// update must run in the transaction that locked the candidate. An empty telegramUsername keeps the current one.
+func (s *Service) update(ctx context.Context, candidate entity.Candidate, req dto_models.UpdateCandidateRequest, telegramUsername string, source string) (entity.Candidate, error) {
+	c := contacts{
+		FullName:         candidate.FullName,
+		Phone:            candidate.Phone,
+		City:             candidate.City,
+		Email:            candidate.Email,
+		PreferredContact: candidate.PreferredContact,
+		HasTelegram:      candidate.TelegramID != 0,
+	}
+	if req.FullName != nil {
+		c.FullName = *req.FullName
+	}
+	if req.Phone != nil {
+		c.Phone = *req.Phone
+	}
+	if req.City != nil {
+		c.City = *req.City
+	}
+	if req.Email != nil {
+		c.Email = *req.Email
+	}
+	if req.PreferredContact != nil {
+		c.PreferredContact = *req.PreferredContact
+	}
+
+	c, err := normalizeContacts(c)
+	if err != nil {
+		return entity.Candidate{}, err
+	}
+
+	var changes []entity.CandidateChange
+	addChange := func(field string, old *string, new string) {
+		if *old == new {
+			return
+		}
+		changes = append(changes, entity.CandidateChange{
+			CandidateID: candidate.ID,
+			Field:       field,
+			OldValue:    *old,
+			NewValue:    new,
+			Source:      source,
+		})
+		*old = new
+	}
+	addChange("full_name", &candidate.FullName, c.FullName)
+	addChange("phone", &candidate.Phone, c.Phone)
+	addChange("city", &candidate.City, c.City)
+	addChange("email", &candidate.Email, c.Email)
+	addChange("preferred_contact", &candidate.PreferredContact, c.PreferredContact)
+	if telegramUsername = strings.TrimSpace(telegramUsername); telegramUsername != "" {
+		addChange("telegram_username", &candidate.TelegramUsername, telegramUsername)
+	}
+
+	if len(changes) == 0 {
+		return candidate, nil
+	}
+
+	err = s.store.Update(ctx, candidate, changes)
+	if err != nil {
+		return entity.Candidate{}, fmt.Errorf("can't update candidate: %w", err)
+	}
+
+	return candidate, nil
+}
+
+func (s *Service) GetChanges(ctx context.Context, candidateID int64) ([]entity.CandidateChange, error) {
+	return s.store.GetChanges(ctx, candidateID)
+}
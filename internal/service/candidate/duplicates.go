@@ -0,0 +1,162 @@
+package candidate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hr-helper/internal/entity"
+)
+
+const (
+	minNameSimilarity = 0.85
+	// names are compared only if they share a word starting with the same runes
+	nameBlockPrefixLen = 3
+)
+
+func (s *Service) FindDuplicates(ctx context.Context) ([]entity.CandidateDuplicate, error) {
+	candidates, err := s.store.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get candidates: %w", err)
+	}
+
+	formerPhones, err := s.store.GetFormerPhones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get former phones: %w", err)
+	}
+
+	return findDuplicates(candidates, formerPhones), nil
+}
+
+// findDuplicates compares candidates without a phone by their former one, so that candidates
+// whose phone was cleared as a duplicate by the phone migration are still reported.
+func findDuplicates(candidates []entity.Candidate, formerPhones map[int64]string) []entity.CandidateDuplicate {
+	phones := make([]string, len(candidates))
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		phone := c.Phone
+		if phone == "" {
+			phone = formerPhones[c.ID]
+		}
+		phones[i], _ = normalizePhone(phone)
+		names[i] = normalizeName(c.FullName)
+	}
+
+	var duplicates []entity.CandidateDuplicate
+	for _, pair := range candidatePairs(candidates, phones, names) {
+		i, j := pair[0], pair[1]
+		d := entity.CandidateDuplicate{
+			First:  candidates[i],
+			Second: candidates[j],
+		}
+
+		if candidates[i].TelegramID != 0 && candidates[i].TelegramID == candidates[j].TelegramID {
+			d.Reasons = append(d.Reasons, entity.CandidateDuplicateReasonTelegramID)
+		}
+		if phones[i] != "" && phones[i] == phones[j] {
+			d.Reasons = append(d.Reasons, entity.CandidateDuplicateReasonPhone)
+		}
+		if names[i] != "" && names[j] != "" {
+			d.NameSimilarity = similarity(names[i], names[j])
+			if d.NameSimilarity >= minNameSimilarity {
+				d.Reasons = append(d.Reasons, entity.CandidateDuplicateReasonFullName)
+			}
+		}
+
+		if len(d.Reasons) > 0 {
+			duplicates = append(duplicates, d)
+		}
+	}
+
+	return duplicates
+}
+
+// candidatePairs returns sorted pairs of candidates sharing a telegram id, a phone or a name word prefix,
+// so that only they are compared instead of every pair.
+func candidatePairs(candidates []entity.Candidate, phones []string, names []string) [][2]int {
+	blocks := make(map[string][]int)
+	for i, c := range candidates {
+		if c.TelegramID != 0 {
+			key := "telegram:" + strconv.FormatInt(c.TelegramID, 10)
+			blocks[key] = append(blocks[key], i)
+		}
+		if phones[i] != "" {
+			blocks["phone:"+phones[i]] = append(blocks["phone:"+phones[i]], i)
+		}
+
+		seen := make(map[string]bool)
+		for _, word := range strings.Fields(names[i]) {
+			runes := []rune(word)
+			key := "name:" + string(runes[:min(nameBlockPrefixLen, len(runes))])
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	pairSet := make(map[[2]int]struct{})
+	for _, block := range blocks {
+		for a := range block {
+			for b := a + 1; b < len(block); b++ {
+				pairSet[[2]int{block[a], block[b]}] = struct{}{}
+			}
+		}
+	}
+
+	pairs := slices.Collect(maps.Keys(pairSet))
+	slices.SortFunc(pairs, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+
+	return pairs
+}
+
+// normalizeName makes "Иванов Иван" and "иван  иванов" equal: case, "ё" and word order are ignored.
+func normalizeName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	words := strings.Fields(name)
+	slices.Sort(words)
+
+	return strings.Join(words, " ")
+}
+
+// similarity returns 1 - levenshtein(a, b) / max(len(a), len(b)) over runes.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	maxLen := max(len(ra), len(rb))
+	if maxLen == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
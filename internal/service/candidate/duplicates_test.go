@@ -0,0 +1,168 @@
+package candidate
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"hr-helper/internal/entity"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "", b: "иван", want: 4},
+		{a: "иван", b: "", want: 4},
+		{a: "иван", b: "иван", want: 0},
+		{a: "иван", b: "иваг", want: 1},
+		{a: "иванов", b: "иванова", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "петров", b: "ветров", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "", b: "", want: 1},
+		{a: "иван", b: "иван", want: 1},
+		{a: "иван", b: "иваг", want: 0.75},
+		{a: "abc", b: "xyz", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := similarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Иванов Иван", want: "иван иванов"},
+		{name: "  иван   Иванов ", want: "иван иванов"},
+		{name: "Семён Фёдоров", want: "семен федоров"},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeName(tt.name); got != tt.want {
+				t.Errorf("normalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	tests := []struct {
+		name         string
+		candidates   []entity.Candidate
+		formerPhones map[int64]string
+		want         map[[2]int64][]entity.CandidateDuplicateReason
+	}{
+		{
+			name: "same telegram id",
+			candidates: []entity.Candidate{
+				{ID: 1, TelegramID: 10, FullName: "Иван Иванов"},
+				{ID: 2, TelegramID: 10, FullName: "Пётр Петров"},
+			},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{
+				{1, 2}: {entity.CandidateDuplicateReasonTelegramID},
+			},
+		},
+		{
+			name: "same phone in different formats",
+			candidates: []entity.Candidate{
+				{ID: 1, Phone: "+7 916 123-45-67", FullName: "Иван Иванов"},
+				{ID: 2, Phone: "89161234567", FullName: "Пётр Петров"},
+			},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{
+				{1, 2}: {entity.CandidateDuplicateReasonPhone},
+			},
+		},
+		{
+			name: "similar names in another word order",
+			candidates: []entity.Candidate{
+				{ID: 1, FullName: "Иванов Иван"},
+				{ID: 2, FullName: "иван  иваноф"},
+				{ID: 3, FullName: "Сидоров Пётр"},
+			},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{
+				{1, 2}: {entity.CandidateDuplicateReasonFullName},
+			},
+		},
+		{
+			name: "all reasons",
+			candidates: []entity.Candidate{
+				{ID: 1, TelegramID: 10, Phone: "+79161234567", FullName: "Иван Иванов"},
+				{ID: 2, TelegramID: 10, Phone: "9161234567", FullName: "Иванов Иван"},
+			},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{
+				{1, 2}: {
+					entity.CandidateDuplicateReasonTelegramID,
+					entity.CandidateDuplicateReasonPhone,
+					entity.CandidateDuplicateReasonFullName,
+				},
+			},
+		},
+		{
+			name: "phone cleared by the migration",
+			candidates: []entity.Candidate{
+				{ID: 1, Phone: "+79161234567", FullName: "Иван Иванов"},
+				{ID: 2, FullName: "Пётр Петров"},
+				{ID: 3, Phone: "+79167654321", FullName: "Сидор Сидоров"},
+			},
+			formerPhones: map[int64]string{2: "8 (916) 123-45-67", 3: "+79161234567"},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{
+				{1, 2}: {entity.CandidateDuplicateReasonPhone},
+			},
+		},
+		{
+			name: "different candidates",
+			candidates: []entity.Candidate{
+				{ID: 1, TelegramID: 10, Phone: "+79161234567", FullName: "Иван Иванов"},
+				{ID: 2, TelegramID: 20, Phone: "+79167654321", FullName: "Пётр Петров"},
+				{ID: 3, FullName: "Иван Петров"},
+			},
+			want: map[[2]int64][]entity.CandidateDuplicateReason{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findDuplicates(tt.candidates, tt.formerPhones)
+			if len(got) != len(tt.want) {
+				t.Fatalf("findDuplicates() returned %d pairs, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for _, d := range got {
+				reasons, ok := tt.want[[2]int64{d.First.ID, d.Second.ID}]
+				if !ok {
+					t.Errorf("unexpected pair %d, %d", d.First.ID, d.Second.ID)
+					continue
+				}
+				if !slices.Equal(d.Reasons, reasons) {
+					t.Errorf("pair %d, %d reasons = %v, want %v", d.First.ID, d.Second.ID, d.Reasons, reasons)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package candidate
+
+import (
+	"context"
+	"fmt"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts dobby.TxOptions) error
+}
+
+// publish must be called within the transaction that changes the state the event is about.
+func (s *Service) publish(ctx context.Context, eventType string, data any) error {
+	event, err := entity.NewEvent(eventType, data)
+	if err != nil {
+		return fmt.Errorf("can't create %s event: %w", eventType, err)
+	}
+
+	err = s.publisher.Publish(ctx, event)
+	if err != nil {
+		return fmt.Errorf("can't publish %s event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+// ExportPersonalData collects everything stored about the candidate: profile, notifications, resumes, scores,
+// summaries and answers.
+func (s *Service) ExportPersonalData(ctx context.Context, candidateID int64) (entity.CandidatePersonalData, error) {
+	candidate, err := s.store.GetByID(ctx, candidateID)
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't get candidate: %w", err)
+	}
+
+	requestID, err := s.store.CreatePersonalDataRequest(ctx, entity.PersonalDataRequest{
+		CandidateID: candidateID,
+		Type:        entity.PersonalDataRequestTypeExport,
+	})
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't log export request: %w", err)
+	}
+
+	changes, err := s.store.GetChanges(ctx, candidateID)
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't get changes: %w", err)
+	}
+
+	notifications, err := s.notificationStore.GetTelegramNotifications(ctx, candidateID)
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't get notifications: %w", err)
+	}
+
+	infos, err := s.store.GetCandidateVacancyInfosByCandidateID(ctx, candidateID)
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't get infos: %w", err)
+	}
+
+	data := entity.CandidatePersonalData{
+		Candidate:     candidate,
+		Changes:       changes,
+		Notifications: notifications,
+		Vacancies:     make([]entity.CandidateVacancyPersonalData, 0, len(infos)),
+	}
+	for _, info := range infos {
+		answers, err := s.store.GetCandidateAnswers(ctx, candidateID, info.Vacancy.ID)
+		if err != nil {
+			return entity.CandidatePersonalData{}, fmt.Errorf("can't get answers: %w", err)
+		}
+
+		resume, err := s.resumeStorage.Download(ctx, candidateID, info.Vacancy.ID)
+		if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+			return entity.CandidatePersonalData{}, fmt.Errorf("can't download resume: %w", err)
+		}
+
+		summary, err := s.store.GetCandidateSummary(ctx, candidateID, info.Vacancy.ID)
+		if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+			return entity.CandidatePersonalData{}, fmt.Errorf("can't get summary: %w", err)
+		}
+		if err == nil {
+			info.Summary = &summary
+		}
+
+		data.Vacancies = append(data.Vacancies, entity.CandidateVacancyPersonalData{
+			Info:    info,
+			Answers: answers,
+			Resume:  resume,
+		})
+	}
+
+	err = s.store.CompletePersonalDataRequest(ctx, requestID)
+	if err != nil {
+		return entity.CandidatePersonalData{}, fmt.Errorf("can't complete export request: %w", err)
+	}
+
+	return data, nil
+}
+
+// ErasePersonalData deletes resumes and anonymizes the candidate. Scores and statuses stay for statistics.
+func (s *Service) ErasePersonalData(ctx context.Context, candidateID int64, reason string) error {
+	_, err := s.store.GetByID(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't get candidate: %w", err)
+	}
+
+	requestID, err := s.store.CreatePersonalDataRequest(ctx, entity.PersonalDataRequest{
+		CandidateID: candidateID,
+		Type:        entity.PersonalDataRequestTypeErasure,
+		Reason:      reason,
+	})
+	if err != nil {
+		return fmt.Errorf("can't log erasure request: %w", err)
+	}
+
+	return s.erasePersonalData(ctx, candidateID, requestID)
+}
+
+// erasePersonalData carries out the logged erasure request. Every step can be repeated.
+func (s *Service) erasePersonalData(ctx context.Context, candidateID int64, requestID int64) error {
+	err := s.resumeStorage.DeleteAll(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't delete resumes: %w", err)
+	}
+
+	err = s.store.Anonymize(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't anonymize candidate: %w", err)
+	}
+
+	err = s.store.CompletePersonalDataRequest(ctx, requestID)
+	if err != nil {
+		return fmt.Errorf("can't complete erasure request: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetPersonalDataRequests(ctx context.Context, candidateID int64) ([]entity.PersonalDataRequest, error) {
+	return s.store.GetPersonalDataRequests(ctx, candidateID)
+}
@@ -0,0 +1,44 @@
+package candidate
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	minE164Digits = 8
+	maxE164Digits = 15
+)
+
+// normalizePhone converts a phone number to E.164. Numbers without a country code are treated as Russian ones.
+func normalizePhone(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	hasPlus := strings.HasPrefix(raw, "+")
+
+	var digits strings.Builder
+	for _, r := range raw {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == '+' || r == '(' || r == ')' || r == '-' || r == '.' || unicode.IsSpace(r):
+		default:
+			return "", false
+		}
+	}
+	d := digits.String()
+
+	switch {
+	case !hasPlus && len(d) == 11 && d[0] == '8':
+		d = "7" + d[1:]
+	case !hasPlus && len(d) == 10 && d[0] == '9':
+		d = "7" + d
+	case !hasPlus && !(len(d) == 11 && d[0] == '7'):
+		return "", false
+	}
+
+	if len(d) < minE164Digits || len(d) > maxE164Digits || d[0] == '0' {
+		return "", false
+	}
+
+	return "+" + d, true
+}
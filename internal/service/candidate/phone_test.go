@@ -0,0 +1,34 @@
+package candidate
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		want   string
+		wantOK bool
+	}{
+		{name: "e164", raw: "+79161234567", want: "+79161234567", wantOK: true},
+		{name: "formatted", raw: " +7 (916) 123-45-67 ", want: "+79161234567", wantOK: true},
+		{name: "russian trunk prefix", raw: "8 916 123 45 67", want: "+79161234567", wantOK: true},
+		{name: "without country code", raw: "9161234567", want: "+79161234567", wantOK: true},
+		{name: "russian without plus", raw: "79161234567", want: "+79161234567", wantOK: true},
+		{name: "foreign", raw: "+44.20.7946.0958", want: "+442079460958", wantOK: true},
+		{name: "foreign without plus", raw: "442079460958", wantOK: false},
+		{name: "letters", raw: "+7916abc4567", wantOK: false},
+		{name: "too short", raw: "+1234567", wantOK: false},
+		{name: "too long", raw: "+1234567890123456", wantOK: false},
+		{name: "leading zero", raw: "+0123456789", wantOK: false},
+		{name: "empty", raw: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := normalizePhone(tt.raw)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("normalizePhone(%q) = %q, %v, want %q, %v", tt.raw, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,16 +1,17 @@
 package candidate
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 
 	"hr-helper/internal/dto_models"
 	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
 	"hr-helper/internal/service_models"
 )
 
@@ -20,61 +21,338 @@ const (
 
 type Storage interface {
 	Create(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error)
+	Upsert(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error)
+	GetAll(ctx context.Context) ([]entity.Candidate, error)
+	GetMergedVacancyIDs(ctx context.Context, targetID int64, sourceID int64) ([]uuid.UUID, error)
+	Merge(ctx context.Context, targetID int64, sourceID int64) error
+	GetMergedFiles(ctx context.Context) ([]service_models.MergedFiles, error)
+	DeleteMergedFiles(ctx context.Context, sourceID int64) error
+	GetFormerPhones(ctx context.Context) (map[int64]string, error)
 	GetByTelegramID(ctx context.Context, telegramID int64) (entity.Candidate, error)
+	GetByTelegramIDForUpdate(ctx context.Context, telegramID int64) (entity.Candidate, error)
+	GetByID(ctx context.Context, candidateID int64) (entity.Candidate, error)
+	GetByIDForUpdate(ctx context.Context, candidateID int64) (entity.Candidate, error)
+	Update(ctx context.Context, candidate entity.Candidate, changes []entity.CandidateChange) error
+	GetChanges(ctx context.Context, candidateID int64) ([]entity.CandidateChange, error)
 	UpdateScreeningResult(ctx context.Context, candidateID int64, vacancyID uuid.UUID, result service_models.ResumeScreeningResultWithStatus) error
 	GetResumeScreening(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.ResumeScreening, error)
+	GetCandidateSummary(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateSummary, error)
+	GetAnswerSignals(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.AnswerSignals, error)
 	GetMeta(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.Meta, error)
 	GetCandidateVacancyInfos(ctx context.Context) ([]entity.CandidateVacancyInfo, error)
 	GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error)
 	GetCandidateAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error)
 	Delete(ctx context.Context, candidateID int64) error
+	GetCandidateVacancyInfosByCandidateID(ctx context.Context, candidateID int64) ([]entity.CandidateVacancyInfo, error)
+	Anonymize(ctx context.Context, candidateID int64) error
+	CreatePersonalDataRequest(ctx context.Context, request entity.PersonalDataRequest) (int64, error)
+	CompletePersonalDataRequest(ctx context.Context, requestID int64) error
+	GetPersonalDataRequests(ctx context.Context, candidateID int64) ([]entity.PersonalDataRequest, error)
+	CreateConsents(ctx context.Context, candidateID int64, consents []dto_models.CreateConsentRequest) error
+	GetConsents(ctx context.Context, candidateID int64) ([]entity.Consent, error)
+	WithdrawConsent(ctx context.Context, candidateID int64, consentType string) error
 }
 
 type VacancyStorage interface {
 	GetByID(ctx context.Context, id uuid.UUID) (entity.Vacancy, error)
+	GetRoundResults(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.RoundResult, error)
 }
 
 type LLMClient interface {
 	ScoreResume(ctx context.Context, resumeBase64 string, vacancy entity.Vacancy) (service_models.ResumeScreeningResult, error)
 }
 
+type TextExtractor interface {
+	ExtractText(ctx context.Context, data []byte, contentType string) (string, error)
+}
+
 type ResumeStorage interface {
 	Download(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]byte, error)
 	GetPresignedURL(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (string, error)
+	Copy(ctx context.Context, fromCandidateID int64, toCandidateID int64, vacancyID uuid.UUID) error
+	DeleteAll(ctx context.Context, candidateID int64) error
 }
 
-type Service struct {
-	tikaURL string
+type NotificationStorage interface {
+	GetTelegramNotifications(ctx context.Context, candidateID int64) ([]entity.TelegramNotification, error)
+}
 
-	store         Storage
-	llmClient     LLMClient
-	vacancyStore  VacancyStorage
-	resumeStorage ResumeStorage
+type VoiceStorage interface {
+	CopyAll(ctx context.Context, fromCandidateID int64, toCandidateID int64) error
+}
+
+type Service struct {
+	store             Storage
+	llmClient         LLMClient
+	vacancyStore      VacancyStorage
+	resumeStorage     ResumeStorage
+	voiceStorage      VoiceStorage
+	textExtractor     TextExtractor
+	notificationStore NotificationStorage
+	publisher         EventPublisher
+	transactor        Transactor
 }
 
-func NewService(tikaURL string, store Storage, resumeStorage ResumeStorage, vacancyStorage VacancyStorage, llmClient LLMClient) *Service {
+func NewService(
+	textExtractor TextExtractor,
+	store Storage,
+	resumeStorage ResumeStorage,
+	voiceStorage VoiceStorage,
+	vacancyStorage VacancyStorage,
+	llmClient LLMClient,
+	publisher EventPublisher,
+	transactor Transactor,
+	notificationStorage NotificationStorage,
+) *Service {
 	return &Service{
-		tikaURL:       tikaURL,
-		store:         store,
-		resumeStorage: resumeStorage,
-		vacancyStore:  vacancyStorage,
-		llmClient:     llmClient,
+		textExtractor:     textExtractor,
+		store:             store,
+		resumeStorage:     resumeStorage,
+		voiceStorage:      voiceStorage,
+		vacancyStore:      vacancyStorage,
+		llmClient:         llmClient,
+		publisher:         publisher,
+		transactor:        transactor,
+		notificationStore: notificationStorage,
 	}
 }
 
 func (s *Service) Create(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error) {
-	return s.store.Create(ctx, candidate)
+	candidate, err := normalizeCreateRequest(candidate)
+	if err != nil {
+		return 0, err
+	}
+
+	var candidateID int64
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		candidateID, err = s.store.Create(ctx, candidate)
+		if err != nil {
+			return err
+		}
+
+		return s.publish(ctx, entity.EventTypeCandidateCreated, entity.CandidateCreatedEvent{
+			CandidateID: candidateID,
+		})
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return 0, err
+	}
+
+	return candidateID, nil
+}
+
+// Upsert creates the candidate or fills the existing one with the same Telegram id with the non-empty fields of the request,
+// recording the changed contacts in the history.
+func (s *Service) Upsert(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error) {
+	if candidate.TelegramID == 0 {
+		return 0, fmt.Errorf("%w: upsert requires telegram_id", inerrors.ErrInvalidInput)
+	}
+
+	err := validateConsents(candidate.Consents)
+	if err != nil {
+		return 0, err
+	}
+
+	var candidateID int64
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.store.GetByTelegramIDForUpdate(ctx, candidate.TelegramID)
+		if errors.Is(err, inerrors.ErrNotFound) {
+			candidateID, err = s.upsertNew(ctx, candidate)
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("can't get candidate: %w", err)
+		}
+
+		candidateID = existing.ID
+		_, err = s.update(ctx, existing, upsertToUpdate(candidate), candidate.TelegramUsername, entity.CandidateChangeSourceBot)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.CreateConsents(ctx, candidateID, candidate.Consents)
+		if err != nil {
+			return fmt.Errorf("can't create consents: %w", err)
+		}
+
+		return nil
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return 0, err
+	}
+
+	return candidateID, nil
+}
+
+// upsertNew still upserts, so a concurrent creation of the same candidate is filled instead of failing.
+func (s *Service) upsertNew(ctx context.Context, candidate dto_models.CreateCandidateRequest) (int64, error) {
+	candidate, err := normalizeCreateRequest(candidate)
+	if err != nil {
+		return 0, err
+	}
+
+	candidateID, err := s.store.Upsert(ctx, candidate)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.publish(ctx, entity.EventTypeCandidateCreated, entity.CandidateCreatedEvent{
+		CandidateID: candidateID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return candidateID, nil
+}
+
+func (s *Service) Merge(ctx context.Context, req dto_models.MergeCandidatesRequest) error {
+	if len(req.SourceIDs) == 0 {
+		return fmt.Errorf("%w: no candidates to merge", inerrors.ErrInvalidInput)
+	}
+
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == req.TargetID {
+			return fmt.Errorf("%w: can't merge candidate %d into itself", inerrors.ErrInvalidInput, sourceID)
+		}
+
+		err := s.merge(ctx, req.TargetID, sourceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// merge copies the files and moves the rows while both candidates are locked, so the source can't get
+// a new application in between, and deletes the source files after the commit. A source that is already
+// gone was merged before, only its files are deleted again, so a merge whose deletion failed can be repeated.
+func (s *Service) merge(ctx context.Context, targetID int64, sourceID int64) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sourceExists, err := s.lockMerged(ctx, targetID, sourceID)
+		if err != nil || !sourceExists {
+			return err
+		}
+
+		vacancyIDs, err := s.store.GetMergedVacancyIDs(ctx, targetID, sourceID)
+		if err != nil {
+			return fmt.Errorf("can't get vacancies of candidate %d: %w", sourceID, err)
+		}
+
+		for _, vacancyID := range vacancyIDs {
+			err = s.resumeStorage.Copy(ctx, sourceID, targetID, vacancyID)
+			if errors.Is(err, inerrors.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("can't copy resume of candidate %d for vacancy %s: %w", sourceID, vacancyID, err)
+			}
+		}
+
+		err = s.voiceStorage.CopyAll(ctx, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("can't copy voice answers of candidate %d: %w", sourceID, err)
+		}
+
+		err = s.store.Merge(ctx, targetID, sourceID)
+		if err != nil {
+			return fmt.Errorf("can't merge candidate %d: %w", sourceID, err)
+		}
+
+		return nil
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return err
+	}
+
+	// voice answers live under the same prefix as resumes
+	err = s.resumeStorage.DeleteAll(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("can't delete files of merged candidate %d: %w", sourceID, err)
+	}
+
+	return nil
+}
+
+// MoveMergedFiles finishes the deduplication migration, which can't reach the object storage: resumes of
+// the moved screenings are copied to the candidates the duplicates were collapsed into, the rest is deleted.
+func (s *Service) MoveMergedFiles(ctx context.Context) error {
+	merged, err := s.store.GetMergedFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get merged files: %w", err)
+	}
+
+	for _, files := range merged {
+		if files.TargetID != nil {
+			for _, vacancyID := range files.VacancyIDs {
+				err = s.resumeStorage.Copy(ctx, files.SourceID, *files.TargetID, vacancyID)
+				if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+					return fmt.Errorf("can't copy resume of candidate %d for vacancy %s: %w", files.SourceID, vacancyID, err)
+				}
+			}
+		}
+
+		err = s.resumeStorage.DeleteAll(ctx, files.SourceID)
+		if err != nil {
+			return fmt.Errorf("can't delete files of merged candidate %d: %w", files.SourceID, err)
+		}
+
+		err = s.store.DeleteMergedFiles(ctx, files.SourceID)
+		if err != nil {
+			return fmt.Errorf("can't delete merged files of candidate %d: %w", files.SourceID, err)
+		}
+	}
+
+	return nil
+}
+
+// lockMerged locks both candidates in id order, the same order Merge uses; a missing target is an error.
+func (s *Service) lockMerged(ctx context.Context, targetID int64, sourceID int64) (sourceExists bool, err error) {
+	ids := []int64{targetID, sourceID}
+	slices.Sort(ids)
+
+	sourceExists = true
+	for _, id := range ids {
+		_, err = s.store.GetByIDForUpdate(ctx, id)
+		if errors.Is(err, inerrors.ErrNotFound) && id == sourceID {
+			sourceExists = false
+			continue
+		}
+		if errors.Is(err, inerrors.ErrNotFound) {
+			return false, fmt.Errorf("%w: candidate %d", inerrors.ErrNotFound, id)
+		}
+		if err != nil {
+			return false, fmt.Errorf("can't lock candidate %d: %w", id, err)
+		}
+	}
+
+	return sourceExists, nil
 }
 
 func (s *Service) Delete(ctx context.Context, candidateID int64) error {
+	err := s.resumeStorage.DeleteAll(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't delete resumes: %w", err)
+	}
+
 	return s.store.Delete(ctx, candidateID)
 }
 
+func (s *Service) GetByID(ctx context.Context, candidateID int64) (entity.Candidate, error) {
+	return s.store.GetByID(ctx, candidateID)
+}
+
 func (s *Service) GetByTelegramID(ctx context.Context, telegramID int64) (entity.Candidate, error) {
 	return s.store.GetByTelegramID(ctx, telegramID)
 }
 
 func (s *Service) ScoreCandidateResume(ctx context.Context, req dto_models.ProcessResumeRequest) error {
+	err := s.checkScreeningConsents(ctx, req.CandidateID)
+	if err != nil {
+		return err
+	}
+
 	vacancy, err := s.vacancyStore.GetByID(ctx, req.VacancyID)
 	if err != nil {
 		return fmt.Errorf("can't get vacancy: %w", err)
@@ -85,7 +363,7 @@ func (s *Service) ScoreCandidateResume(ctx context.Context, req dto_models.Proce
 		return fmt.Errorf("can't download resume: %w", err)
 	}
 
-	resumeText, err := s.extractTextFromPDF(resumeBytes)
+	resumeText, err := s.textExtractor.ExtractText(ctx, resumeBytes, "application/pdf")
 	if err != nil {
 		return fmt.Errorf("can't extract text from resume: %w", err)
 	}
@@ -95,40 +373,56 @@ func (s *Service) ScoreCandidateResume(ctx context.Context, req dto_models.Proce
 		return fmt.Errorf("can't score resume via llm: %w", err)
 	}
 
+	oldStatus, err := s.getStatus(ctx, req.CandidateID, req.VacancyID)
+	if err != nil {
+		return err
+	}
+
 	scoringResultWithStatus := service_models.ResumeScreeningResultWithStatus{
 		ResumeScreeningResult: scoringResult,
 		Status:                s.checkScreeningScore(scoringResult.Score),
 	}
 
-	err = s.store.UpdateScreeningResult(ctx, req.CandidateID, req.VacancyID, scoringResultWithStatus)
-	if err != nil {
-		return fmt.Errorf("can't update scoring results: %w", err)
-	}
-
-	return nil
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.store.UpdateScreeningResult(ctx, req.CandidateID, req.VacancyID, scoringResultWithStatus)
+		if err != nil {
+			return fmt.Errorf("can't update scoring results: %w", err)
+		}
+
+		err = s.publish(ctx, entity.EventTypeScreeningCompleted, entity.ScreeningCompletedEvent{
+			CandidateID: req.CandidateID,
+			VacancyID:   req.VacancyID,
+			Score:       scoringResult.Score,
+			Status:      scoringResultWithStatus.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		if oldStatus == scoringResultWithStatus.Status {
+			return nil
+		}
+
+		return s.publish(ctx, entity.EventTypeStatusChanged, entity.StatusChangedEvent{
+			CandidateID: req.CandidateID,
+			VacancyID:   req.VacancyID,
+			OldStatus:   oldStatus,
+			NewStatus:   scoringResultWithStatus.Status,
+		})
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
 }
 
-func (s *Service) extractTextFromPDF(pdfData []byte) (string, error) {
-	req, err := http.NewRequest("PUT", s.tikaURL+"/tika", bytes.NewReader(pdfData))
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Content-Type", "application/pdf")
-	req.Header.Set("Accept", "text/plain")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+// getStatus returns the current application status or "" if the application has no meta yet.
+func (s *Service) getStatus(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (string, error) {
+	meta, err := s.store.GetMeta(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return "", nil
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("can't get meta: %w", err)
 	}
 
-	return string(body), nil
+	return string(meta.Status), nil
 }
 
 func (s *Service) checkScreeningScore(score int) string {
@@ -159,6 +453,19 @@ func (s *Service) GetCandidateVacancyInfo(ctx context.Context, candidateID int64
 	}
 	info.ResumeLink = resumeLink
 
+	summary, err := s.store.GetCandidateSummary(ctx, candidateID, vacancyID)
+	if err != nil && !errors.Is(err, inerrors.ErrNotFound) {
+		return entity.CandidateVacancyInfo{}, fmt.Errorf("can't get summary: %w", err)
+	}
+	if err == nil {
+		info.Summary = &summary
+	}
+
+	info.RoundResults, err = s.vacancyStore.GetRoundResults(ctx, candidateID, vacancyID)
+	if err != nil {
+		return entity.CandidateVacancyInfo{}, fmt.Errorf("can't get round results: %w", err)
+	}
+
 	return info, nil
 }
 
@@ -166,6 +473,27 @@ func (s *Service) GetCandidateVacancyInfos(ctx context.Context) ([]entity.Candid
 	return s.store.GetCandidateVacancyInfos(ctx)
 }
 
+// GetCandidateAnswers returns the answers with their anti-cheating signals, when computed.
 func (s *Service) GetCandidateAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error) {
-	return s.store.GetCandidateAnswers(ctx, candidateID, vacancyID)
+	answers, err := s.store.GetCandidateAnswers(ctx, candidateID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := s.store.GetAnswerSignals(ctx, candidateID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get answer signals: %w", err)
+	}
+
+	signalsByAnswer := make(map[int64]entity.AnswerSignals, len(signals))
+	for _, signal := range signals {
+		signalsByAnswer[signal.AnswerID] = signal
+	}
+	for i := range answers {
+		if signal, ok := signalsByAnswer[answers[i].Answer.ID]; ok {
+			answers[i].Signals = &signal
+		}
+	}
+
+	return answers, nil
 }
@@ -0,0 +1,110 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+func TestServiceUpsertRequiresTelegramID(t *testing.T) {
+	s := &Service{}
+
+	_, err := s.Upsert(context.Background(), dto_models.CreateCandidateRequest{FullName: "Иван"})
+	if !errors.Is(err, inerrors.ErrInvalidInput) {
+		t.Errorf("Upsert() without telegram id error = %v, want invalid input", err)
+	}
+}
+
+type mergeStorageStub struct {
+	Storage
+	existing map[int64]bool
+	locked   []int64
+	merged   bool
+}
+
+func (s *mergeStorageStub) GetByIDForUpdate(_ context.Context, candidateID int64) (entity.Candidate, error) {
+	s.locked = append(s.locked, candidateID)
+	if !s.existing[candidateID] {
+		return entity.Candidate{}, inerrors.ErrNotFound
+	}
+	return entity.Candidate{ID: candidateID}, nil
+}
+
+func (s *mergeStorageStub) GetMergedVacancyIDs(context.Context, int64, int64) ([]uuid.UUID, error) {
+	return []uuid.UUID{uuid.New()}, nil
+}
+
+func (s *mergeStorageStub) Merge(context.Context, int64, int64) error {
+	s.merged = true
+	return nil
+}
+
+type mergeFilesStub struct {
+	ResumeStorage
+	copied  int
+	deleted []int64
+}
+
+func (f *mergeFilesStub) Copy(context.Context, int64, int64, uuid.UUID) error {
+	f.copied++
+	return nil
+}
+
+func (f *mergeFilesStub) CopyAll(context.Context, int64, int64) error {
+	return nil
+}
+
+func (f *mergeFilesStub) DeleteAll(_ context.Context, candidateID int64) error {
+	f.deleted = append(f.deleted, candidateID)
+	return nil
+}
+
+type transactorStub struct{}
+
+func (transactorStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, _ dobby.TxOptions) error {
+	return fn(ctx)
+}
+
+func TestServiceMerge(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    map[int64]bool
+		wantErr     error
+		wantMerged  bool
+		wantDeleted []int64
+	}{
+		{name: "merged", existing: map[int64]bool{1: true, 2: true}, wantMerged: true, wantDeleted: []int64{2}},
+		{name: "repeated after a failed deletion", existing: map[int64]bool{1: true}, wantDeleted: []int64{2}},
+		{name: "missing target", existing: map[int64]bool{2: true}, wantErr: inerrors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mergeStorageStub{existing: tt.existing}
+			files := &mergeFilesStub{}
+			s := &Service{store: store, resumeStorage: files, voiceStorage: files, transactor: transactorStub{}}
+
+			err := s.Merge(context.Background(), dto_models.MergeCandidatesRequest{TargetID: 1, SourceIDs: []int64{2}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(store.locked, []int64{1, 2}) && tt.wantErr == nil {
+				t.Errorf("locked %v, want both candidates in id order", store.locked)
+			}
+			if store.merged != tt.wantMerged || (files.copied > 0) != tt.wantMerged {
+				t.Errorf("merged = %v, copied %d files, want merged %v", store.merged, files.copied, tt.wantMerged)
+			}
+			if !slices.Equal(files.deleted, tt.wantDeleted) {
+				t.Errorf("deleted files of %v, want %v", files.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// utf8BOM makes Excel open the file as UTF-8 instead of the system code page, which breaks Cyrillic.
+const utf8BOM = "\uFEFF"
+
+type csvWriter struct {
+	w        io.Writer
+	csv      *csv.Writer
+	wroteBOM bool
+	record   []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{
+		w:   w,
+		csv: csv.NewWriter(w),
+	}
+}
+
+func (w *csvWriter) WriteRow(cells ...any) error {
+	if !w.wroteBOM {
+		_, err := io.WriteString(w.w, utf8BOM)
+		if err != nil {
+			return err
+		}
+		w.wroteBOM = true
+	}
+
+	w.record = w.record[:0]
+	for _, cell := range cells {
+		if number, ok := numberCell(cell); ok {
+			w.record = append(w.record, number)
+			continue
+		}
+		w.record = append(w.record, escapeFormula(textCell(cell)))
+	}
+
+	return w.csv.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// escapeFormula keeps spreadsheet apps from evaluating user-provided text such as "=HYPERLINK(...)".
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+type Storage interface {
+	EachCandidateVacancyInfo(ctx context.Context, filter service_models.CandidateExportFilter, fn func(entity.CandidateVacancyInfo) error) error
+	EachVacancyAnswerRow(ctx context.Context, vacancyID uuid.UUID, fn func(service_models.VacancyAnswerRow) error) error
+}
+
+type VacancyStorage interface {
+	GetVacancyWithQuestions(ctx context.Context, vacancyID uuid.UUID) (entity.VacancyWithQuestion, error)
+}
+
+type Service struct {
+	store          Storage
+	vacancyStorage VacancyStorage
+}
+
+func NewService(store Storage, vacancyStorage VacancyStorage) *Service {
+	return &Service{
+		store:          store,
+		vacancyStorage: vacancyStorage,
+	}
+}
+
+// ExportCandidates streams the filtered applications with their screening and interview results.
+// On error w may already hold a part of the document.
+func (s *Service) ExportCandidates(ctx context.Context, w io.Writer, format string, filter service_models.CandidateExportFilter) error {
+	table, err := newTableWriter(w, format, "Candidates")
+	if err != nil {
+		return err
+	}
+
+	err = table.WriteRow(
+		"Candidate ID",
+		"Full name",
+		"Email",
+		"Phone",
+		"City",
+		"Telegram",
+		"Vacancy",
+		"Status",
+		"Archived",
+		"Resume score",
+		"Interview score",
+		"Applied at",
+		"Updated at",
+	)
+	if err != nil {
+		return fmt.Errorf("can't write header: %w", err)
+	}
+
+	err = s.store.EachCandidateVacancyInfo(ctx, filter, func(info entity.CandidateVacancyInfo) error {
+		return table.WriteRow(
+			info.Candidate.ID,
+			info.Candidate.FullName,
+			info.Candidate.Email,
+			info.Candidate.Phone,
+			info.Candidate.City,
+			info.Candidate.TelegramUsername,
+			info.Vacancy.Title,
+			string(info.Meta.Status),
+			yesNo(info.Meta.IsArchived),
+			info.ResumeScreening.Score,
+			info.Meta.InterviewScore,
+			info.ResumeScreening.CreatedAt,
+			info.Meta.UpdatedAt,
+		)
+	})
+	if err != nil {
+		return fmt.Errorf("can't write candidates: %w", err)
+	}
+
+	return table.Close()
+}
+
+// ExportAnswerMatrix streams a candidate × question table of answer scores for the vacancy.
+// On error w may already hold a part of the document.
+func (s *Service) ExportAnswerMatrix(ctx context.Context, w io.Writer, format string, vacancyID uuid.UUID) error {
+	vacancy, err := s.vacancyStorage.GetVacancyWithQuestions(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get vacancy: %w", err)
+	}
+
+	table, err := newTableWriter(w, format, vacancy.Title)
+	if err != nil {
+		return err
+	}
+
+	columns := make(map[int64]int, len(vacancy.Questions))
+	header := []any{"Candidate ID", "Full name", "Status", "Archived", "Interview score"}
+	fixed := len(header)
+	for i, question := range vacancy.Questions {
+		columns[question.ID] = fixed + i
+		header = append(header, fmt.Sprintf("Q%d. %s", i+1, question.Content))
+	}
+
+	err = table.WriteRow(header...)
+	if err != nil {
+		return fmt.Errorf("can't write header: %w", err)
+	}
+
+	// rows come grouped by candidate, so only the current candidate is kept in memory
+	var row []any
+	var candidateID int64
+	flush := func() error {
+		if row == nil {
+			return nil
+		}
+		return table.WriteRow(row...)
+	}
+
+	err = s.store.EachVacancyAnswerRow(ctx, vacancyID, func(answer service_models.VacancyAnswerRow) error {
+		if row == nil || answer.Candidate.ID != candidateID {
+			err := flush()
+			if err != nil {
+				return err
+			}
+
+			candidateID = answer.Candidate.ID
+			row = make([]any, len(header))
+			row[0] = answer.Candidate.ID
+			row[1] = answer.Candidate.FullName
+			row[2] = string(answer.Meta.Status)
+			row[3] = yesNo(answer.Meta.IsArchived)
+			row[4] = answer.Meta.InterviewScore
+		}
+
+		if answer.QuestionID != nil {
+			if column, ok := columns[*answer.QuestionID]; ok {
+				row[column] = answer.Score
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("can't write answers: %w", err)
+	}
+
+	err = flush()
+	if err != nil {
+		return fmt.Errorf("can't write answers: %w", err)
+	}
+
+	return table.Close()
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
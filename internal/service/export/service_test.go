@@ -0,0 +1,272 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type storageStub struct {
+	Storage
+	rows []service_models.VacancyAnswerRow
+}
+
+func (s storageStub) EachVacancyAnswerRow(_ context.Context, _ uuid.UUID, fn func(service_models.VacancyAnswerRow) error) error {
+	for _, row := range s.rows {
+		err := fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type vacancyStorageStub struct {
+	vacancy entity.VacancyWithQuestion
+}
+
+func (s vacancyStorageStub) GetVacancyWithQuestions(context.Context, uuid.UUID) (entity.VacancyWithQuestion, error) {
+	return s.vacancy, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "Иван", want: "Иван"},
+		{in: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{in: "+7 999 123-45-67", want: "'+7 999 123-45-67"},
+		{in: "-1", want: "'-1"},
+		{in: "@SUM(A1)", want: "'@SUM(A1)"},
+		{in: "\tcmd", want: "'\tcmd"},
+		{in: "\rcmd", want: "'\rcmd"},
+		{in: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeFormula(tt.in); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNumberCell(t *testing.T) {
+	tests := []struct {
+		name   string
+		cell   any
+		want   string
+		wantOK bool
+	}{
+		{name: "int", cell: 42, want: "42", wantOK: true},
+		{name: "int64", cell: int64(-7), want: "-7", wantOK: true},
+		{name: "float", cell: 0.25, want: "0.25", wantOK: true},
+		{name: "int pointer", cell: ptr(90), want: "90", wantOK: true},
+		{name: "nil int pointer", cell: (*int)(nil)},
+		{name: "numeric string", cell: "42"},
+		{name: "nil", cell: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := numberCell(tt.cell)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("numberCell() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTextCell(t *testing.T) {
+	id := uuid.MustParse("6f1c2a52-8e3b-4a5e-9d55-3f1e2b7c9a10")
+
+	tests := []struct {
+		name string
+		cell any
+		want string
+	}{
+		{name: "nil", cell: nil, want: ""},
+		{name: "nil int pointer", cell: (*int)(nil), want: ""},
+		{name: "string", cell: "Go-разработчик", want: "Go-разработчик"},
+		{name: "time", cell: time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC), want: "2025-03-10 09:05:00"},
+		{name: "stringer", cell: id, want: id.String()},
+		{name: "bool", cell: true, want: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textCell(tt.cell); got != tt.want {
+				t.Errorf("textCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(t *testing.T, data []byte) (workbook string, sheet [][]string) {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("can't open xlsx: %v", err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("can't open %s: %v", f.Name, err)
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("can't read %s: %v", f.Name, err)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("xlsx has no %s", name)
+		}
+	}
+
+	var parsed xlsxSheet
+	err = xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &parsed)
+	if err != nil {
+		t.Fatalf("can't parse sheet: %v", err)
+	}
+	for _, row := range parsed.Rows {
+		var cells []string
+		for _, cell := range row.Cells {
+			if cell.Type == "inlineStr" {
+				cells = append(cells, cell.Inline)
+				continue
+			}
+			cells = append(cells, cell.Value)
+		}
+		sheet = append(sheet, cells)
+	}
+
+	return string(files["xl/workbook.xml"]), sheet
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newXLSXWriter(&buf, "Go [senior]: backend/platform & infra team")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := [][]any{
+		{"ID", "Name", "Score"},
+		{int64(1), "Анна <admin> & co", ptr(95)},
+		{int64(2), "=1+1", nil},
+	}
+	for _, row := range rows {
+		err = w.WriteRow(row...)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workbook, sheet := readXLSX(t, buf.Bytes())
+	if !strings.Contains(workbook, `name="Go senior backendplatform &amp; inf"`) {
+		t.Errorf("workbook doesn't have the sanitized sheet name: %s", workbook)
+	}
+
+	// inline strings are never evaluated, so formulas are kept as they are
+	want := [][]string{
+		{"ID", "Name", "Score"},
+		{"1", "Анна <admin> & co", "95"},
+		{"2", "=1+1", ""},
+	}
+	if !slices.EqualFunc(sheet, want, slices.Equal[[]string]) {
+		t.Errorf("sheet = %q, want %q", sheet, want)
+	}
+}
+
+func TestNewTableWriterUnknownFormat(t *testing.T) {
+	_, err := newTableWriter(io.Discard, "pdf", "Sheet")
+	if !errors.Is(err, inerrors.ErrInvalidInput) {
+		t.Errorf("newTableWriter() error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestServiceExportAnswerMatrix(t *testing.T) {
+	vacancy := entity.VacancyWithQuestion{
+		Title: "Go",
+		Questions: []entity.Question{
+			{ID: 10, Content: "Каналы"},
+			{ID: 20, Content: "Горутины"},
+		},
+	}
+	anna := entity.Candidate{ID: 1, FullName: "Анна"}
+	boris := entity.Candidate{ID: 2, FullName: "=Борис"}
+	vera := entity.Candidate{ID: 3, FullName: "Вера"}
+	rows := []service_models.VacancyAnswerRow{
+		{Candidate: anna, Meta: entity.Meta{Status: entity.CandidateVacancyStatusInterviewOk, InterviewScore: ptr(80)}, QuestionID: ptr(int64(20)), Score: ptr(70)},
+		{Candidate: anna, Meta: entity.Meta{Status: entity.CandidateVacancyStatusInterviewOk, InterviewScore: ptr(80)}, QuestionID: ptr(int64(10)), Score: ptr(90)},
+		// an answer to a question deleted from the vacancy
+		{Candidate: anna, Meta: entity.Meta{Status: entity.CandidateVacancyStatusInterviewOk, InterviewScore: ptr(80)}, QuestionID: ptr(int64(30)), Score: ptr(10)},
+		{Candidate: boris, Meta: entity.Meta{Status: entity.CandidateVacancyStatusScreeningOk, IsArchived: true}, QuestionID: ptr(int64(10)), Score: ptr(40)},
+		// a candidate without answers
+		{Candidate: vera, Meta: entity.Meta{Status: entity.CandidateVacancyStatusScreeningFailed}},
+	}
+
+	var buf bytes.Buffer
+	s := NewService(storageStub{rows: rows}, vacancyStorageStub{vacancy: vacancy})
+	err := s.ExportAnswerMatrix(context.Background(), &buf, FormatCSV, uuid.New())
+	if err != nil {
+		t.Fatalf("ExportAnswerMatrix() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, utf8BOM) {
+		t.Error("csv has no BOM")
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(out, utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatalf("can't read csv: %v", err)
+	}
+
+	want := [][]string{
+		{"Candidate ID", "Full name", "Status", "Archived", "Interview score", "Q1. Каналы", "Q2. Горутины"},
+		{"1", "Анна", "interview_ok", "no", "80", "90", "70"},
+		{"2", "'=Борис", "screening_ok", "yes", "", "40", ""},
+		{"3", "Вера", "screening_failed", "no", "", "", ""},
+	}
+	if !slices.EqualFunc(records, want, slices.Equal[[]string]) {
+		t.Errorf("matrix = %q, want %q", records, want)
+	}
+}
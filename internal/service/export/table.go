@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"hr-helper/internal/inerrors"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// tableWriter streams rows of a single table. Cells are strings, numbers, times or nil for empty ones.
+type tableWriter interface {
+	WriteRow(cells ...any) error
+	// Close flushes buffered rows and finishes the document, it doesn't close the underlying writer.
+	Close() error
+}
+
+func newTableWriter(w io.Writer, format string, sheetName string) (tableWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheetName)
+	default:
+		return nil, fmt.Errorf("%w: unknown export format %q", inerrors.ErrInvalidInput, format)
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format string) (string, error) {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", nil
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+	default:
+		return "", fmt.Errorf("%w: unknown export format %q", inerrors.ErrInvalidInput, format)
+	}
+}
+
+// numberCell returns the cell formatted as a number, ok is false for non-numeric cells.
+func numberCell(cell any) (string, bool) {
+	switch v := cell.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case *int:
+		if v == nil {
+			return "", false
+		}
+		return strconv.Itoa(*v), true
+	default:
+		return "", false
+	}
+}
+
+func textCell(cell any) string {
+	switch v := cell.(type) {
+	case nil, *int:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.DateTime)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,146 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Minimal SpreadsheetML package with a single worksheet. Strings are stored inline,
+// so rows can be written as they come without building a shared strings table in memory.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+const maxSheetNameLength = 31
+
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+}
+
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: xlsxContentTypes},
+		{name: "_rels/.rels", content: xlsxRels},
+		{name: "xl/workbook.xml", content: fmt.Sprintf(xlsxWorkbook, escapeXML(sanitizeSheetName(sheetName)))},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("can't create %s: %w", part.name, err)
+		}
+		_, err = io.WriteString(pw, part.content)
+		if err != nil {
+			return nil, fmt.Errorf("can't write %s: %w", part.name, err)
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("can't create sheet: %w", err)
+	}
+
+	xw := &xlsxWriter{
+		zip:   zw,
+		sheet: bufio.NewWriter(sheet),
+	}
+	_, err = xw.sheet.WriteString(xlsxSheetHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (w *xlsxWriter) WriteRow(cells ...any) error {
+	w.sheet.WriteString("<row>")
+	for _, cell := range cells {
+		if number, ok := numberCell(cell); ok {
+			w.sheet.WriteString(`<c><v>` + number + `</v></c>`)
+			continue
+		}
+
+		text := textCell(cell)
+		if text == "" {
+			w.sheet.WriteString("<c/>")
+			continue
+		}
+		w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(w.sheet, []byte(text))
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := w.sheet.WriteString("</row>")
+
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	_, err := w.sheet.WriteString(xlsxSheetFooter)
+	if err != nil {
+		return err
+	}
+
+	err = w.sheet.Flush()
+	if err != nil {
+		return err
+	}
+
+	return w.zip.Close()
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// sanitizeSheetName drops characters Excel forbids in sheet names and cuts the name to its length limit.
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	runes := []rune(strings.TrimSpace(name))
+	if len(runes) > maxSheetNameLength {
+		runes = runes[:maxSheetNameLength]
+	}
+	if len(runes) == 0 {
+		return "Sheet1"
+	}
+
+	return string(runes)
+}
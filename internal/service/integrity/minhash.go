@@ -0,0 +1,90 @@
+package integrity
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	shingleSize   = 3
+	signatureSize = 128
+)
+
+// signature is a MinHash sketch of the text word shingles, the share of equal positions
+// of two signatures estimates the Jaccard similarity of their shingle sets.
+type signature []uint64
+
+func newSignature(text string) signature {
+	shingles := wordShingles(text)
+	if len(shingles) == 0 {
+		return nil
+	}
+
+	sig := make(signature, signatureSize)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+
+	for _, shingle := range shingles {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		base := h.Sum64()
+
+		for i := range sig {
+			value := mix(base ^ (uint64(i+1) * 0x9e3779b97f4a7c15))
+			if value < sig[i] {
+				sig[i] = value
+			}
+		}
+	}
+
+	return sig
+}
+
+func (s signature) similarity(other signature) float64 {
+	if len(s) == 0 || len(other) == 0 {
+		return 0
+	}
+
+	equal := 0
+	for i := range s {
+		if s[i] == other[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(s))
+}
+
+// wordShingles splits the normalized text into overlapping word n-grams,
+// texts shorter than a shingle become a single shingle.
+func wordShingles(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil
+	}
+	if len(words) < shingleSize {
+		return []string{strings.Join(words, " ")}
+	}
+
+	shingles := make([]string, 0, len(words)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+shingleSize], " "))
+	}
+
+	return shingles
+}
+
+// mix is the splitmix64 finalizer, it turns the seeded shingle hash into an independent hash function.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
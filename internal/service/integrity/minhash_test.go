@@ -0,0 +1,103 @@
+package integrity
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWordShingles(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "empty", text: " ,.! ", want: nil},
+		{name: "shorter than a shingle", text: "Горутины, каналы!", want: []string{"горутины каналы"}},
+		{
+			name: "overlapping",
+			text: "Канал — это труба; между ГОРУТИНАМИ 2",
+			want: []string{"канал это труба", "это труба между", "труба между горутинами", "между горутинами 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordShingles(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("wordShingles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// jaccard is the exact similarity the signatures estimate.
+func jaccard(a string, b string) float64 {
+	setA := make(map[string]bool)
+	for _, s := range wordShingles(a) {
+		setA[s] = true
+	}
+	setB := make(map[string]bool)
+	for _, s := range wordShingles(b) {
+		setB[s] = true
+	}
+
+	intersection := 0
+	for s := range setA {
+		if setB[s] {
+			intersection++
+		}
+	}
+
+	return float64(intersection) / float64(len(setA)+len(setB)-intersection)
+}
+
+func TestSignatureSimilarity(t *testing.T) {
+	base := strings.Repeat("горутина это легковесный поток выполнения управляемый рантаймом go ", 3) +
+		"каналы позволяют передавать данные между горутинами без явных блокировок мьютексами"
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "same text", a: base, b: base},
+		{name: "case and punctuation", a: base, b: strings.ToUpper(base) + "!!!"},
+		{
+			name: "partly rewritten",
+			a:    base,
+			b:    strings.Replace(base, "каналы позволяют передавать данные", "очереди помогают пересылать сообщения", 1),
+		},
+		{
+			name: "different",
+			a:    base,
+			b:    "индекс в postgres ускоряет поиск по столбцу но замедляет вставку и занимает место на диске",
+		},
+		{name: "empty", a: base, b: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSignature(tt.a).similarity(newSignature(tt.b))
+			want := jaccard(tt.a, tt.b)
+
+			// the standard error of the estimate is at most 0.5/sqrt(signatureSize) ≈ 0.044
+			if math.Abs(got-want) > 0.15 {
+				t.Errorf("similarity() = %v, want about %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSignatureDeterministic(t *testing.T) {
+	text := "defer выполняется при выходе из функции в обратном порядке"
+	if !slices.Equal(newSignature(text), newSignature(text)) {
+		t.Error("signatures of the same text differ")
+	}
+	if sig := newSignature(text); len(sig) != signatureSize {
+		t.Errorf("signature has %d values, want %d", len(sig), signatureSize)
+	}
+	if sig := newSignature("..."); sig != nil {
+		t.Errorf("signature of a text without words = %v, want nil", sig)
+	}
+}
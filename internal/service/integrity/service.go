@@ -0,0 +1,184 @@
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	// maxCharsPerMinute is well above a fast typist, answers typed faster are most likely pasted.
+	maxCharsPerMinute = 500
+	// minTypingChars skips short answers, where time_taken is dominated by thinking.
+	minTypingChars = 100
+	// minCopyChars skips short answers, which match others and the reference by chance.
+	minCopyChars = 100
+
+	maxPeerSimilarity      = 0.6
+	maxReferenceSimilarity = 0.6
+	maxAILikelihood        = 0.7
+)
+
+type Storage interface {
+	GetVacancyAnswers(ctx context.Context, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error)
+	GetConsents(ctx context.Context, candidateID int64) ([]entity.Consent, error)
+	UpsertAnswerSignals(ctx context.Context, signals entity.AnswerSignals) error
+}
+
+type LLMClient interface {
+	JudgeAIGenerated(ctx context.Context, question string, answer string) (service_models.AIGeneratedJudgement, error)
+}
+
+type Service struct {
+	store     Storage
+	llmClient LLMClient
+}
+
+func NewService(store Storage, llmClient LLMClient) *Service {
+	return &Service{
+		store:     store,
+		llmClient: llmClient,
+	}
+}
+
+// Publish computes answer signals on interview.completed events, so the service can be an outbox sink.
+func (s *Service) Publish(ctx context.Context, event entity.Event) error {
+	if event.Type != entity.EventTypeInterviewCompleted {
+		return nil
+	}
+
+	var data entity.InterviewCompletedEvent
+	err := json.Unmarshal(event.Data, &data)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal event data: %w", err)
+	}
+
+	return s.ComputeSignals(ctx, data.CandidateID, data.VacancyID)
+}
+
+// ComputeSignals replaces the signals of the candidate answers to the vacancy questions.
+// Peers are the answers given so far, so earlier candidates aren't flagged for answers copied from them later.
+// The LLM judgement is skipped without the ai_evaluation consent.
+func (s *Service) ComputeSignals(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	consents, err := s.store.GetConsents(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't get consents: %w", err)
+	}
+	allowed := entity.HasActiveConsent(consents, entity.ConsentTypeAIEvaluation)
+
+	vacancyAnswers, err := s.store.GetVacancyAnswers(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get vacancy answers: %w", err)
+	}
+
+	peersByQuestion := make(map[int64][]peer)
+	var answers []entity.CandidateQuestionAnswer
+	for _, qa := range vacancyAnswers {
+		if !isFreeForm(qa.Question) {
+			// choice and numeric answers are expected to match
+			continue
+		}
+		if qa.Answer.CandidateID == candidateID {
+			answers = append(answers, qa)
+			continue
+		}
+		if qa.Answer.Content == "" {
+			// anonymized
+			continue
+		}
+
+		peersByQuestion[qa.Question.ID] = append(peersByQuestion[qa.Question.ID], peer{
+			answerID:  qa.Answer.ID,
+			signature: newSignature(qa.Answer.Content),
+		})
+	}
+
+	for _, qa := range answers {
+		signals := computeSignals(qa, peersByQuestion[qa.Question.ID])
+
+		if allowed {
+			judgement, err := s.llmClient.JudgeAIGenerated(ctx, qa.Question.Content, qa.Answer.Content)
+			if err != nil {
+				return fmt.Errorf("can't judge answer %d via llm: %w", qa.Answer.ID, err)
+			}
+
+			signals.AILikelihood = &judgement.Likelihood
+			signals.AIReason = judgement.Reason
+			if judgement.Likelihood >= maxAILikelihood {
+				signals.Flags = append(signals.Flags, entity.AnswerFlagAIGenerated)
+			}
+		}
+
+		signals.Risk = risk(signals.Flags)
+
+		err = s.store.UpsertAnswerSignals(ctx, signals)
+		if err != nil {
+			return fmt.Errorf("can't save signals of answer %d: %w", qa.Answer.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func isFreeForm(question entity.Question) bool {
+	return question.Type != entity.QuestionTypeSingleChoice &&
+		question.Type != entity.QuestionTypeMultipleChoice &&
+		question.Type != entity.QuestionTypeNumeric
+}
+
+type peer struct {
+	answerID  int64
+	signature signature
+}
+
+// computeSignals fills the signals which don't need the LLM.
+func computeSignals(qa entity.CandidateQuestionAnswer, peers []peer) entity.AnswerSignals {
+	signals := entity.AnswerSignals{
+		AnswerID: qa.Answer.ID,
+		Flags:    []string{},
+	}
+
+	chars := utf8.RuneCountInString(qa.Answer.Content)
+	if !qa.Answer.Voice && qa.Answer.TimeTaken > 0 {
+		signals.CharsPerMinute = float64(chars) / (float64(qa.Answer.TimeTaken) / 60)
+		if chars >= minTypingChars && signals.CharsPerMinute > maxCharsPerMinute {
+			signals.Flags = append(signals.Flags, entity.AnswerFlagFastTyping)
+		}
+	}
+
+	answerSignature := newSignature(qa.Answer.Content)
+	for _, p := range peers {
+		similarity := answerSignature.similarity(p.signature)
+		if similarity > signals.PeerSimilarity {
+			signals.PeerSimilarity = similarity
+			signals.SimilarAnswerID = &p.answerID
+		}
+	}
+	if chars >= minCopyChars && signals.PeerSimilarity >= maxPeerSimilarity {
+		signals.Flags = append(signals.Flags, entity.AnswerFlagPeerCopy)
+	}
+
+	signals.ReferenceSimilarity = answerSignature.similarity(newSignature(qa.Question.Reference))
+	if chars >= minCopyChars && signals.ReferenceSimilarity >= maxReferenceSimilarity {
+		signals.Flags = append(signals.Flags, entity.AnswerFlagReferenceCopy)
+	}
+
+	return signals
+}
+
+func risk(flags []string) string {
+	switch {
+	case len(flags) == 0:
+		return entity.AnswerRiskLow
+	case len(flags) == 1:
+		return entity.AnswerRiskMedium
+	default:
+		return entity.AnswerRiskHigh
+	}
+}
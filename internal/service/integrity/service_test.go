@@ -0,0 +1,75 @@
+package integrity
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"hr-helper/internal/entity"
+)
+
+func TestComputeSignals(t *testing.T) {
+	long := "горутина это легковесный поток выполнения управляемый рантаймом go, " +
+		"каналы позволяют передавать данные между горутинами без явных блокировок мьютексами"
+	short := "использовал каналы и мьютексы"
+
+	tests := []struct {
+		name      string
+		answer    entity.Answer
+		reference string
+		peers     []string
+		wantFlags []string
+	}{
+		{
+			name:      "long answer copied from a peer",
+			answer:    entity.Answer{Content: long, TimeTaken: 600},
+			peers:     []string{"не знаю", long},
+			wantFlags: []string{entity.AnswerFlagPeerCopy},
+		},
+		{
+			name:      "long answer copied from the reference",
+			answer:    entity.Answer{Content: long, TimeTaken: 600},
+			reference: long,
+			wantFlags: []string{entity.AnswerFlagReferenceCopy},
+		},
+		{
+			name:      "short answer matching a peer and the reference",
+			answer:    entity.Answer{Content: short, TimeTaken: 60},
+			reference: short,
+			peers:     []string{short},
+			wantFlags: []string{},
+		},
+		{
+			name:      "long answer typed too fast",
+			answer:    entity.Answer{Content: long, TimeTaken: 5},
+			wantFlags: []string{entity.AnswerFlagFastTyping},
+		},
+		{
+			name:      "short answer typed fast",
+			answer:    entity.Answer{Content: short, TimeTaken: 1},
+			wantFlags: []string{},
+		},
+		{
+			name:      "voice answer",
+			answer:    entity.Answer{Content: strings.Repeat(long, 3), TimeTaken: 5, Voice: true},
+			wantFlags: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peers := make([]peer, 0, len(tt.peers))
+			for i, content := range tt.peers {
+				peers = append(peers, peer{answerID: int64(i + 1), signature: newSignature(content)})
+			}
+
+			signals := computeSignals(entity.CandidateQuestionAnswer{
+				Question: entity.Question{Reference: tt.reference},
+				Answer:   tt.answer,
+			}, peers)
+			if !slices.Equal(signals.Flags, tt.wantFlags) {
+				t.Errorf("computeSignals() flags = %q, want %q", signals.Flags, tt.wantFlags)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxTrackedChats bounds the per-chat state; entries that can't delay anyone anymore are dropped past it.
+const maxTrackedChats = 10_000
+
+// limiter spaces messages by interval overall and by chatInterval within a chat.
+type limiter struct {
+	mu           sync.Mutex
+	interval     time.Duration
+	chatInterval time.Duration
+	next         time.Time
+	chatNext     map[int64]time.Time
+}
+
+func newLimiter(interval time.Duration, chatInterval time.Duration) *limiter {
+	return &limiter{
+		interval:     interval,
+		chatInterval: chatInterval,
+		chatNext:     make(map[int64]time.Time),
+	}
+}
+
+// Wait blocks until a message to chatID may be sent and reserves that slot.
+func (l *limiter) Wait(ctx context.Context, chatID int64) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := now
+	if l.next.After(at) {
+		at = l.next
+	}
+	if chatNext := l.chatNext[chatID]; chatNext.After(at) {
+		at = chatNext
+	}
+
+	l.next = at.Add(l.interval)
+	l.chatNext[chatID] = at.Add(l.chatInterval)
+
+	if len(l.chatNext) > maxTrackedChats {
+		for id, t := range l.chatNext {
+			if t.Before(now) {
+				delete(l.chatNext, id)
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Pause holds all messages for d, as Telegram demands after a 429 response.
+func (l *limiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
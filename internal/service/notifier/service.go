@@ -0,0 +1,202 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/adapter/telegram"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+)
+
+type Storage interface {
+	CreateTelegramNotification(ctx context.Context, notification entity.TelegramNotification) error
+	IsTelegramNotificationSent(ctx context.Context, eventID uuid.UUID) (bool, error)
+	GetTelegramNotifications(ctx context.Context, candidateID int64) ([]entity.TelegramNotification, error)
+}
+
+type CandidateStorage interface {
+	GetByID(ctx context.Context, candidateID int64) (entity.Candidate, error)
+}
+
+type VacancyStorage interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Vacancy, error)
+}
+
+type Bot interface {
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
+
+type Config struct {
+	// MessagesPerSecond limits all messages together; Telegram allows about 30.
+	MessagesPerSecond int `yaml:"messages_per_second"`
+	// ChatInterval is the minimal interval between messages to one chat; Telegram allows about one per second.
+	ChatInterval time.Duration `yaml:"chat_interval"`
+	// Templates are html/template texts by candidate vacancy status; statuses without a template aren't notified.
+	Templates map[string]string `yaml:"templates"`
+}
+
+func (c Config) Validate() error {
+	if c.MessagesPerSecond <= 0 {
+		return fmt.Errorf("messages_per_second must be positive")
+	}
+	if c.ChatInterval < 0 {
+		return fmt.Errorf("chat_interval must not be negative")
+	}
+
+	statuses := []string{
+		entity.CandidateVacancyStatusScreeningOk,
+		entity.CandidateVacancyStatusScreeningFailed,
+		entity.CandidateVacancyStatusInterviewOk,
+		entity.CandidateVacancyStatusInterviewFailed,
+		entity.CandidateVacancyStatusInterviewScheduled,
+	}
+	for status := range c.Templates {
+		if !slices.Contains(statuses, status) {
+			return fmt.Errorf("template for unknown status %q", status)
+		}
+	}
+
+	return nil
+}
+
+// templateData is available in message templates.
+type templateData struct {
+	FullName     string
+	VacancyTitle string
+	Status       string
+}
+
+type Service struct {
+	store          Storage
+	candidateStore CandidateStorage
+	vacancyStore   VacancyStorage
+	bot            Bot
+	templates      map[string]*template.Template
+	limiter        *limiter
+}
+
+func NewService(cfg Config, store Storage, candidateStore CandidateStorage, vacancyStore VacancyStorage, bot Bot) (*Service, error) {
+	templates := make(map[string]*template.Template, len(cfg.Templates))
+	for status, text := range cfg.Templates {
+		tmpl, err := template.New(status).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse template for status %s: %w", status, err)
+		}
+		templates[status] = tmpl
+	}
+
+	return &Service{
+		store:          store,
+		candidateStore: candidateStore,
+		vacancyStore:   vacancyStore,
+		bot:            bot,
+		templates:      templates,
+		limiter:        newLimiter(time.Second/time.Duration(cfg.MessagesPerSecond), cfg.ChatInterval),
+	}, nil
+}
+
+// Publish notifies the candidate about status.changed events and ignores the rest, so the service can be an outbox sink.
+func (s *Service) Publish(ctx context.Context, event entity.Event) error {
+	if event.Type != entity.EventTypeStatusChanged {
+		return nil
+	}
+
+	var data entity.StatusChangedEvent
+	err := json.Unmarshal(event.Data, &data)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal event data: %w", err)
+	}
+
+	tmpl, ok := s.templates[data.NewStatus]
+	if !ok {
+		return nil
+	}
+
+	sent, err := s.store.IsTelegramNotificationSent(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("can't check notification: %w", err)
+	}
+	if sent {
+		return nil
+	}
+
+	candidate, err := s.candidateStore.GetByID(ctx, data.CandidateID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get candidate: %w", err)
+	}
+	// erased candidates have no telegram id
+	if candidate.TelegramID == 0 {
+		return nil
+	}
+
+	vacancy, err := s.vacancyStore.GetByID(ctx, data.VacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get vacancy: %w", err)
+	}
+
+	var text bytes.Buffer
+	err = tmpl.Execute(&text, templateData{
+		FullName:     candidate.FullName,
+		VacancyTitle: vacancy.Title,
+		Status:       data.NewStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("can't execute template: %w", err)
+	}
+
+	err = s.limiter.Wait(ctx, candidate.TelegramID)
+	if err != nil {
+		return err
+	}
+
+	notification := entity.TelegramNotification{
+		EventID:     event.ID,
+		CandidateID: candidate.ID,
+		VacancyID:   data.VacancyID,
+		TelegramID:  candidate.TelegramID,
+		Status:      data.NewStatus,
+		Text:        text.String(),
+	}
+
+	sendErr := s.bot.SendMessage(ctx, candidate.TelegramID, text.String())
+	if sendErr != nil {
+		notification.Error = sendErr.Error()
+	}
+
+	err = s.store.CreateTelegramNotification(ctx, notification)
+	if err != nil {
+		loggy.Errorf("can't save telegram notification for event %s: %v", event.ID, err)
+	}
+
+	var apiErr *telegram.APIError
+	if errors.As(sendErr, &apiErr) {
+		if apiErr.RetryAfter > 0 {
+			s.limiter.Pause(apiErr.RetryAfter)
+		}
+		if apiErr.Permanent() {
+			return nil
+		}
+	}
+	if sendErr != nil {
+		return fmt.Errorf("can't send message: %w", sendErr)
+	}
+
+	return nil
+}
+
+func (s *Service) GetTelegramNotifications(ctx context.Context, candidateID int64) ([]entity.TelegramNotification, error) {
+	return s.store.GetTelegramNotifications(ctx, candidateID)
+}
@@ -0,0 +1,233 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/adapter/telegram"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+)
+
+func TestMain(m *testing.M) {
+	loggy.InitDefault()
+	os.Exit(m.Run())
+}
+
+type storageStub struct {
+	sent          bool
+	notifications []entity.TelegramNotification
+}
+
+func (s *storageStub) CreateTelegramNotification(_ context.Context, notification entity.TelegramNotification) error {
+	s.notifications = append(s.notifications, notification)
+	return nil
+}
+
+func (s *storageStub) IsTelegramNotificationSent(context.Context, uuid.UUID) (bool, error) {
+	return s.sent, nil
+}
+
+func (s *storageStub) GetTelegramNotifications(context.Context, int64) ([]entity.TelegramNotification, error) {
+	return s.notifications, nil
+}
+
+type candidateStorageStub map[int64]entity.Candidate
+
+func (s candidateStorageStub) GetByID(_ context.Context, candidateID int64) (entity.Candidate, error) {
+	candidate, ok := s[candidateID]
+	if !ok {
+		return entity.Candidate{}, inerrors.ErrNotFound
+	}
+	return candidate, nil
+}
+
+type vacancyStorageStub struct{}
+
+func (vacancyStorageStub) GetByID(_ context.Context, id uuid.UUID) (entity.Vacancy, error) {
+	return entity.Vacancy{ID: id, Title: "Go <developer>"}, nil
+}
+
+type botStub struct {
+	err  error
+	sent []string
+}
+
+func (b *botStub) SendMessage(_ context.Context, _ int64, text string) error {
+	b.sent = append(b.sent, text)
+	return b.err
+}
+
+func statusChanged(t *testing.T, candidateID int64, status string) entity.Event {
+	t.Helper()
+
+	event, err := entity.NewEvent(entity.EventTypeStatusChanged, entity.StatusChangedEvent{
+		CandidateID: candidateID,
+		VacancyID:   uuid.New(),
+		NewStatus:   status,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return event
+}
+
+func TestServicePublish(t *testing.T) {
+	cfg := Config{
+		MessagesPerSecond: 1000,
+		Templates: map[string]string{
+			entity.CandidateVacancyStatusScreeningOk: "{{.FullName}}, резюме на «{{.VacancyTitle}}» прошло отбор",
+		},
+	}
+	candidates := candidateStorageStub{
+		1: {ID: 1, TelegramID: 100, FullName: "Иван"},
+		2: {ID: 2, FullName: ""},
+	}
+	createdEvent, err := entity.NewEvent(entity.EventTypeCandidateCreated, entity.CandidateCreatedEvent{CandidateID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		event       entity.Event
+		alreadySent bool
+		botErr      error
+		wantSent    []string
+		wantSaved   string // error of the saved notification, "-" if nothing is saved
+		wantErr     bool
+	}{
+		{
+			name:      "sent",
+			event:     statusChanged(t, 1, entity.CandidateVacancyStatusScreeningOk),
+			wantSent:  []string{"Иван, резюме на «Go &lt;developer&gt;» прошло отбор"},
+			wantSaved: "",
+		},
+		{
+			name:      "other event",
+			event:     createdEvent,
+			wantSaved: "-",
+		},
+		{
+			name:      "status without template",
+			event:     statusChanged(t, 1, entity.CandidateVacancyStatusInterviewOk),
+			wantSaved: "-",
+		},
+		{
+			name:        "already sent",
+			event:       statusChanged(t, 1, entity.CandidateVacancyStatusScreeningOk),
+			alreadySent: true,
+			wantSaved:   "-",
+		},
+		{
+			name:      "erased candidate",
+			event:     statusChanged(t, 2, entity.CandidateVacancyStatusScreeningOk),
+			wantSaved: "-",
+		},
+		{
+			name:      "deleted candidate",
+			event:     statusChanged(t, 3, entity.CandidateVacancyStatusScreeningOk),
+			wantSaved: "-",
+		},
+		{
+			name:      "blocked by user",
+			event:     statusChanged(t, 1, entity.CandidateVacancyStatusScreeningOk),
+			botErr:    &telegram.APIError{Code: 403, Description: "blocked"},
+			wantSent:  []string{"Иван, резюме на «Go &lt;developer&gt;» прошло отбор"},
+			wantSaved: "telegram error 403: blocked",
+		},
+		{
+			name:      "temporary error",
+			event:     statusChanged(t, 1, entity.CandidateVacancyStatusScreeningOk),
+			botErr:    errors.New("timeout"),
+			wantSent:  []string{"Иван, резюме на «Go &lt;developer&gt;» прошло отбор"},
+			wantSaved: "timeout",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storageStub{sent: tt.alreadySent}
+			bot := &botStub{err: tt.botErr}
+			s, err := NewService(cfg, store, candidates, vacancyStorageStub{}, bot)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.Publish(context.Background(), tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(bot.sent) != len(tt.wantSent) || (len(tt.wantSent) > 0 && bot.sent[0] != tt.wantSent[0]) {
+				t.Errorf("sent = %q, want %q", bot.sent, tt.wantSent)
+			}
+
+			if tt.wantSaved == "-" {
+				if len(store.notifications) != 0 {
+					t.Errorf("saved notifications = %+v, want none", store.notifications)
+				}
+				return
+			}
+			if len(store.notifications) != 1 {
+				t.Fatalf("saved %d notifications, want 1", len(store.notifications))
+			}
+			n := store.notifications[0]
+			if n.EventID != tt.event.ID || n.TelegramID != 100 || n.Error != tt.wantSaved {
+				t.Errorf("saved notification = %+v", n)
+			}
+		})
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	l := newLimiter(time.Millisecond, 50*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for _, chatID := range []int64{1, 2, 1} {
+		err := l.Wait(ctx, chatID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second message to the chat came after %v, want at least the chat interval", elapsed)
+	}
+
+	l.Pause(time.Hour)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() during pause error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "valid", cfg: Config{MessagesPerSecond: 25, ChatInterval: time.Second}},
+		{name: "no rate", cfg: Config{}, wantErr: true},
+		{name: "negative chat interval", cfg: Config{MessagesPerSecond: 25, ChatInterval: -time.Second}, wantErr: true},
+		{name: "unknown status", cfg: Config{MessagesPerSecond: 25, Templates: map[string]string{"hired": "hi"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
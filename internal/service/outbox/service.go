@@ -0,0 +1,196 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	SinkWebhooks  = "webhooks"
+	SinkLog       = "log"
+	SinkBroker    = "broker"
+	SinkTelegram  = "telegram"
+	SinkEmail     = "email"
+	SinkSummary   = "summary"
+	SinkIntegrity = "integrity"
+)
+
+// relayLease must exceed the time a sink takes to publish one message, otherwise another relay may pick it again.
+const relayLease = 5 * time.Minute
+
+type Storage interface {
+	Dispatch(ctx context.Context, limit int, sinks []string) (int, error)
+	ClaimDue(ctx context.Context, sink string, lease time.Duration) (service_models.OutboxDelivery, error)
+	MarkPublished(ctx context.Context, messageID int64, sink string) error
+	MarkFailed(ctx context.Context, messageID int64, sink string, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, messageID int64, sink string, lastError string) error
+}
+
+// Sink receives every event at least once; consumers should deduplicate by entity.Event.ID.
+type Sink interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type Config struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	BaseBackoff  time.Duration `yaml:"base_backoff"`
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+	MaxAttempts  int           `yaml:"max_attempts"`
+	Sinks        []string      `yaml:"sinks"`
+}
+
+func (c Config) Validate() error {
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+	if c.BaseBackoff <= 0 || c.MaxBackoff < c.BaseBackoff {
+		return fmt.Errorf("base_backoff must be positive and not greater than max_backoff")
+	}
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("max_attempts must be positive")
+	}
+
+	for _, sink := range c.Sinks {
+		if !slices.Contains([]string{SinkWebhooks, SinkLog, SinkBroker, SinkTelegram, SinkEmail, SinkSummary, SinkIntegrity}, sink) {
+			return fmt.Errorf("unknown sink %q", sink)
+		}
+	}
+
+	return nil
+}
+
+type Service struct {
+	cfg   Config
+	store Storage
+	sinks map[string]Sink
+}
+
+// NewService creates a relay publishing to the sinks listed in cfg.Sinks; sinks maps their names to implementations.
+func NewService(cfg Config, store Storage, sinks map[string]Sink) (*Service, error) {
+	enabled := make(map[string]Sink, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		sink, ok := sinks[name]
+		if !ok {
+			return nil, fmt.Errorf("sink %q is not available", name)
+		}
+		enabled[name] = sink
+	}
+
+	return &Service{
+		cfg:   cfg,
+		store: store,
+		sinks: enabled,
+	}, nil
+}
+
+// Start dispatches new messages and relays them to every sink in its own loop, each running every cfg.PollInterval
+// until the returned stop function is called.
+func (s *Service) Start(ctx context.Context) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		s.poll(ctx, "dispatch outbox", s.Dispatch)
+	})
+	for name := range s.sinks {
+		wg.Go(func() {
+			s.poll(ctx, "relay outbox to "+name, func(ctx context.Context) error {
+				return s.RelayDue(ctx, name)
+			})
+		})
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (s *Service) poll(ctx context.Context, what string, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := fn(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			loggy.Errorf("can't %s: %v", what, err)
+		}
+	}
+}
+
+// Dispatch creates deliveries of new messages to the enabled sinks.
+func (s *Service) Dispatch(ctx context.Context) error {
+	sinks := slices.Sorted(maps.Keys(s.sinks))
+	for {
+		count, err := s.store.Dispatch(ctx, s.cfg.BatchSize, sinks)
+		if err != nil {
+			return fmt.Errorf("can't dispatch messages: %w", err)
+		}
+		if count < s.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+// RelayDue publishes up to cfg.BatchSize due messages to the sink. Every message is leased right before
+// it's published, so a slow sink can't outlive the lease of messages waiting in its batch.
+func (s *Service) RelayDue(ctx context.Context, name string) error {
+	sink := s.sinks[name]
+
+	for range s.cfg.BatchSize {
+		delivery, err := s.store.ClaimDue(ctx, name, relayLease)
+		if errors.Is(err, inerrors.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("can't claim message: %w", err)
+		}
+
+		publishErr := sink.Publish(ctx, delivery.Event)
+		switch {
+		case publishErr == nil:
+			err = s.store.MarkPublished(ctx, delivery.MessageID, name)
+		case delivery.Attempts+1 >= s.cfg.MaxAttempts:
+			loggy.Errorf("giving up on message %d for %s after %d attempts: %v", delivery.MessageID, name, delivery.Attempts+1, publishErr)
+			err = s.store.MarkDead(ctx, delivery.MessageID, name, publishErr.Error())
+		default:
+			err = s.store.MarkFailed(ctx, delivery.MessageID, name, publishErr.Error(), time.Now().Add(s.backoff(delivery.Attempts+1)))
+		}
+		if err != nil {
+			return fmt.Errorf("can't save delivery of message %d: %w", delivery.MessageID, err)
+		}
+	}
+
+	return nil
+}
+
+// backoff doubles the delay after each failed attempt up to cfg.MaxBackoff; after cfg.MaxAttempts
+// the delivery is dead-lettered.
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.cfg.MaxBackoff)
+}
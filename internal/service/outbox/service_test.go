@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+func TestMain(m *testing.M) {
+	loggy.InitDefault()
+	os.Exit(m.Run())
+}
+
+type storageStub struct {
+	due       []service_models.OutboxDelivery
+	published []int64
+	failed    []int64
+	dead      []int64
+}
+
+func (s *storageStub) Dispatch(context.Context, int, []string) (int, error) {
+	return 0, nil
+}
+
+func (s *storageStub) ClaimDue(_ context.Context, sink string, _ time.Duration) (service_models.OutboxDelivery, error) {
+	for i, d := range s.due {
+		if d.Sink == sink {
+			s.due = append(s.due[:i], s.due[i+1:]...)
+			return d, nil
+		}
+	}
+
+	return service_models.OutboxDelivery{}, inerrors.ErrNotFound
+}
+
+func (s *storageStub) MarkPublished(_ context.Context, messageID int64, _ string) error {
+	s.published = append(s.published, messageID)
+	return nil
+}
+
+func (s *storageStub) MarkFailed(_ context.Context, messageID int64, _ string, _ string, _ time.Time) error {
+	s.failed = append(s.failed, messageID)
+	return nil
+}
+
+func (s *storageStub) MarkDead(_ context.Context, messageID int64, _ string, _ string) error {
+	s.dead = append(s.dead, messageID)
+	return nil
+}
+
+type sinkStub struct {
+	err error
+}
+
+func (s sinkStub) Publish(context.Context, entity.Event) error {
+	return s.err
+}
+
+func TestRelayDue(t *testing.T) {
+	cfg := Config{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+		MaxAttempts:  3,
+		Sinks:        []string{SinkLog, SinkTelegram},
+	}
+
+	tests := []struct {
+		name          string
+		sinkErr       error
+		attempts      int
+		wantPublished bool
+		wantFailed    bool
+		wantDead      bool
+	}{
+		{name: "published", wantPublished: true},
+		{name: "failed", sinkErr: errors.New("down"), attempts: 1, wantFailed: true},
+		{name: "attempts exhausted", sinkErr: errors.New("down"), attempts: 2, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storageStub{due: []service_models.OutboxDelivery{
+				{MessageID: 1, Sink: SinkTelegram, Attempts: tt.attempts},
+				{MessageID: 2, Sink: SinkLog},
+			}}
+			s, err := NewService(cfg, store, map[string]Sink{
+				SinkTelegram: sinkStub{err: tt.sinkErr},
+				SinkLog:      sinkStub{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.RelayDue(context.Background(), SinkTelegram)
+			if err != nil {
+				t.Fatalf("RelayDue() error = %v", err)
+			}
+
+			if got := len(store.published) == 1; got != tt.wantPublished {
+				t.Errorf("published = %v, want %v", store.published, tt.wantPublished)
+			}
+			if got := len(store.failed) == 1; got != tt.wantFailed {
+				t.Errorf("failed = %v, want %v", store.failed, tt.wantFailed)
+			}
+			if got := len(store.dead) == 1; got != tt.wantDead {
+				t.Errorf("dead = %v, want %v", store.dead, tt.wantDead)
+			}
+			if len(store.due) != 1 || store.due[0].Sink != SinkLog {
+				t.Errorf("delivery to another sink was relayed: %v", store.due)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	s := &Service{cfg: Config{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 5 * time.Second},
+		{attempts: 10, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := s.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/pkg/houston/loggy"
+)
+
+// LogSink writes events to the application log, which is handy for local runs.
+type LogSink struct{}
+
+func (LogSink) Publish(_ context.Context, event entity.Event) error {
+	loggy.Infof("event %s %s: %s", event.Type, event.ID, event.Data)
+	return nil
+}
+
+// Broker is the subset of a Kafka or NATS client the relay needs.
+type Broker interface {
+	Publish(ctx context.Context, topic string, key []byte, value []byte) error
+}
+
+// BrokerSink publishes every event type to its own topic, keyed by event id.
+type BrokerSink struct {
+	broker      Broker
+	topicPrefix string
+}
+
+func NewBrokerSink(broker Broker, topicPrefix string) *BrokerSink {
+	return &BrokerSink{
+		broker:      broker,
+		topicPrefix: topicPrefix,
+	}
+}
+
+func (s *BrokerSink) Publish(ctx context.Context, event entity.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("can't marshal event: %w", err)
+	}
+
+	err = s.broker.Publish(ctx, s.topicPrefix+event.Type, []byte(event.ID.String()), value)
+	if err != nil {
+		return fmt.Errorf("can't publish to broker: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package ranking
+
+import (
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+const defaultQuestionWeight = 1
+
+// score computes the components and their weighted mean, all on the 0-100 scale.
+func score(info entity.CandidateVacancyInfo, answers []entity.CandidateQuestionAnswer, weights service_models.RankingWeights) service_models.RankedCandidate {
+	res := service_models.RankedCandidate{
+		Candidate: info.Candidate,
+		Meta:      info.Meta,
+	}
+
+	resume := float64(info.ResumeScreening.Score)
+	res.ResumeScore = &resume
+
+	if info.Meta.InterviewScore != nil {
+		interview := float64(*info.Meta.InterviewScore)
+		res.InterviewScore = &interview
+	}
+
+	res.AnswersScore = answersScore(answers, weights.QuestionWeights)
+	res.SpeedScore = speedScore(answers)
+
+	total := weights.Resume + weights.Interview + weights.Answers + weights.Speed
+	res.Composite = (weights.Resume*valueOrZero(res.ResumeScore) +
+		weights.Interview*valueOrZero(res.InterviewScore) +
+		weights.Answers*valueOrZero(res.AnswersScore) +
+		weights.Speed*valueOrZero(res.SpeedScore)) / total
+
+	return res
+}
+
+func answersScore(answers []entity.CandidateQuestionAnswer, questionWeights map[int64]float64) *float64 {
+	var sum, weightSum float64
+	for _, qa := range answers {
+		w, ok := questionWeights[qa.Question.ID]
+		if !ok {
+			w = defaultQuestionWeight
+		}
+
+		sum += w * float64(qa.Answer.Score)
+		weightSum += w
+	}
+	if weightSum == 0 {
+		return nil
+	}
+
+	res := sum / weightSum
+	return &res
+}
+
+// speedScore is 100 for instant answers and 0 for answers taking the whole time limit or longer.
+func speedScore(answers []entity.CandidateQuestionAnswer) *float64 {
+	var sum float64
+	var n int
+	for _, qa := range answers {
+		if qa.Question.TimeLimit <= 0 {
+			continue
+		}
+
+		used := float64(qa.Answer.TimeTaken) / float64(qa.Question.TimeLimit)
+		sum += 100 * (1 - min(max(used, 0), 1))
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	res := sum / float64(n)
+	return &res
+}
+
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
@@ -0,0 +1,210 @@
+package ranking
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	minComparedCandidates = 2
+	maxComparedCandidates = 5
+)
+
+type Storage interface {
+	GetCandidateVacancyInfosByVacancyID(ctx context.Context, vacancyID uuid.UUID) ([]entity.CandidateVacancyInfo, error)
+	GetVacancyAnswers(ctx context.Context, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error)
+}
+
+type Weights struct {
+	Resume    float64 `yaml:"resume"`
+	Interview float64 `yaml:"interview"`
+	// Answers is the mean of per-question scores with per-request question weights.
+	Answers float64 `yaml:"answers"`
+	// Speed rewards answering well within question time limits.
+	Speed float64 `yaml:"speed"`
+}
+
+type Config struct {
+	// DefaultWeights are used for the components a request doesn't set.
+	DefaultWeights Weights `yaml:"default_weights"`
+}
+
+func (c Config) Validate() error {
+	return validateWeights(service_models.RankingWeights{
+		Resume:    c.DefaultWeights.Resume,
+		Interview: c.DefaultWeights.Interview,
+		Answers:   c.DefaultWeights.Answers,
+		Speed:     c.DefaultWeights.Speed,
+	})
+}
+
+type Service struct {
+	cfg   Config
+	store Storage
+}
+
+func NewService(cfg Config, store Storage) *Service {
+	return &Service{
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+// DefaultWeights returns the configured weights, so that requests can override some of them.
+func (s *Service) DefaultWeights() service_models.RankingWeights {
+	return service_models.RankingWeights{
+		Resume:    s.cfg.DefaultWeights.Resume,
+		Interview: s.cfg.DefaultWeights.Interview,
+		Answers:   s.cfg.DefaultWeights.Answers,
+		Speed:     s.cfg.DefaultWeights.Speed,
+	}
+}
+
+// RankCandidates orders applications to the vacancy by the weighted composite of their scores.
+// Components of stages the candidate hasn't reached count as zero.
+func (s *Service) RankCandidates(ctx context.Context, vacancyID uuid.UUID, filter service_models.RankingFilter) (service_models.CandidateRanking, error) {
+	err := validateWeights(filter.Weights)
+	if err != nil {
+		return service_models.CandidateRanking{}, err
+	}
+
+	ranked, _, err := s.rank(ctx, vacancyID, filter.Weights, func(info entity.CandidateVacancyInfo) bool {
+		return filter.IncludeArchived || !info.Meta.IsArchived
+	})
+	if err != nil {
+		return service_models.CandidateRanking{}, err
+	}
+
+	if filter.Limit > 0 && len(ranked) > filter.Limit {
+		ranked = ranked[:filter.Limit]
+	}
+
+	return service_models.CandidateRanking{
+		Weights:    filter.Weights,
+		Candidates: ranked,
+	}, nil
+}
+
+// CompareCandidates puts the candidates side by side with their answers to the same questions.
+func (s *Service) CompareCandidates(ctx context.Context, vacancyID uuid.UUID, candidateIDs []int64, weights service_models.RankingWeights) (service_models.CandidateComparison, error) {
+	slices.Sort(candidateIDs)
+	candidateIDs = slices.Compact(candidateIDs)
+	if len(candidateIDs) < minComparedCandidates || len(candidateIDs) > maxComparedCandidates {
+		return service_models.CandidateComparison{}, fmt.Errorf("%w: compare from %d to %d distinct candidates",
+			inerrors.ErrInvalidInput, minComparedCandidates, maxComparedCandidates)
+	}
+
+	err := validateWeights(weights)
+	if err != nil {
+		return service_models.CandidateComparison{}, err
+	}
+
+	ranked, answers, err := s.rank(ctx, vacancyID, weights, func(info entity.CandidateVacancyInfo) bool {
+		return slices.Contains(candidateIDs, info.Candidate.ID)
+	})
+	if err != nil {
+		return service_models.CandidateComparison{}, err
+	}
+	if len(ranked) != len(candidateIDs) {
+		return service_models.CandidateComparison{}, fmt.Errorf("%w: some candidates didn't apply to vacancy %s", inerrors.ErrNotFound, vacancyID)
+	}
+
+	// answers are ordered by question position
+	var questions []service_models.ComparedQuestion
+	questionIdx := make(map[int64]int)
+	for _, qa := range answers {
+		if !slices.Contains(candidateIDs, qa.Answer.CandidateID) {
+			continue
+		}
+
+		idx, ok := questionIdx[qa.Question.ID]
+		if !ok {
+			idx = len(questions)
+			questionIdx[qa.Question.ID] = idx
+			questions = append(questions, service_models.ComparedQuestion{
+				Question: qa.Question,
+				Answers:  make(map[int64]entity.Answer),
+			})
+		}
+		questions[idx].Answers[qa.Answer.CandidateID] = qa.Answer
+	}
+
+	return service_models.CandidateComparison{
+		Weights:    weights,
+		Candidates: ranked,
+		Questions:  questions,
+	}, nil
+}
+
+func (s *Service) rank(
+	ctx context.Context,
+	vacancyID uuid.UUID,
+	weights service_models.RankingWeights,
+	include func(info entity.CandidateVacancyInfo) bool,
+) ([]service_models.RankedCandidate, []entity.CandidateQuestionAnswer, error) {
+	infos, err := s.store.GetCandidateVacancyInfosByVacancyID(ctx, vacancyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't get candidates: %w", err)
+	}
+
+	answers, err := s.store.GetVacancyAnswers(ctx, vacancyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't get answers: %w", err)
+	}
+
+	answersByCandidate := make(map[int64][]entity.CandidateQuestionAnswer)
+	for _, qa := range answers {
+		answersByCandidate[qa.Answer.CandidateID] = append(answersByCandidate[qa.Answer.CandidateID], qa)
+	}
+
+	var ranked []service_models.RankedCandidate
+	for _, info := range infos {
+		if !include(info) {
+			continue
+		}
+
+		ranked = append(ranked, score(info, answersByCandidate[info.Candidate.ID], weights))
+	}
+
+	slices.SortStableFunc(ranked, func(a, b service_models.RankedCandidate) int {
+		return cmp.Compare(b.Composite, a.Composite)
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+
+	return ranked, answers, nil
+}
+
+func validateWeights(weights service_models.RankingWeights) error {
+	components := []float64{weights.Resume, weights.Interview, weights.Answers, weights.Speed}
+	for _, w := range components {
+		if !validWeight(w) {
+			return fmt.Errorf("%w: weights must be finite and not negative", inerrors.ErrInvalidInput)
+		}
+	}
+	if weights.Resume+weights.Interview+weights.Answers+weights.Speed == 0 {
+		return fmt.Errorf("%w: at least one weight must be positive", inerrors.ErrInvalidInput)
+	}
+
+	for questionID, w := range weights.QuestionWeights {
+		if !validWeight(w) {
+			return fmt.Errorf("%w: weight of question %d must be finite and not negative", inerrors.ErrInvalidInput, questionID)
+		}
+	}
+
+	return nil
+}
+
+func validWeight(w float64) bool {
+	return !math.IsNaN(w) && !math.IsInf(w, 0) && w >= 0
+}
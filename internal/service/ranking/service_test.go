@@ -0,0 +1,303 @@
+package ranking
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type storageStub struct {
+	infos   []entity.CandidateVacancyInfo
+	answers []entity.CandidateQuestionAnswer
+}
+
+func (s storageStub) GetCandidateVacancyInfosByVacancyID(context.Context, uuid.UUID) ([]entity.CandidateVacancyInfo, error) {
+	return s.infos, nil
+}
+
+func (s storageStub) GetVacancyAnswers(context.Context, uuid.UUID) ([]entity.CandidateQuestionAnswer, error) {
+	return s.answers, nil
+}
+
+func answer(candidateID int64, questionID int64, timeLimit int, score int, timeTaken int64) entity.CandidateQuestionAnswer {
+	return entity.CandidateQuestionAnswer{
+		Question: entity.Question{ID: questionID, TimeLimit: timeLimit},
+		Answer:   entity.Answer{CandidateID: candidateID, QuestionID: questionID, Score: score, TimeTaken: timeTaken},
+	}
+}
+
+func info(candidateID int64, resumeScore int, interviewScore *int, archived bool) entity.CandidateVacancyInfo {
+	return entity.CandidateVacancyInfo{
+		Candidate:       entity.Candidate{ID: candidateID},
+		Meta:            entity.Meta{CandidateID: candidateID, InterviewScore: interviewScore, IsArchived: archived},
+		ResumeScreening: entity.ResumeScreening{Score: resumeScore},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func equalFloat(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(*a-*b) < 1e-9
+}
+
+func TestAnswersScore(t *testing.T) {
+	answers := []entity.CandidateQuestionAnswer{
+		answer(1, 1, 60, 100, 0),
+		answer(1, 2, 60, 40, 0),
+	}
+
+	tests := []struct {
+		name    string
+		answers []entity.CandidateQuestionAnswer
+		weights map[int64]float64
+		want    *float64
+	}{
+		{name: "no answers", want: nil},
+		{name: "default weights", answers: answers, want: ptr(70.0)},
+		{name: "question weight", answers: answers, weights: map[int64]float64{2: 3}, want: ptr(55.0)},
+		{name: "zero weights", answers: answers, weights: map[int64]float64{1: 0, 2: 0}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := answersScore(tt.answers, tt.weights); !equalFloat(got, tt.want) {
+				t.Errorf("answersScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeedScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []entity.CandidateQuestionAnswer
+		want    *float64
+	}{
+		{name: "no answers", want: nil},
+		{name: "no time limits", answers: []entity.CandidateQuestionAnswer{answer(1, 1, 0, 100, 10)}, want: nil},
+		{name: "instant", answers: []entity.CandidateQuestionAnswer{answer(1, 1, 60, 100, 0)}, want: ptr(100.0)},
+		{name: "half of the limit", answers: []entity.CandidateQuestionAnswer{answer(1, 1, 60, 100, 30)}, want: ptr(50.0)},
+		{name: "over the limit", answers: []entity.CandidateQuestionAnswer{answer(1, 1, 60, 100, 120)}, want: ptr(0.0)},
+		{
+			name: "questions without limits are skipped",
+			answers: []entity.CandidateQuestionAnswer{
+				answer(1, 1, 60, 100, 15),
+				answer(1, 2, 0, 100, 1000),
+				answer(1, 3, 60, 100, 45),
+			},
+			want: ptr(50.0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := speedScore(tt.answers); !equalFloat(got, tt.want) {
+				t.Errorf("speedScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	weights := service_models.RankingWeights{Resume: 1, Interview: 1, Answers: 2}
+
+	got := score(info(1, 80, ptr(60), false), []entity.CandidateQuestionAnswer{answer(1, 1, 0, 90, 0)}, weights)
+	if want := (80 + 60 + 2*90) / 4.0; math.Abs(got.Composite-want) > 1e-9 {
+		t.Errorf("Composite = %v, want %v", got.Composite, want)
+	}
+	if got.SpeedScore != nil {
+		t.Errorf("SpeedScore = %v, want nil", *got.SpeedScore)
+	}
+
+	// stages the candidate hasn't reached count as zero
+	got = score(info(1, 80, nil, false), nil, weights)
+	if want := 80 / 4.0; math.Abs(got.Composite-want) > 1e-9 {
+		t.Errorf("Composite without interview = %v, want %v", got.Composite, want)
+	}
+	if got.InterviewScore != nil || got.AnswersScore != nil {
+		t.Errorf("components of unreached stages = %v, %v, want nil", got.InterviewScore, got.AnswersScore)
+	}
+}
+
+func TestServiceRankCandidates(t *testing.T) {
+	store := storageStub{
+		infos: []entity.CandidateVacancyInfo{
+			info(1, 50, nil, false),
+			info(2, 90, nil, false),
+			info(3, 100, nil, true),
+			info(4, 70, nil, false),
+		},
+	}
+	s := NewService(Config{}, store)
+
+	tests := []struct {
+		name    string
+		filter  service_models.RankingFilter
+		wantIDs []int64
+		wantErr error
+	}{
+		{
+			name:    "ordered by composite",
+			filter:  service_models.RankingFilter{Weights: service_models.RankingWeights{Resume: 1}},
+			wantIDs: []int64{2, 4, 1},
+		},
+		{
+			name:    "archived included",
+			filter:  service_models.RankingFilter{Weights: service_models.RankingWeights{Resume: 1}, IncludeArchived: true},
+			wantIDs: []int64{3, 2, 4, 1},
+		},
+		{
+			name:    "limit",
+			filter:  service_models.RankingFilter{Weights: service_models.RankingWeights{Resume: 1}, Limit: 2},
+			wantIDs: []int64{2, 4},
+		},
+		{
+			name:    "zero weights",
+			filter:  service_models.RankingFilter{},
+			wantErr: inerrors.ErrInvalidInput,
+		},
+		{
+			name:    "negative weight",
+			filter:  service_models.RankingFilter{Weights: service_models.RankingWeights{Resume: 1, Speed: -1}},
+			wantErr: inerrors.ErrInvalidInput,
+		},
+		{
+			name:    "negative question weight",
+			filter:  service_models.RankingFilter{Weights: service_models.RankingWeights{Resume: 1, QuestionWeights: map[int64]float64{1: -1}}},
+			wantErr: inerrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranking, err := s.RankCandidates(context.Background(), uuid.New(), tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RankCandidates() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(ranking.Candidates) != len(tt.wantIDs) {
+				t.Fatalf("got %d candidates, want %d", len(ranking.Candidates), len(tt.wantIDs))
+			}
+			for i, c := range ranking.Candidates {
+				if c.Candidate.ID != tt.wantIDs[i] || c.Rank != i+1 {
+					t.Errorf("candidate %d = id %d rank %d, want id %d rank %d", i, c.Candidate.ID, c.Rank, tt.wantIDs[i], i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceCompareCandidates(t *testing.T) {
+	store := storageStub{
+		infos: []entity.CandidateVacancyInfo{
+			info(1, 50, nil, false),
+			info(2, 90, nil, false),
+			info(3, 70, nil, false),
+		},
+		answers: []entity.CandidateQuestionAnswer{
+			answer(1, 10, 0, 30, 0),
+			answer(2, 10, 0, 80, 0),
+			answer(3, 10, 0, 100, 0),
+			answer(2, 11, 0, 60, 0),
+		},
+	}
+	s := NewService(Config{}, store)
+	weights := service_models.RankingWeights{Resume: 1}
+
+	comparison, err := s.CompareCandidates(context.Background(), uuid.New(), []int64{2, 1, 2}, weights)
+	if err != nil {
+		t.Fatalf("CompareCandidates() error = %v", err)
+	}
+	if len(comparison.Candidates) != 2 || comparison.Candidates[0].Candidate.ID != 2 {
+		t.Errorf("candidates = %+v, want 2 then 1", comparison.Candidates)
+	}
+	if len(comparison.Questions) != 2 {
+		t.Fatalf("got %d questions, want 2", len(comparison.Questions))
+	}
+	if q := comparison.Questions[0]; q.Question.ID != 10 || len(q.Answers) != 2 || q.Answers[1].Score != 30 {
+		t.Errorf("first question = %+v, want answers of candidates 1 and 2 only", q)
+	}
+	if q := comparison.Questions[1]; q.Question.ID != 11 || len(q.Answers) != 1 {
+		t.Errorf("second question = %+v, want the answer of candidate 2 only", q)
+	}
+
+	_, err = s.CompareCandidates(context.Background(), uuid.New(), []int64{1, 1}, weights)
+	if !errors.Is(err, inerrors.ErrInvalidInput) {
+		t.Errorf("CompareCandidates() of a single candidate error = %v, want invalid input", err)
+	}
+
+	_, err = s.CompareCandidates(context.Background(), uuid.New(), []int64{1, 4}, weights)
+	if !errors.Is(err, inerrors.ErrNotFound) {
+		t.Errorf("CompareCandidates() of a candidate without application error = %v, want not found", err)
+	}
+}
+
+func TestValidateWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights service_models.RankingWeights
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			weights: service_models.RankingWeights{Resume: 1, Interview: 0.5, QuestionWeights: map[int64]float64{1: 2}},
+		},
+		{
+			name:    "negative",
+			weights: service_models.RankingWeights{Resume: 1, Speed: -1},
+			wantErr: true,
+		},
+		{
+			name:    "all zero",
+			weights: service_models.RankingWeights{},
+			wantErr: true,
+		},
+		{
+			name:    "NaN",
+			weights: service_models.RankingWeights{Resume: math.NaN()},
+			wantErr: true,
+		},
+		{
+			name:    "infinite",
+			weights: service_models.RankingWeights{Resume: 1, Answers: math.Inf(1)},
+			wantErr: true,
+		},
+		{
+			name:    "NaN question weight",
+			weights: service_models.RankingWeights{Resume: 1, QuestionWeights: map[int64]float64{1: math.NaN()}},
+			wantErr: true,
+		},
+		{
+			name:    "infinite question weight",
+			weights: service_models.RankingWeights{Resume: 1, QuestionWeights: map[int64]float64{1: math.Inf(1)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWeights(tt.weights)
+			if tt.wantErr != errors.Is(err, inerrors.ErrInvalidInput) {
+				t.Errorf("validateWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateWeights() unexpected error = %v", err)
+			}
+		})
+	}
+}
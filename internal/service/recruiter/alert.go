@@ -0,0 +1,115 @@
+package recruiter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type alertData struct {
+	FullName     string
+	City         string
+	VacancyTitle string
+	Stage        string
+	Score        int
+	Link         string
+}
+
+// Publish sends instant alerts about high scores in screening.completed and interview.completed events,
+// so the service can be an outbox sink.
+func (s *Service) Publish(ctx context.Context, event entity.Event) error {
+	var (
+		candidateID int64
+		vacancyID   uuid.UUID
+		score       int
+		stage       string
+	)
+
+	switch event.Type {
+	case entity.EventTypeScreeningCompleted:
+		var data entity.ScreeningCompletedEvent
+		err := json.Unmarshal(event.Data, &data)
+		if err != nil {
+			return fmt.Errorf("can't unmarshal event data: %w", err)
+		}
+		candidateID, vacancyID, score, stage = data.CandidateID, data.VacancyID, data.Score, "Оценка резюме"
+	case entity.EventTypeInterviewCompleted:
+		var data entity.InterviewCompletedEvent
+		err := json.Unmarshal(event.Data, &data)
+		if err != nil {
+			return fmt.Errorf("can't unmarshal event data: %w", err)
+		}
+		candidateID, vacancyID, score, stage = data.CandidateID, data.VacancyID, data.Score, "Оценка интервью"
+	default:
+		return nil
+	}
+
+	subscriptions, err := s.store.GetSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get subscriptions: %w", err)
+	}
+
+	// recipients alerted before a failure are skipped when the event is delivered again
+	alerted, err := s.store.GetAlertedEmails(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("can't get alerted emails: %w", err)
+	}
+
+	var to []string
+	for _, subscription := range subscriptions {
+		if subscription.InstantAlerts && score >= subscription.AlertMinScore && subscription.Watches(vacancyID) &&
+			!slices.Contains(alerted, subscription.Email) {
+			to = append(to, subscription.Email)
+		}
+	}
+	if len(to) == 0 {
+		return nil
+	}
+
+	info, err := s.candidateStore.GetCandidateVacancyInfo(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get candidate info: %w", err)
+	}
+
+	var body bytes.Buffer
+	err = templates.ExecuteTemplate(&body, "alert.html", alertData{
+		FullName:     info.Candidate.FullName,
+		City:         info.Candidate.City,
+		VacancyTitle: info.Vacancy.Title,
+		Stage:        stage,
+		Score:        score,
+		Link:         s.candidateLink(candidateID, vacancyID),
+	})
+	if err != nil {
+		return fmt.Errorf("can't execute template: %w", err)
+	}
+
+	// one email per recipient, a failed one doesn't keep the rest from being sent
+	subject := fmt.Sprintf("Сильный кандидат: %s (%d)", info.Vacancy.Title, score)
+	var errs []error
+	for _, email := range to {
+		err = s.mailer.SendHTML(email, subject, body.String())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't send alert to %s: %w", email, err))
+			continue
+		}
+
+		err = s.store.MarkAlertSent(ctx, event.ID, email)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't mark alert to %s sent: %w", email, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,144 @@
+package recruiter
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+)
+
+type storageStub struct {
+	subscriptions []entity.RecruiterSubscription
+	alerted       []string
+}
+
+func (s *storageStub) GetSubscriptions(context.Context) ([]entity.RecruiterSubscription, error) {
+	return s.subscriptions, nil
+}
+
+func (s *storageStub) GetSubscription(context.Context, string) (entity.RecruiterSubscription, error) {
+	return entity.RecruiterSubscription{}, nil
+}
+
+func (s *storageStub) UpsertSubscription(context.Context, entity.RecruiterSubscription) error {
+	return nil
+}
+
+func (s *storageStub) MarkDigestSent(context.Context, string, time.Time) error {
+	return nil
+}
+
+func (s *storageStub) GetAlertedEmails(context.Context, uuid.UUID) ([]string, error) {
+	return s.alerted, nil
+}
+
+func (s *storageStub) MarkAlertSent(_ context.Context, _ uuid.UUID, email string) error {
+	s.alerted = append(s.alerted, email)
+	return nil
+}
+
+type candidateStorageStub struct{}
+
+func (candidateStorageStub) GetCandidateVacancyInfo(_ context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error) {
+	return entity.CandidateVacancyInfo{
+		Candidate: entity.Candidate{ID: candidateID, FullName: "Иван"},
+		Vacancy:   entity.Vacancy{ID: vacancyID, Title: "Go"},
+	}, nil
+}
+
+func (candidateStorageStub) GetScreenedCandidateVacancyInfos(context.Context, time.Time, time.Time) ([]entity.CandidateVacancyInfo, error) {
+	return nil, nil
+}
+
+type mailerStub struct {
+	failFor string
+	sent    []string
+}
+
+func (m *mailerStub) SendHTML(to string, _ string, _ string) error {
+	m.sent = append(m.sent, to)
+	if to == m.failFor {
+		return errors.New("mailbox unavailable")
+	}
+	return nil
+}
+
+func TestServicePublishAlerts(t *testing.T) {
+	vacancyID := uuid.New()
+	subscriptions := []entity.RecruiterSubscription{
+		{Email: "a@example.com", InstantAlerts: true, AlertMinScore: 90},
+		{Email: "b@example.com", InstantAlerts: true, AlertMinScore: 80, VacancyIDs: []uuid.UUID{vacancyID}},
+		{Email: "c@example.com", InstantAlerts: true, AlertMinScore: 80},
+		{Email: "low@example.com", InstantAlerts: true, AlertMinScore: 99},
+		{Email: "other@example.com", InstantAlerts: true, AlertMinScore: 0, VacancyIDs: []uuid.UUID{uuid.New()}},
+		{Email: "off@example.com", AlertMinScore: 0},
+	}
+	event, err := entity.NewEvent(entity.EventTypeScreeningCompleted, entity.ScreeningCompletedEvent{
+		CandidateID: 1,
+		VacancyID:   vacancyID,
+		Score:       95,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		alerted     []string
+		failFor     string
+		wantSent    []string
+		wantAlerted []string
+		wantErr     bool
+	}{
+		{
+			name:        "one email per recipient",
+			wantSent:    []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantAlerted: []string{"a@example.com", "b@example.com", "c@example.com"},
+		},
+		{
+			name:        "failed recipient doesn't stop the rest",
+			failFor:     "b@example.com",
+			wantSent:    []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantAlerted: []string{"a@example.com", "c@example.com"},
+			wantErr:     true,
+		},
+		{
+			name:        "retry skips alerted recipients",
+			alerted:     []string{"a@example.com", "c@example.com"},
+			wantSent:    []string{"b@example.com"},
+			wantAlerted: []string{"a@example.com", "c@example.com", "b@example.com"},
+		},
+		{
+			name:        "everyone alerted",
+			alerted:     []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantAlerted: []string{"a@example.com", "b@example.com", "c@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailer := &mailerStub{failFor: tt.failFor}
+			store := &storageStub{subscriptions: subscriptions, alerted: slices.Clone(tt.alerted)}
+			s, err := NewService(Config{DigestTime: "09:00", Timezone: "UTC"}, store, candidateStorageStub{}, mailer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.Publish(context.Background(), event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(mailer.sent, tt.wantSent) {
+				t.Errorf("sent to %q, want %q", mailer.sent, tt.wantSent)
+			}
+			if !slices.Equal(store.alerted, tt.wantAlerted) {
+				t.Errorf("alerted %q, want %q", store.alerted, tt.wantAlerted)
+			}
+		})
+	}
+}
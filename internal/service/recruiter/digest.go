@@ -0,0 +1,171 @@
+package recruiter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/pkg/houston/loggy"
+)
+
+// digestPeriod is used for recruiters who have never got a digest.
+const digestPeriod = 24 * time.Hour
+
+type digestData struct {
+	From      time.Time
+	To        time.Time
+	Vacancies []digestVacancy
+}
+
+type digestVacancy struct {
+	Title      string
+	Candidates []digestCandidate
+}
+
+type digestCandidate struct {
+	FullName string
+	City     string
+	Score    int
+	Link     string
+}
+
+// Start sends digests daily at cfg.DigestTime until the returned stop function is called.
+func (s *Service) Start(ctx context.Context) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			timer := time.NewTimer(time.Until(s.nextDigestAt(time.Now())))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			err := s.SendDigests(ctx, time.Now())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				loggy.Errorf("can't send digests: %v", err)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (s *Service) nextDigestAt(now time.Time) time.Time {
+	// validated in Config.Validate
+	clock, _ := time.Parse("15:04", s.cfg.DigestTime)
+
+	now = now.In(s.location)
+	next := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, s.location)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// SendDigests sends every subscribed recruiter the candidates screened since their previous digest.
+func (s *Service) SendDigests(ctx context.Context, now time.Time) error {
+	subscriptions, err := s.store.GetSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get subscriptions: %w", err)
+	}
+
+	from := now
+	for _, subscription := range subscriptions {
+		if subscriptionFrom := s.digestFrom(subscription, now); subscription.DailyDigest && subscriptionFrom.Before(from) {
+			from = subscriptionFrom
+		}
+	}
+
+	infos, err := s.candidateStore.GetScreenedCandidateVacancyInfos(ctx, from, now)
+	if err != nil {
+		return fmt.Errorf("can't get screened candidates: %w", err)
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.DailyDigest {
+			continue
+		}
+
+		err = s.sendDigest(ctx, subscription, infos, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", subscription.Email, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Service) digestFrom(subscription entity.RecruiterSubscription, now time.Time) time.Time {
+	if subscription.LastDigestAt != nil {
+		return *subscription.LastDigestAt
+	}
+
+	return now.Add(-digestPeriod)
+}
+
+func (s *Service) sendDigest(ctx context.Context, subscription entity.RecruiterSubscription, infos []entity.CandidateVacancyInfo, now time.Time) error {
+	data := digestData{
+		From: s.digestFrom(subscription, now).In(s.location),
+		To:   now.In(s.location),
+	}
+
+	// infos are ordered by vacancy title
+	vacancyIdx := make(map[uuid.UUID]int)
+	for _, info := range infos {
+		if !subscription.Watches(info.Vacancy.ID) || info.ResumeScreening.UpdatedAt.Before(data.From) {
+			continue
+		}
+
+		idx, ok := vacancyIdx[info.Vacancy.ID]
+		if !ok {
+			idx = len(data.Vacancies)
+			vacancyIdx[info.Vacancy.ID] = idx
+			data.Vacancies = append(data.Vacancies, digestVacancy{Title: info.Vacancy.Title})
+		}
+
+		data.Vacancies[idx].Candidates = append(data.Vacancies[idx].Candidates, digestCandidate{
+			FullName: info.Candidate.FullName,
+			City:     info.Candidate.City,
+			Score:    info.ResumeScreening.Score,
+			Link:     s.candidateLink(info.Candidate.ID, info.Vacancy.ID),
+		})
+	}
+
+	if len(data.Vacancies) > 0 {
+		var body bytes.Buffer
+		err := templates.ExecuteTemplate(&body, "digest.html", data)
+		if err != nil {
+			return fmt.Errorf("can't execute template: %w", err)
+		}
+
+		subject := "Кандидаты за " + data.To.Format("02.01.2006")
+		err = s.mailer.SendHTML(subscription.Email, subject, body.String())
+		if err != nil {
+			return fmt.Errorf("can't send digest: %w", err)
+		}
+	}
+
+	err := s.store.MarkDigestSent(ctx, subscription.Email, now)
+	if err != nil {
+		return fmt.Errorf("can't mark digest sent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package recruiter
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+const defaultAlertMinScore = 90
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.html"))
+
+type Storage interface {
+	GetSubscriptions(ctx context.Context) ([]entity.RecruiterSubscription, error)
+	GetSubscription(ctx context.Context, email string) (entity.RecruiterSubscription, error)
+	UpsertSubscription(ctx context.Context, subscription entity.RecruiterSubscription) error
+	MarkDigestSent(ctx context.Context, email string, sentAt time.Time) error
+	GetAlertedEmails(ctx context.Context, eventID uuid.UUID) ([]string, error)
+	MarkAlertSent(ctx context.Context, eventID uuid.UUID, email string) error
+}
+
+type CandidateStorage interface {
+	GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error)
+	GetScreenedCandidateVacancyInfos(ctx context.Context, from time.Time, to time.Time) ([]entity.CandidateVacancyInfo, error)
+}
+
+type Mailer interface {
+	SendHTML(to string, subject string, body string) error
+}
+
+type Config struct {
+	// DigestTime is the local time of day the daily digest is sent at, e.g. "09:00".
+	DigestTime string `yaml:"digest_time"`
+	Timezone   string `yaml:"timezone"`
+	// CandidateLinkFormat builds links to candidate cards from candidate and vacancy ids,
+	// e.g. "https://example.com/candidates/%d/%s"; links are omitted when it's empty.
+	CandidateLinkFormat string `yaml:"candidate_link_format"`
+}
+
+func (c Config) Validate() error {
+	_, err := time.Parse("15:04", c.DigestTime)
+	if err != nil {
+		return fmt.Errorf("invalid digest_time: %w", err)
+	}
+
+	_, err = time.LoadLocation(c.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	return nil
+}
+
+type Service struct {
+	cfg            Config
+	store          Storage
+	candidateStore CandidateStorage
+	mailer         Mailer
+	location       *time.Location
+}
+
+func NewService(cfg Config, store Storage, candidateStore CandidateStorage, mailer Mailer) (*Service, error) {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("can't load timezone: %w", err)
+	}
+
+	return &Service{
+		cfg:            cfg,
+		store:          store,
+		candidateStore: candidateStore,
+		mailer:         mailer,
+		location:       location,
+	}, nil
+}
+
+// GetSubscription returns the recruiter's settings or the defaults if they were never saved.
+func (s *Service) GetSubscription(ctx context.Context, email string) (entity.RecruiterSubscription, error) {
+	subscription, err := s.store.GetSubscription(ctx, email)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return entity.RecruiterSubscription{
+			Email:         email,
+			AlertMinScore: defaultAlertMinScore,
+		}, nil
+	}
+	if err != nil {
+		return entity.RecruiterSubscription{}, fmt.Errorf("can't get subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, email string, req dto_models.UpdateRecruiterSubscriptionRequest) (entity.RecruiterSubscription, error) {
+	if req.AlertMinScore < 0 || req.AlertMinScore > 100 {
+		return entity.RecruiterSubscription{}, fmt.Errorf("%w: alert_min_score must be within [0, 100]", inerrors.ErrInvalidInput)
+	}
+
+	subscription := entity.RecruiterSubscription{
+		Email:         email,
+		VacancyIDs:    req.VacancyIDs,
+		DailyDigest:   req.DailyDigest,
+		InstantAlerts: req.InstantAlerts,
+		AlertMinScore: req.AlertMinScore,
+	}
+	if subscription.VacancyIDs == nil {
+		subscription.VacancyIDs = []uuid.UUID{}
+	}
+
+	err := s.store.UpsertSubscription(ctx, subscription)
+	if err != nil {
+		return entity.RecruiterSubscription{}, fmt.Errorf("can't save subscription: %w", err)
+	}
+
+	return s.GetSubscription(ctx, email)
+}
+
+func (s *Service) candidateLink(candidateID int64, vacancyID uuid.UUID) string {
+	if s.cfg.CandidateLinkFormat == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(s.cfg.CandidateLinkFormat, candidateID, vacancyID)
+}
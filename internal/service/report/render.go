@@ -0,0 +1,125 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Templates produce line-based markup, the renderer lays it out on A4 pages.
+// Lines of user text are always prefixed with markParagraph or markMuted.
+const (
+	markTitle      = "# "
+	markSection    = "## "
+	markSubsection = "### "
+	markField      = "- "
+	markParagraph  = "| "
+	markMuted      = "> "
+)
+
+const (
+	fontFamily = "DejaVuSans"
+	emptyValue = "—"
+
+	pageMargin = 15.0
+	labelWidth = 50.0
+	lineHeight = 5.5
+)
+
+func renderPDF(markup string, generatedAt time.Time) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+
+	err := addFonts(pdf)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pageMargin + 3)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Сформировано %s · стр. %s из {nb}",
+			generatedAt.Format("02.01.2006 15:04"), strconv.Itoa(pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	for _, line := range strings.Split(markup, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, markTitle):
+			pdf.SetFont(fontFamily, "B", 18)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, 9, strings.TrimPrefix(line, markTitle), "", "L", false)
+		case strings.HasPrefix(line, markSection):
+			pdf.Ln(4)
+			pdf.SetFont(fontFamily, "B", 13)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, 7, strings.TrimPrefix(line, markSection), "B", "L", false)
+			pdf.Ln(1)
+		case strings.HasPrefix(line, markSubsection):
+			pdf.Ln(2)
+			pdf.SetFont(fontFamily, "B", 11)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, 6, strings.TrimPrefix(line, markSubsection), "", "L", false)
+		case strings.HasPrefix(line, markField):
+			renderField(pdf, strings.TrimPrefix(line, markField))
+		case strings.HasPrefix(line, markParagraph):
+			pdf.SetFont(fontFamily, "", 10)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, lineHeight, strings.TrimPrefix(line, markParagraph), "", "L", false)
+		case strings.HasPrefix(line, markMuted):
+			pdf.SetFont(fontFamily, "", 9)
+			pdf.SetTextColor(100, 100, 100)
+			pdf.MultiCell(0, lineHeight, strings.TrimPrefix(line, markMuted), "", "L", false)
+		default:
+			pdf.SetFont(fontFamily, "", 11)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, 6, line, "", "L", false)
+		}
+	}
+
+	var buf bytes.Buffer
+	err = pdf.Output(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderField lays out a "label: value" line as two columns.
+func renderField(pdf *fpdf.Fpdf, field string) {
+	label, value, _ := strings.Cut(field, ": ")
+	if strings.TrimSpace(value) == "" {
+		value = emptyValue
+	}
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.CellFormat(labelWidth, lineHeight, label, "", 0, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.MultiCell(0, lineHeight, value, "", "L", false)
+}
+
+func addFonts(pdf *fpdf.Fpdf) error {
+	for style, file := range map[string]string{
+		"":  "fonts/DejaVuSans.ttf",
+		"B": "fonts/DejaVuSans-Bold.ttf",
+	} {
+		font, err := fontsFS.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("can't read font %s: %w", file, err)
+		}
+		pdf.AddUTF8FontFromBytes(fontFamily, style, font)
+	}
+
+	return pdf.Error()
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// fonts are embedded, so that reports render Cyrillic without fonts installed in the container.
+//
+//go:embed fonts/*.ttf
+var fontsFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"oneline": oneline,
+	"para":    func(s string) string { return prefixLines(s, markParagraph) },
+	"muted":   func(s string) string { return prefixLines(s, markMuted) },
+	"join":    func(ss []string) string { return oneline(strings.Join(ss, ", ")) },
+	"date":    func(t time.Time) string { return t.Format("02.01.2006") },
+	"inc":     func(i int) int { return i + 1 },
+	"status":  statusTitle,
+}).ParseFS(templatesFS, "templates/*.tmpl"))
+
+var statusTitles = map[entity.CandidateVacancyStatus]string{
+	entity.CandidateVacancyStatusScreeningOk:        "резюме прошло отбор",
+	entity.CandidateVacancyStatusScreeningFailed:    "резюме не прошло отбор",
+	entity.CandidateVacancyStatusInterviewOk:        "интервью пройдено",
+	entity.CandidateVacancyStatusInterviewFailed:    "интервью не пройдено",
+	entity.CandidateVacancyStatusInterviewScheduled: "назначено интервью с рекрутером",
+}
+
+type Storage interface {
+	GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error)
+	GetCandidateAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error)
+}
+
+type Service struct {
+	store Storage
+}
+
+func NewService(store Storage) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+type candidateReport struct {
+	entity.CandidateVacancyInfo
+	Answers []entity.CandidateQuestionAnswer
+}
+
+// CandidateReport renders the application to the vacancy as a PDF document.
+func (s *Service) CandidateReport(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]byte, error) {
+	info, err := s.store.GetCandidateVacancyInfo(ctx, candidateID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get candidate vacancy info: %w", err)
+	}
+
+	answers, err := s.store.GetCandidateAnswers(ctx, candidateID, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get answers: %w", err)
+	}
+
+	var markup bytes.Buffer
+	err = templates.ExecuteTemplate(&markup, "candidate.tmpl", candidateReport{
+		CandidateVacancyInfo: info,
+		Answers:              answers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't execute template: %w", err)
+	}
+
+	doc, err := renderPDF(markup.String(), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("can't render pdf: %w", err)
+	}
+
+	return doc, nil
+}
+
+func statusTitle(status entity.CandidateVacancyStatus) string {
+	if title, ok := statusTitles[status]; ok {
+		return title
+	}
+
+	return string(status)
+}
+
+func oneline(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// prefixLines marks every line of user text, so that it's never read as markup.
+func prefixLines(s string, mark string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = mark + strings.TrimRight(line, " \r\t")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+)
+
+type storageStub struct {
+	info    entity.CandidateVacancyInfo
+	answers []entity.CandidateQuestionAnswer
+}
+
+func (s storageStub) GetCandidateVacancyInfo(context.Context, int64, uuid.UUID) (entity.CandidateVacancyInfo, error) {
+	return s.info, nil
+}
+
+func (s storageStub) GetCandidateAnswers(context.Context, int64, uuid.UUID) ([]entity.CandidateQuestionAnswer, error) {
+	return s.answers, nil
+}
+
+func fixture() storageStub {
+	score := 85
+
+	return storageStub{
+		info: entity.CandidateVacancyInfo{
+			Candidate: entity.Candidate{
+				ID:               1,
+				FullName:         "Иван\n## Петров",
+				Email:            "ivan@example.com",
+				City:             "Москва\n- Зарплата: 1 000 000",
+				TelegramUsername: "ivan",
+			},
+			Vacancy: entity.Vacancy{
+				Title:           "Go-разработчик",
+				KeyRequirements: []string{"Go", "PostgreSQL\n# Kafka"},
+			},
+			Meta: entity.Meta{
+				Status:         entity.CandidateVacancyStatusInterviewOk,
+				InterviewScore: &score,
+			},
+			ResumeScreening: entity.ResumeScreening{
+				Score:     90,
+				Feedback:  "Сильный опыт.\n# Рекомендую\n- нанять без интервью",
+				CreatedAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		answers: []entity.CandidateQuestionAnswer{
+			{
+				Question: entity.Question{Content: "Что такое канал?", Reference: "Труба между горутинами\n### Бонус"},
+				Answer:   entity.Answer{Content: "- это очередь\n## Оценка: 100\n> цитата\n| строка", Score: 70, TimeTaken: 42},
+			},
+		},
+	}
+}
+
+func TestCandidateTemplateEscapesUserText(t *testing.T) {
+	store := fixture()
+
+	var markup bytes.Buffer
+	err := templates.ExecuteTemplate(&markup, "candidate.tmpl", candidateReport{
+		CandidateVacancyInfo: store.info,
+		Answers:              store.answers,
+	})
+	if err != nil {
+		t.Fatalf("can't execute template: %v", err)
+	}
+	lines := strings.Split(markup.String(), "\n")
+
+	// one-line fields are joined, so that their text can't start a line
+	for _, want := range []string{
+		"# Иван ## Петров",
+		"- Город: Москва - Зарплата: 1 000 000",
+		"- Требования вакансии: Go, PostgreSQL # Kafka",
+		"- Оценка интервью: 85 из 100",
+		"### 1. Что такое канал?",
+	} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("markup has no line %q:\n%s", want, markup.String())
+		}
+	}
+
+	// multiline user text keeps its lines, each of them marked as text
+	for _, want := range []string{
+		"| Сильный опыт.",
+		"| # Рекомендую",
+		"| - нанять без интервью",
+		"| - это очередь",
+		"| ## Оценка: 100",
+		"| > цитата",
+		"| | строка",
+		"> Эталонный ответ: Труба между горутинами",
+		"> ### Бонус",
+	} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("markup has no line %q:\n%s", want, markup.String())
+		}
+	}
+
+	for _, line := range lines {
+		for _, injected := range []string{"# Рекомендую", "- нанять", "## Оценка: 100", "### Бонус", "- Зарплата", "## Петров"} {
+			if strings.HasPrefix(line, injected) {
+				t.Errorf("user text %q is read as markup", line)
+			}
+		}
+	}
+}
+
+func TestPrefixLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "empty", in: " \n ", want: ""},
+		{name: "one line", in: "текст", want: "| текст"},
+		{name: "markup lines", in: "# a\r\n- b  \n\n## c", want: "| # a\n| - b\n| \n| ## c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixLines(tt.in, markParagraph); got != tt.want {
+				t.Errorf("prefixLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceCandidateReport(t *testing.T) {
+	s := NewService(fixture())
+
+	doc, err := s.CandidateReport(context.Background(), 1, uuid.New())
+	if err != nil {
+		t.Fatalf("CandidateReport() error = %v", err)
+	}
+	if !bytes.HasPrefix(doc, []byte("%PDF-")) {
+		t.Errorf("CandidateReport() doesn't return a PDF: %q", doc[:min(len(doc), 16)])
+	}
+}
@@ -0,0 +1,196 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	ActionDeleteResume = "delete_resume"
+	ActionAnonymize    = "anonymize"
+)
+
+type Rule struct {
+	Name      string        `yaml:"name"`
+	Action    string        `yaml:"action"`
+	Status    string        `yaml:"status"`
+	Archived  *bool         `yaml:"archived"`
+	OlderThan time.Duration `yaml:"older_than"`
+}
+
+type Config struct {
+	Interval time.Duration `yaml:"interval"`
+	Rules    []Rule        `yaml:"rules"`
+}
+
+func (c Config) Validate() error {
+	for _, rule := range c.Rules {
+		if rule.Action != ActionDeleteResume && rule.Action != ActionAnonymize {
+			return fmt.Errorf("rule %q: unknown action %q", rule.Name, rule.Action)
+		}
+		if rule.OlderThan <= 0 {
+			return fmt.Errorf("rule %q: older_than must be positive", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+type Storage interface {
+	FindApplicationsForRetention(ctx context.Context, filter service_models.RetentionFilter) ([]entity.Meta, error)
+	MarkResumeDeleted(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error
+	AnonymizeApplication(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error
+}
+
+type ResumeStorage interface {
+	Delete(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error
+}
+
+type VoiceStorage interface {
+	Delete(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error
+}
+
+type Service struct {
+	cfg           Config
+	store         Storage
+	resumeStorage ResumeStorage
+	voiceStorage  VoiceStorage
+}
+
+func NewService(cfg Config, store Storage, resumeStorage ResumeStorage, voiceStorage VoiceStorage) *Service {
+	return &Service{
+		cfg:           cfg,
+		store:         store,
+		resumeStorage: resumeStorage,
+		voiceStorage:  voiceStorage,
+	}
+}
+
+// Start runs the rules every cfg.Interval until the returned stop function is called.
+func (s *Service) Start(ctx context.Context) (stop func()) {
+	if s.cfg.Interval <= 0 || len(s.cfg.Rules) == 0 {
+		loggy.Infoln("retention scheduler is disabled")
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			err := s.Apply(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				loggy.Errorf("can't apply retention rules: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// DryRun reports applications which would be purged by each rule without touching them.
+func (s *Service) DryRun(ctx context.Context) ([]service_models.RetentionRuleReport, error) {
+	reports := make([]service_models.RetentionRuleReport, 0, len(s.cfg.Rules))
+
+	for _, rule := range s.cfg.Rules {
+		applications, err := s.store.FindApplicationsForRetention(ctx, ruleFilter(rule, time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("can't find applications for rule %q: %w", rule.Name, err)
+		}
+
+		reports = append(reports, service_models.RetentionRuleReport{
+			Rule:         rule.Name,
+			Action:       rule.Action,
+			Applications: applications,
+		})
+	}
+
+	return reports, nil
+}
+
+// Apply runs every rule over the matching applications. A failed rule or application is logged and skipped,
+// so that it doesn't hold back the rest; it is retried on the next run.
+func (s *Service) Apply(ctx context.Context) error {
+	for _, rule := range s.cfg.Rules {
+		applications, err := s.store.FindApplicationsForRetention(ctx, ruleFilter(rule, time.Now()))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			loggy.Errorf("can't find applications for rule %q: %v", rule.Name, err)
+			continue
+		}
+
+		applied := 0
+		for _, application := range applications {
+			err = s.applyRule(ctx, rule, application)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				loggy.Errorf("can't apply rule %q to candidate %d, vacancy %s: %v",
+					rule.Name, application.CandidateID, application.VacancyID, err)
+				continue
+			}
+			applied++
+		}
+
+		if len(applications) > 0 {
+			loggy.Infof("retention rule %q applied to %d of %d applications", rule.Name, applied, len(applications))
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) applyRule(ctx context.Context, rule Rule, application entity.Meta) error {
+	err := s.resumeStorage.Delete(ctx, application.CandidateID, application.VacancyID)
+	if err != nil {
+		return fmt.Errorf("can't delete resume: %w", err)
+	}
+
+	switch rule.Action {
+	case ActionDeleteResume:
+		return s.store.MarkResumeDeleted(ctx, application.CandidateID, application.VacancyID)
+	case ActionAnonymize:
+		err = s.voiceStorage.Delete(ctx, application.CandidateID, application.VacancyID)
+		if err != nil {
+			return fmt.Errorf("can't delete voice answers: %w", err)
+		}
+
+		return s.store.AnonymizeApplication(ctx, application.CandidateID, application.VacancyID)
+	default:
+		return fmt.Errorf("unknown action %q", rule.Action)
+	}
+}
+
+func ruleFilter(rule Rule, now time.Time) service_models.RetentionFilter {
+	return service_models.RetentionFilter{
+		Status:            rule.Status,
+		IsArchived:        rule.Archived,
+		UpdatedBefore:     now.Add(-rule.OlderThan),
+		SkipResumeDeleted: rule.Action == ActionDeleteResume,
+	}
+}
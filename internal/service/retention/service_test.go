@@ -0,0 +1,166 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+func TestMain(m *testing.M) {
+	loggy.InitDefault()
+	os.Exit(m.Run())
+}
+
+type storageStub struct {
+	applications map[string][]entity.Meta
+	findErr      map[string]error
+	anonymized   []int64
+	resumeMarked []int64
+}
+
+func (s *storageStub) FindApplicationsForRetention(_ context.Context, filter service_models.RetentionFilter) ([]entity.Meta, error) {
+	return s.applications[filter.Status], s.findErr[filter.Status]
+}
+
+func (s *storageStub) MarkResumeDeleted(_ context.Context, candidateID int64, _ uuid.UUID) error {
+	s.resumeMarked = append(s.resumeMarked, candidateID)
+	return nil
+}
+
+func (s *storageStub) AnonymizeApplication(_ context.Context, candidateID int64, _ uuid.UUID) error {
+	s.anonymized = append(s.anonymized, candidateID)
+	return nil
+}
+
+type filesStub struct {
+	failFor int64
+}
+
+func (s filesStub) Delete(_ context.Context, candidateID int64, _ uuid.UUID) error {
+	if candidateID == s.failFor {
+		return errors.New("storage is unavailable")
+	}
+	return nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []Rule
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			rules: []Rule{
+				{Name: "resumes", Action: ActionDeleteResume, OlderThan: time.Hour},
+				{Name: "rejected", Action: ActionAnonymize, Status: "screening_failed", OlderThan: 24 * time.Hour},
+			},
+		},
+		{
+			name: "no rules",
+		},
+		{
+			name:    "unknown action",
+			rules:   []Rule{{Name: "drop", Action: "drop", OlderThan: time.Hour}},
+			wantErr: true,
+		},
+		{
+			name:    "zero age",
+			rules:   []Rule{{Name: "resumes", Action: ActionDeleteResume}},
+			wantErr: true,
+		},
+		{
+			name:    "negative age",
+			rules:   []Rule{{Name: "resumes", Action: ActionAnonymize, OlderThan: -time.Hour}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{Rules: tt.rules}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleFilter(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	archived := true
+
+	tests := []struct {
+		name string
+		rule Rule
+		want service_models.RetentionFilter
+	}{
+		{
+			name: "delete resume",
+			rule: Rule{Action: ActionDeleteResume, Status: "screening_failed", OlderThan: 48 * time.Hour},
+			want: service_models.RetentionFilter{
+				Status:            "screening_failed",
+				UpdatedBefore:     now.Add(-48 * time.Hour),
+				SkipResumeDeleted: true,
+			},
+		},
+		{
+			name: "anonymize archived",
+			rule: Rule{Action: ActionAnonymize, Archived: &archived, OlderThan: time.Hour},
+			want: service_models.RetentionFilter{
+				IsArchived:    &archived,
+				UpdatedBefore: now.Add(-time.Hour),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ruleFilter(tt.rule, now)
+			if got.Status != tt.want.Status ||
+				got.IsArchived != tt.want.IsArchived ||
+				!got.UpdatedBefore.Equal(tt.want.UpdatedBefore) ||
+				got.SkipResumeDeleted != tt.want.SkipResumeDeleted {
+				t.Errorf("ruleFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceApplyContinuesAfterFailure(t *testing.T) {
+	store := &storageStub{
+		applications: map[string][]entity.Meta{
+			"screening_failed": {{CandidateID: 1}, {CandidateID: 2}, {CandidateID: 3}},
+			"interview_failed": {{CandidateID: 4}},
+		},
+		findErr: map[string]error{
+			"screening_ok": errors.New("database is unavailable"),
+		},
+	}
+	service := NewService(Config{Rules: []Rule{
+		{Name: "broken", Action: ActionAnonymize, Status: "screening_ok", OlderThan: time.Hour},
+		{Name: "rejected", Action: ActionAnonymize, Status: "screening_failed", OlderThan: time.Hour},
+		{Name: "failed", Action: ActionDeleteResume, Status: "interview_failed", OlderThan: time.Hour},
+	}}, store, filesStub{failFor: 2}, filesStub{})
+
+	err := service.Apply(context.Background())
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if !slices.Equal(store.anonymized, []int64{1, 3}) {
+		t.Errorf("anonymized = %v, want [1 3]", store.anonymized)
+	}
+	if !slices.Equal(store.resumeMarked, []int64{4}) {
+		t.Errorf("resume marked deleted = %v, want [4]", store.resumeMarked)
+	}
+}
@@ -0,0 +1,85 @@
+package scheduling
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const (
+	icsTimeLayout = "20060102T150405Z"
+	// icsLineLength is the maximum line length in octets, see RFC 5545 section 3.1.
+	icsLineLength = 75
+)
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+type calendarEvent struct {
+	UID            string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	Summary        string
+	Description    string
+	Location       string
+	OrganizerEmail string
+	AttendeeName   string
+	AttendeeEmail  string
+}
+
+// Marshal renders the event as a single-event iCalendar request.
+func (e calendarEvent) Marshal(now time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeLine := func(line string) {
+		buf.WriteString(foldICSLine(line))
+		buf.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//hr-helper//interview scheduling//RU")
+	writeLine("METHOD:REQUEST")
+	writeLine("BEGIN:VEVENT")
+	writeLine("UID:" + e.UID)
+	writeLine("DTSTAMP:" + now.UTC().Format(icsTimeLayout))
+	writeLine("DTSTART:" + e.StartsAt.UTC().Format(icsTimeLayout))
+	writeLine("DTEND:" + e.EndsAt.UTC().Format(icsTimeLayout))
+	writeLine("SUMMARY:" + icsTextEscaper.Replace(e.Summary))
+	if e.Description != "" {
+		writeLine("DESCRIPTION:" + icsTextEscaper.Replace(e.Description))
+	}
+	if e.Location != "" {
+		writeLine("LOCATION:" + icsTextEscaper.Replace(e.Location))
+	}
+	writeLine("ORGANIZER:mailto:" + e.OrganizerEmail)
+	if e.AttendeeEmail != "" {
+		writeLine(`ATTENDEE;CN="` + strings.ReplaceAll(e.AttendeeName, `"`, "'") + `";ROLE=REQ-PARTICIPANT:mailto:` + e.AttendeeEmail)
+	}
+	writeLine("STATUS:CONFIRMED")
+	writeLine("END:VEVENT")
+	writeLine("END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// foldICSLine splits lines longer than icsLineLength octets without breaking UTF-8 sequences.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLength {
+		return line
+	}
+
+	var b strings.Builder
+	lineLen := 0
+	for _, r := range line {
+		size := len(string(r))
+		if lineLen+size > icsLineLength {
+			b.WriteString("\r\n ")
+			// the leading space counts towards the limit of the continuation line
+			lineLen = 1
+		}
+		b.WriteRune(r)
+		lineLen += size
+	}
+
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package scheduling
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:Интервью"},
+		{name: "exactly the limit", line: strings.Repeat("a", icsLineLength)},
+		{name: "ascii", line: "DESCRIPTION:" + strings.Repeat("x", 200)},
+		{name: "cyrillic", line: "SUMMARY:" + strings.Repeat("Интервью ", 30)},
+		{name: "four-byte runes", line: "LOCATION:" + strings.Repeat("😀", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldICSLine(tt.line)
+
+			lines := strings.Split(folded, "\r\n")
+			for i, line := range lines {
+				if len(line) > icsLineLength {
+					t.Errorf("line %d is %d octets long, want at most %d", i, len(line), icsLineLength)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d breaks a UTF-8 sequence: %q", i, line)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d doesn't start with a space: %q", i, line)
+				}
+			}
+
+			// unfolding per RFC 5545 removes CRLF followed by a single space
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.line)
+			}
+			if len(tt.line) <= icsLineLength && folded != tt.line {
+				t.Errorf("short line was folded: %q", folded)
+			}
+		})
+	}
+}
+
+func TestCalendarEventMarshal(t *testing.T) {
+	startsAt := time.Date(2026, 3, 2, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	event := calendarEvent{
+		UID:            "interview-slot-1@hr-helper",
+		StartsAt:       startsAt,
+		EndsAt:         startsAt.Add(time.Hour),
+		Summary:        "Интервью: Go, backend; senior",
+		Description:    "строка 1\nстрока 2",
+		OrganizerEmail: "hr@example.com",
+		AttendeeName:   `Иван "Ваня"`,
+		AttendeeEmail:  "ivan@example.com",
+	}
+
+	got := string(event.Marshal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20260301T000000Z\r\n",
+		"DTSTART:20260302T090000Z\r\n",
+		"DTEND:20260302T100000Z\r\n",
+		`SUMMARY:Интервью: Go\, backend\; senior` + "\r\n",
+		`DESCRIPTION:строка 1\nстрока 2` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("calendar doesn't contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "LOCATION:") {
+		t.Errorf("calendar has a location though it's empty:\n%s", got)
+	}
+	if unfolded := strings.ReplaceAll(got, "\r\n ", ""); !strings.Contains(unfolded, `ATTENDEE;CN="Иван 'Ваня'";ROLE=REQ-PARTICIPANT:mailto:ivan@example.com`) {
+		t.Errorf("calendar doesn't contain the attendee:\n%s", got)
+	}
+}
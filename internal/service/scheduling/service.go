@@ -0,0 +1,226 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+const (
+	maxSlotDuration = 8 * time.Hour
+	// recruiterSlotsPeriod is used when the recruiter doesn't limit the listed period.
+	recruiterSlotsPeriod = 30 * 24 * time.Hour
+)
+
+type Storage interface {
+	CreateSlot(ctx context.Context, slot entity.InterviewSlot) (int64, error)
+	GetSlot(ctx context.Context, slotID int64) (entity.InterviewSlot, error)
+	GetBookedSlot(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.InterviewSlot, error)
+	GetRecruiterSlots(ctx context.Context, recruiterEmail string, from time.Time, to time.Time) ([]entity.InterviewSlot, error)
+	GetAvailableSlots(ctx context.Context, vacancyID uuid.UUID) ([]entity.InterviewSlot, error)
+	DeleteSlot(ctx context.Context, slotID int64, recruiterEmail string) error
+	BookSlot(ctx context.Context, slotID int64, candidateID int64, vacancyID uuid.UUID) error
+}
+
+type CandidateStorage interface {
+	GetByID(ctx context.Context, candidateID int64) (entity.Candidate, error)
+	GetMeta(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.Meta, error)
+}
+
+type VacancyStorage interface {
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Vacancy, error)
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts dobby.TxOptions) error
+}
+
+type Service struct {
+	store          Storage
+	candidateStore CandidateStorage
+	vacancyStore   VacancyStorage
+	publisher      EventPublisher
+	transactor     Transactor
+}
+
+func NewService(store Storage, candidateStore CandidateStorage, vacancyStore VacancyStorage, publisher EventPublisher, transactor Transactor) *Service {
+	return &Service{
+		store:          store,
+		candidateStore: candidateStore,
+		vacancyStore:   vacancyStore,
+		publisher:      publisher,
+		transactor:     transactor,
+	}
+}
+
+func (s *Service) CreateSlot(ctx context.Context, recruiterEmail string, req dto_models.CreateInterviewSlotRequest) (entity.InterviewSlot, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return entity.InterviewSlot{}, fmt.Errorf("%w: slot must end after it starts", inerrors.ErrInvalidInput)
+	}
+	if req.EndsAt.Sub(req.StartsAt) > maxSlotDuration {
+		return entity.InterviewSlot{}, fmt.Errorf("%w: slot can't be longer than %s", inerrors.ErrInvalidInput, maxSlotDuration)
+	}
+	if !req.StartsAt.After(time.Now()) {
+		return entity.InterviewSlot{}, fmt.Errorf("%w: slot must start in the future", inerrors.ErrInvalidInput)
+	}
+
+	slot := entity.InterviewSlot{
+		RecruiterEmail: recruiterEmail,
+		VacancyID:      req.VacancyID,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Location:       req.Location,
+	}
+
+	id, err := s.store.CreateSlot(ctx, slot)
+	if err != nil {
+		return entity.InterviewSlot{}, err
+	}
+
+	return s.store.GetSlot(ctx, id)
+}
+
+func (s *Service) GetRecruiterSlots(ctx context.Context, recruiterEmail string, from time.Time, to time.Time) ([]entity.InterviewSlot, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(recruiterSlotsPeriod)
+	}
+
+	return s.store.GetRecruiterSlots(ctx, recruiterEmail, from, to)
+}
+
+func (s *Service) DeleteSlot(ctx context.Context, slotID int64, recruiterEmail string) error {
+	return s.store.DeleteSlot(ctx, slotID, recruiterEmail)
+}
+
+// GetAvailableSlots returns slots the candidate can book; only applications that passed the interview get them.
+func (s *Service) GetAvailableSlots(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.InterviewSlot, error) {
+	err := s.checkCanBook(ctx, candidateID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetAvailableSlots(ctx, vacancyID)
+}
+
+func (s *Service) GetBookedSlot(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.InterviewSlot, error) {
+	return s.store.GetBookedSlot(ctx, candidateID, vacancyID)
+}
+
+// BookSlot books the slot for an application that passed the interview; the storage checks the status
+// under the candidate's booking lock.
+func (s *Service) BookSlot(ctx context.Context, req dto_models.BookInterviewSlotRequest) (entity.InterviewSlot, error) {
+	_, err := s.store.GetSlot(ctx, req.SlotID)
+	if err != nil {
+		return entity.InterviewSlot{}, fmt.Errorf("can't get slot: %w", err)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.store.BookSlot(ctx, req.SlotID, req.CandidateID, req.VacancyID)
+		if err != nil {
+			return err
+		}
+
+		event, err := entity.NewEvent(entity.EventTypeStatusChanged, entity.StatusChangedEvent{
+			CandidateID: req.CandidateID,
+			VacancyID:   req.VacancyID,
+			OldStatus:   entity.CandidateVacancyStatusInterviewOk,
+			NewStatus:   entity.CandidateVacancyStatusInterviewScheduled,
+		})
+		if err != nil {
+			return fmt.Errorf("can't create event: %w", err)
+		}
+
+		return s.publisher.Publish(ctx, event)
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return entity.InterviewSlot{}, err
+	}
+
+	return s.store.GetSlot(ctx, req.SlotID)
+}
+
+func (s *Service) checkCanBook(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	meta, err := s.candidateStore.GetMeta(ctx, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get meta: %w", err)
+	}
+	if meta.Status != entity.CandidateVacancyStatusInterviewOk {
+		return fmt.Errorf("%w: application is in status %s, not %s", inerrors.ErrConflict, meta.Status, entity.CandidateVacancyStatusInterviewOk)
+	}
+
+	return nil
+}
+
+// GetSlotCalendar returns the slot as an iCalendar invitation; booked slots include the candidate as an attendee.
+func (s *Service) GetSlotCalendar(ctx context.Context, slotID int64) ([]byte, error) {
+	slot, err := s.store.GetSlot(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get slot: %w", err)
+	}
+
+	return s.slotCalendar(ctx, slot)
+}
+
+// GetBookedSlotCalendar returns the invitation to a slot only to the candidate who booked it for the vacancy.
+func (s *Service) GetBookedSlotCalendar(ctx context.Context, slotID int64, candidateID int64, vacancyID uuid.UUID) ([]byte, error) {
+	slot, err := s.store.GetSlot(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get slot: %w", err)
+	}
+	if !slot.IsBooked() || *slot.CandidateID != candidateID || slot.BookedVacancyID == nil || *slot.BookedVacancyID != vacancyID {
+		return nil, fmt.Errorf("%w: slot %d isn't booked by candidate %d for vacancy %s", inerrors.ErrNotFound, slotID, candidateID, vacancyID)
+	}
+
+	return s.slotCalendar(ctx, slot)
+}
+
+func (s *Service) slotCalendar(ctx context.Context, slot entity.InterviewSlot) ([]byte, error) {
+	event := calendarEvent{
+		UID:            fmt.Sprintf("interview-slot-%d@hr-helper", slot.ID),
+		StartsAt:       slot.StartsAt,
+		EndsAt:         slot.EndsAt,
+		Summary:        "Интервью",
+		Location:       slot.Location,
+		OrganizerEmail: slot.RecruiterEmail,
+	}
+
+	vacancyID := slot.VacancyID
+	if slot.IsBooked() {
+		vacancyID = slot.BookedVacancyID
+
+		candidate, err := s.candidateStore.GetByID(ctx, *slot.CandidateID)
+		if err != nil {
+			return nil, fmt.Errorf("can't get candidate: %w", err)
+		}
+		event.AttendeeName = candidate.FullName
+		event.AttendeeEmail = candidate.Email
+		event.Description = "Кандидат: " + candidate.FullName
+		if candidate.TelegramUsername != "" {
+			event.Description += ", Telegram: @" + candidate.TelegramUsername
+		}
+	}
+
+	if vacancyID != nil {
+		vacancy, err := s.vacancyStore.GetByID(ctx, *vacancyID)
+		if err != nil {
+			return nil, fmt.Errorf("can't get vacancy: %w", err)
+		}
+		event.Summary += ": " + vacancy.Title
+	}
+
+	return event.Marshal(time.Now()), nil
+}
@@ -0,0 +1,85 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type storageStub struct {
+	Storage
+	slots map[int64]entity.InterviewSlot
+}
+
+func (s storageStub) GetSlot(_ context.Context, slotID int64) (entity.InterviewSlot, error) {
+	slot, ok := s.slots[slotID]
+	if !ok {
+		return entity.InterviewSlot{}, inerrors.ErrNotFound
+	}
+	return slot, nil
+}
+
+type candidateStorageStub struct {
+	CandidateStorage
+}
+
+func (candidateStorageStub) GetByID(_ context.Context, candidateID int64) (entity.Candidate, error) {
+	return entity.Candidate{ID: candidateID, FullName: "Иван"}, nil
+}
+
+type vacancyStorageStub struct{}
+
+func (vacancyStorageStub) GetByID(_ context.Context, id uuid.UUID) (entity.Vacancy, error) {
+	return entity.Vacancy{ID: id, Title: "Go"}, nil
+}
+
+func TestServiceGetBookedSlotCalendar(t *testing.T) {
+	candidateID := int64(1)
+	otherCandidateID := int64(2)
+	vacancyID := uuid.New()
+	otherVacancyID := uuid.New()
+	startsAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	s := NewService(storageStub{slots: map[int64]entity.InterviewSlot{
+		1: {ID: 1, RecruiterEmail: "hr@example.com", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), CandidateID: &candidateID, BookedVacancyID: &vacancyID},
+		2: {ID: 2, RecruiterEmail: "hr@example.com", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)},
+		3: {ID: 3, RecruiterEmail: "hr@example.com", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), CandidateID: &otherCandidateID, BookedVacancyID: &vacancyID},
+	}}, candidateStorageStub{}, vacancyStorageStub{}, nil, nil)
+
+	tests := []struct {
+		name      string
+		slotID    int64
+		vacancyID uuid.UUID
+		wantErr   error
+	}{
+		{name: "booked by the candidate", slotID: 1, vacancyID: vacancyID},
+		{name: "booked for another vacancy", slotID: 1, vacancyID: otherVacancyID, wantErr: inerrors.ErrNotFound},
+		{name: "free slot", slotID: 2, vacancyID: vacancyID, wantErr: inerrors.ErrNotFound},
+		{name: "booked by another candidate", slotID: 3, vacancyID: vacancyID, wantErr: inerrors.ErrNotFound},
+		{name: "missing slot", slotID: 4, vacancyID: vacancyID, wantErr: inerrors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar, err := s.GetBookedSlotCalendar(context.Background(), tt.slotID, candidateID, tt.vacancyID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetBookedSlotCalendar() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetBookedSlotCalendar() error = %v", err)
+			}
+			if len(calendar) == 0 {
+				t.Error("GetBookedSlotCalendar() returned an empty calendar")
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+type Storage interface {
+	GetCandidateVacancyInfo(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.CandidateVacancyInfo, error)
+	GetCandidateAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.CandidateQuestionAnswer, error)
+	GetConsents(ctx context.Context, candidateID int64) ([]entity.Consent, error)
+	UpsertCandidateSummary(ctx context.Context, summary entity.CandidateSummary) error
+}
+
+type ResumeStorage interface {
+	Download(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]byte, error)
+}
+
+type TextExtractor interface {
+	ExtractText(ctx context.Context, data []byte, contentType string) (string, error)
+}
+
+type LLMClient interface {
+	SummarizeCandidate(ctx context.Context, resumeText string, vacancy entity.Vacancy, answers []entity.CandidateQuestionAnswer) (service_models.CandidateSummaryResult, error)
+}
+
+type Service struct {
+	store         Storage
+	resumeStorage ResumeStorage
+	textExtractor TextExtractor
+	llmClient     LLMClient
+}
+
+func NewService(store Storage, resumeStorage ResumeStorage, textExtractor TextExtractor, llmClient LLMClient) *Service {
+	return &Service{
+		store:         store,
+		resumeStorage: resumeStorage,
+		textExtractor: textExtractor,
+		llmClient:     llmClient,
+	}
+}
+
+// Publish summarizes the application on interview.completed events, so the service can be an outbox sink.
+func (s *Service) Publish(ctx context.Context, event entity.Event) error {
+	if event.Type != entity.EventTypeInterviewCompleted {
+		return nil
+	}
+
+	var data entity.InterviewCompletedEvent
+	err := json.Unmarshal(event.Data, &data)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal event data: %w", err)
+	}
+
+	return s.Summarize(ctx, data.CandidateID, data.VacancyID)
+}
+
+// Summarize asks the LLM for strengths, weaknesses, red flags and a recommendation over the resume and interview,
+// replacing the previous summary of the application.
+func (s *Service) Summarize(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
+	consents, err := s.store.GetConsents(ctx, candidateID)
+	if err != nil {
+		return fmt.Errorf("can't get consents: %w", err)
+	}
+	if !entity.HasActiveConsent(consents, entity.ConsentTypeAIEvaluation) {
+		loggy.Infof("skipping summary of candidate %d: no ai evaluation consent", candidateID)
+		return nil
+	}
+
+	info, err := s.store.GetCandidateVacancyInfo(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		// the application was deleted after the interview
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get candidate vacancy info: %w", err)
+	}
+
+	answers, err := s.store.GetCandidateAnswers(ctx, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get answers: %w", err)
+	}
+
+	resumeText, err := s.resumeText(ctx, info)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.llmClient.SummarizeCandidate(ctx, resumeText, info.Vacancy, answers)
+	if err != nil {
+		return fmt.Errorf("can't summarize candidate via llm: %w", err)
+	}
+	if result.Recommendation != entity.CandidateRecommendationHire && result.Recommendation != entity.CandidateRecommendationNoHire {
+		return fmt.Errorf("llm returned unknown recommendation %q", result.Recommendation)
+	}
+
+	err = s.store.UpsertCandidateSummary(ctx, entity.CandidateSummary{
+		CandidateID:    candidateID,
+		VacancyID:      vacancyID,
+		Strengths:      nonNil(result.Strengths),
+		Weaknesses:     nonNil(result.Weaknesses),
+		RedFlags:       nonNil(result.RedFlags),
+		Recommendation: result.Recommendation,
+		Rationale:      result.Rationale,
+	})
+	if err != nil {
+		return fmt.Errorf("can't save summary: %w", err)
+	}
+
+	return nil
+}
+
+// resumeText falls back to the screening feedback when the resume file is already removed by retention rules.
+func (s *Service) resumeText(ctx context.Context, info entity.CandidateVacancyInfo) (string, error) {
+	resume, err := s.resumeStorage.Download(ctx, info.Candidate.ID, info.Vacancy.ID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return info.ResumeScreening.Feedback, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't download resume: %w", err)
+	}
+
+	text, err := s.textExtractor.ExtractText(ctx, resume, "application/pdf")
+	if err != nil {
+		return "", fmt.Errorf("can't extract text from resume: %w", err)
+	}
+
+	return text, nil
+}
+
+func nonNil(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+
+	return items
+}
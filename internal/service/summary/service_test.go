@@ -0,0 +1,60 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type resumeStorageStub struct {
+	resume []byte
+	err    error
+}
+
+func (s resumeStorageStub) Download(context.Context, int64, uuid.UUID) ([]byte, error) {
+	return s.resume, s.err
+}
+
+type textExtractorStub struct{}
+
+func (textExtractorStub) ExtractText(_ context.Context, data []byte, _ string) (string, error) {
+	return "text of " + string(data), nil
+}
+
+func TestResumeText(t *testing.T) {
+	info := entity.CandidateVacancyInfo{
+		ResumeScreening: entity.ResumeScreening{Feedback: "screening feedback"},
+	}
+	errStorage := errors.New("storage is down")
+
+	tests := []struct {
+		name    string
+		storage resumeStorageStub
+		want    string
+		wantErr error
+	}{
+		{name: "resume", storage: resumeStorageStub{resume: []byte("resume")}, want: "text of resume"},
+		{name: "missing resume", storage: resumeStorageStub{err: fmt.Errorf("can't find: %w", inerrors.ErrNotFound)}, want: "screening feedback"},
+		{name: "storage error", storage: resumeStorageStub{err: errStorage}, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(nil, tt.storage, textExtractorStub{}, nil)
+
+			got, err := s.resumeText(context.Background(), info)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resumeText() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resumeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package vacancy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	defaultBankLimit = 100
+	maxBankLimit     = 1000
+)
+
+type QuestionBankStorage interface {
+	CreateBankQuestion(ctx context.Context, question dto_models.CreateBankQuestionRequest) (int64, error)
+	GetBankQuestion(ctx context.Context, id int64) (entity.BankQuestion, error)
+	GetBankQuestionsByIDs(ctx context.Context, ids []int64) ([]entity.BankQuestion, error)
+	FindBankQuestions(ctx context.Context, filter service_models.BankQuestionFilter) ([]entity.BankQuestion, error)
+	DeleteBankQuestion(ctx context.Context, id int64) error
+	DrawBankQuestions(ctx context.Context, candidateID int64, vacancyID uuid.UUID, tag string, count int) ([]int64, error)
+}
+
+func (s *Service) CreateBankQuestion(ctx context.Context, req dto_models.CreateBankQuestionRequest) (int64, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return 0, fmt.Errorf("%w: content is required", inerrors.ErrInvalidInput)
+	}
+
+	err := validateQuestion(&req.CreateQuestionRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Tags, err = normalizeTags(req.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.bankStore.CreateBankQuestion(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("can't create bank question: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) GetBankQuestion(ctx context.Context, id int64) (entity.BankQuestion, error) {
+	question, err := s.bankStore.GetBankQuestion(ctx, id)
+	if err != nil {
+		return entity.BankQuestion{}, fmt.Errorf("can't get bank question: %w", err)
+	}
+
+	return question, nil
+}
+
+func (s *Service) FindBankQuestions(ctx context.Context, filter service_models.BankQuestionFilter) ([]entity.BankQuestion, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultBankLimit
+	}
+	if filter.Limit > maxBankLimit {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", inerrors.ErrInvalidInput, maxBankLimit)
+	}
+
+	var err error
+	filter.Tags, err = normalizeTags(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.bankStore.FindBankQuestions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("can't find bank questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (s *Service) DeleteBankQuestion(ctx context.Context, id int64) error {
+	err := s.bankStore.DeleteBankQuestion(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't delete bank question: %w", err)
+	}
+
+	return nil
+}
+
+// AttachBankQuestions copies the bank questions to the end of the vacancy round, the copies are asked to everyone
+// and aren't affected by later changes of the bank.
+func (s *Service) AttachBankQuestions(ctx context.Context, vacancyID uuid.UUID, req dto_models.AttachBankQuestionsRequest) error {
+	if len(req.QuestionIDs) == 0 {
+		return fmt.Errorf("%w: question_ids are required", inerrors.ErrInvalidInput)
+	}
+	for i, id := range req.QuestionIDs {
+		if slices.Contains(req.QuestionIDs[:i], id) {
+			return fmt.Errorf("%w: question %d is listed twice", inerrors.ErrInvalidInput, id)
+		}
+	}
+	if req.Round == 0 {
+		req.Round = 1
+	}
+
+	rounds, err := s.store.GetRounds(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get rounds: %w", err)
+	}
+	if !slices.ContainsFunc(rounds, func(round entity.InterviewRound) bool { return round.Number == req.Round }) {
+		return fmt.Errorf("%w: round %d of vacancy %s", inerrors.ErrNotFound, req.Round, vacancyID)
+	}
+
+	questions, err := s.bankStore.GetBankQuestionsByIDs(ctx, req.QuestionIDs)
+	if err != nil {
+		return fmt.Errorf("can't get bank questions: %w", err)
+	}
+	for _, id := range req.QuestionIDs {
+		if !slices.ContainsFunc(questions, func(question entity.BankQuestion) bool { return question.ID == id }) {
+			return fmt.Errorf("%w: bank question %d", inerrors.ErrNotFound, id)
+		}
+	}
+
+	err = s.store.CopyBankQuestions(ctx, vacancyID, req.Round, nil, req.QuestionIDs)
+	if err != nil {
+		return fmt.Errorf("can't copy bank questions: %w", err)
+	}
+
+	return nil
+}
+
+// drawBankQuestions copies random bank questions by the round rules for the candidate once, so that
+// candidates get different questions and reloading the round doesn't change them.
+// A rule gets fewer questions if the bank runs out of them.
+func (s *Service) drawBankQuestions(ctx context.Context, candidateID int64, round entity.InterviewRound) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		drawn, err := s.store.MarkQuestionsDrawn(ctx, candidateID, round.VacancyID, round.Number)
+		if err != nil {
+			return fmt.Errorf("can't mark questions drawn: %w", err)
+		}
+		if !drawn {
+			return nil
+		}
+
+		for _, draw := range round.BankDraws {
+			ids, err := s.bankStore.DrawBankQuestions(ctx, candidateID, round.VacancyID, draw.Tag, draw.Count)
+			if err != nil {
+				return fmt.Errorf("can't draw bank questions by %s: %w", draw.Tag, err)
+			}
+			if len(ids) == 0 {
+				continue
+			}
+
+			err = s.store.CopyBankQuestions(ctx, round.VacancyID, round.Number, &candidateID, ids)
+			if err != nil {
+				return fmt.Errorf("can't copy bank questions: %w", err)
+			}
+		}
+
+		return nil
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		normalized, err := normalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(res, normalized) {
+			res = append(res, normalized)
+		}
+	}
+
+	return res, nil
+}
+
+// normalizeTag lowercases the "kind:value" tag and checks its kind.
+func normalizeTag(tag string) (string, error) {
+	kind, value, ok := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), ":")
+	kind, value = strings.TrimSpace(kind), strings.TrimSpace(value)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: tag %q must look like kind:value", inerrors.ErrInvalidInput, tag)
+	}
+
+	switch kind {
+	case entity.TagKindSkill, entity.TagKindSeniority, entity.TagKindLanguage:
+	default:
+		return "", fmt.Errorf("%w: unknown tag kind %q", inerrors.ErrInvalidInput, kind)
+	}
+
+	return kind + ":" + value, nil
+}
@@ -0,0 +1,224 @@
+package vacancy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
+)
+
+type copiedQuestions struct {
+	round       int
+	candidateID *int64
+	ids         []int64
+}
+
+type bankVacancyStorageStub struct {
+	Storage
+	rounds []entity.InterviewRound
+	drawn  map[int64]bool
+	copied []copiedQuestions
+}
+
+func (s *bankVacancyStorageStub) GetRounds(context.Context, uuid.UUID) ([]entity.InterviewRound, error) {
+	return s.rounds, nil
+}
+
+func (s *bankVacancyStorageStub) MarkQuestionsDrawn(_ context.Context, candidateID int64, _ uuid.UUID, _ int) (bool, error) {
+	if s.drawn[candidateID] {
+		return false, nil
+	}
+	s.drawn[candidateID] = true
+	return true, nil
+}
+
+func (s *bankVacancyStorageStub) CopyBankQuestions(_ context.Context, _ uuid.UUID, round int, candidateID *int64, ids []int64) error {
+	s.copied = append(s.copied, copiedQuestions{round: round, candidateID: candidateID, ids: ids})
+	return nil
+}
+
+type bankStorageStub struct {
+	QuestionBankStorage
+	questions []entity.BankQuestion
+	byTag     map[string][]int64
+}
+
+func (s bankStorageStub) GetBankQuestionsByIDs(_ context.Context, ids []int64) ([]entity.BankQuestion, error) {
+	var res []entity.BankQuestion
+	for _, question := range s.questions {
+		if slices.Contains(ids, question.ID) {
+			res = append(res, question)
+		}
+	}
+	return res, nil
+}
+
+func (s bankStorageStub) DrawBankQuestions(_ context.Context, _ int64, _ uuid.UUID, tag string, count int) ([]int64, error) {
+	ids := s.byTag[tag]
+	return ids[:min(count, len(ids))], nil
+}
+
+type transactorStub struct{}
+
+func (transactorStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, _ dobby.TxOptions) error {
+	return fn(ctx)
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "skill:go", want: "skill:go"},
+		{tag: " Skill : Go ", want: "skill:go"},
+		{tag: "SENIORITY:Senior", want: "seniority:senior"},
+		{tag: "language:en", want: "language:en"},
+		{tag: "skill:c:c++", want: "skill:c:c++"},
+		{tag: "go", wantErr: true},
+		{tag: "skill:", wantErr: true},
+		{tag: "skill: ", wantErr: true},
+		{tag: "topic:go", wantErr: true},
+		{tag: ":go", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := normalizeTag(tt.tag)
+			if tt.wantErr {
+				if !errors.Is(err, inerrors.ErrInvalidInput) {
+					t.Errorf("normalizeTag() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceAttachBankQuestions(t *testing.T) {
+	rounds := []entity.InterviewRound{{Number: 1}, {Number: 2}}
+	bank := bankStorageStub{questions: []entity.BankQuestion{{ID: 1}, {ID: 2}, {ID: 3}}}
+
+	tests := []struct {
+		name    string
+		req     dto_models.AttachBankQuestionsRequest
+		want    []copiedQuestions
+		wantErr error
+	}{
+		{
+			name: "first round by default",
+			req:  dto_models.AttachBankQuestionsRequest{QuestionIDs: []int64{3, 1}},
+			want: []copiedQuestions{{round: 1, ids: []int64{3, 1}}},
+		},
+		{
+			name: "second round",
+			req:  dto_models.AttachBankQuestionsRequest{Round: 2, QuestionIDs: []int64{2}},
+			want: []copiedQuestions{{round: 2, ids: []int64{2}}},
+		},
+		{
+			name:    "no questions",
+			req:     dto_models.AttachBankQuestionsRequest{},
+			wantErr: inerrors.ErrInvalidInput,
+		},
+		{
+			name:    "question listed twice",
+			req:     dto_models.AttachBankQuestionsRequest{QuestionIDs: []int64{1, 2, 1}},
+			wantErr: inerrors.ErrInvalidInput,
+		},
+		{
+			name:    "unknown round",
+			req:     dto_models.AttachBankQuestionsRequest{Round: 3, QuestionIDs: []int64{1}},
+			wantErr: inerrors.ErrNotFound,
+		},
+		{
+			name:    "unknown question",
+			req:     dto_models.AttachBankQuestionsRequest{QuestionIDs: []int64{1, 4}},
+			wantErr: inerrors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &bankVacancyStorageStub{rounds: rounds}
+			service := NewService(store, nil, nil, nil, nil, transactorStub{}, nil, nil, nil, bank)
+
+			err := service.AttachBankQuestions(context.Background(), uuid.New(), tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("AttachBankQuestions() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(store.copied) > 0 {
+					t.Errorf("copied %v, want nothing", store.copied)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AttachBankQuestions() error = %v", err)
+			}
+			if !equalCopies(store.copied, tt.want) {
+				t.Errorf("copied %v, want %v", store.copied, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceDrawBankQuestions(t *testing.T) {
+	store := &bankVacancyStorageStub{drawn: map[int64]bool{}}
+	bank := bankStorageStub{byTag: map[string][]int64{
+		"skill:go":         {10, 11, 12},
+		"seniority:senior": {20},
+	}}
+	service := NewService(store, nil, nil, nil, nil, transactorStub{}, nil, nil, nil, bank)
+
+	candidateID := int64(7)
+	round := entity.InterviewRound{
+		VacancyID: uuid.New(),
+		Number:    2,
+		BankDraws: []entity.BankDraw{
+			{Tag: "skill:go", Count: 2},
+			{Tag: "skill:rust", Count: 1},
+			{Tag: "seniority:senior", Count: 3},
+		},
+	}
+
+	err := service.drawBankQuestions(context.Background(), candidateID, round)
+	if err != nil {
+		t.Fatalf("drawBankQuestions() error = %v", err)
+	}
+	want := []copiedQuestions{
+		{round: 2, candidateID: &candidateID, ids: []int64{10, 11}},
+		{round: 2, candidateID: &candidateID, ids: []int64{20}},
+	}
+	if !equalCopies(store.copied, want) {
+		t.Errorf("copied %v, want %v", store.copied, want)
+	}
+
+	err = service.drawBankQuestions(context.Background(), candidateID, round)
+	if err != nil {
+		t.Fatalf("repeated drawBankQuestions() error = %v", err)
+	}
+	if len(store.copied) != len(want) {
+		t.Errorf("repeated draw copied %d more question sets", len(store.copied)-len(want))
+	}
+}
+
+func equalCopies(got []copiedQuestions, want []copiedQuestions) bool {
+	return slices.EqualFunc(got, want, func(a copiedQuestions, b copiedQuestions) bool {
+		sameCandidate := a.candidateID == nil && b.candidateID == nil ||
+			a.candidateID != nil && b.candidateID != nil && *a.candidateID == *b.candidateID
+		return a.round == b.round && sameCandidate && slices.Equal(a.ids, b.ids)
+	})
+}
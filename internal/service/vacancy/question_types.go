@@ -0,0 +1,183 @@
+package vacancy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+type CodeRunner interface {
+	Run(ctx context.Context, language string, code string, stdin string) (service_models.CodeRunResult, error)
+}
+
+// validateQuestion checks the fields required by the question type, an empty type means a text question.
+func validateQuestion(question *dto_models.CreateQuestionRequest) error {
+	if question.Type == "" {
+		question.Type = entity.QuestionTypeText
+	}
+
+	for _, point := range question.Rubric {
+		if strings.TrimSpace(point.Point) == "" || point.Weight <= 0 {
+			return fmt.Errorf("%w: rubric points must be non-empty with positive weight", inerrors.ErrInvalidInput)
+		}
+	}
+
+	switch question.Type {
+	case entity.QuestionTypeText:
+	case entity.QuestionTypeSingleChoice, entity.QuestionTypeMultipleChoice:
+		if len(question.Options) < 2 {
+			return fmt.Errorf("%w: choice question must have at least 2 options", inerrors.ErrInvalidInput)
+		}
+
+		correct := 0
+		for _, option := range question.Options {
+			if strings.TrimSpace(option.Text) == "" {
+				return fmt.Errorf("%w: options must be non-empty", inerrors.ErrInvalidInput)
+			}
+			if option.Correct {
+				correct++
+			}
+		}
+		if question.Type == entity.QuestionTypeSingleChoice && correct != 1 {
+			return fmt.Errorf("%w: single choice question must have exactly 1 correct option", inerrors.ErrInvalidInput)
+		}
+		if correct == 0 {
+			return fmt.Errorf("%w: multiple choice question must have a correct option", inerrors.ErrInvalidInput)
+		}
+	case entity.QuestionTypeNumeric:
+		if question.NumericAnswer == nil {
+			return fmt.Errorf("%w: numeric question must have numeric_answer", inerrors.ErrInvalidInput)
+		}
+		if question.NumericTolerance < 0 {
+			return fmt.Errorf("%w: numeric_tolerance must be non-negative", inerrors.ErrInvalidInput)
+		}
+	case entity.QuestionTypeCode:
+		if strings.TrimSpace(question.CodeLanguage) == "" {
+			return fmt.Errorf("%w: code question must have code_language", inerrors.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: unknown question type %q", inerrors.ErrInvalidInput, question.Type)
+	}
+
+	return nil
+}
+
+// scoreChoice scores the selected options without the LLM. A multiple choice answer gets a share of the correct
+// options it selected, every wrong one cancels a correct one.
+func scoreChoice(question entity.Question, selected []int) (string, service_models.AnswerScoringResult, error) {
+	if len(selected) == 0 {
+		return "", service_models.AnswerScoringResult{}, fmt.Errorf("%w: no options selected", inerrors.ErrInvalidInput)
+	}
+	if question.Type == entity.QuestionTypeSingleChoice && len(selected) > 1 {
+		return "", service_models.AnswerScoringResult{}, fmt.Errorf("%w: single choice question takes one option", inerrors.ErrInvalidInput)
+	}
+
+	texts := make([]string, 0, len(selected))
+	for i, option := range selected {
+		if option < 0 || option >= len(question.Options) {
+			return "", service_models.AnswerScoringResult{}, fmt.Errorf("%w: option %d is out of range", inerrors.ErrInvalidInput, option)
+		}
+		if slices.Contains(selected[:i], option) {
+			return "", service_models.AnswerScoringResult{}, fmt.Errorf("%w: option %d is selected twice", inerrors.ErrInvalidInput, option)
+		}
+
+		texts = append(texts, question.Options[option].Text)
+	}
+
+	var correct, selectedCorrect, selectedWrong int
+	var missingPoints []string
+	for i, option := range question.Options {
+		isSelected := slices.Contains(selected, i)
+		switch {
+		case option.Correct && isSelected:
+			selectedCorrect++
+		case option.Correct:
+			missingPoints = append(missingPoints, option.Text)
+		case isSelected:
+			selectedWrong++
+		}
+		if option.Correct {
+			correct++
+		}
+	}
+
+	res := service_models.AnswerScoringResult{
+		Score:         int(math.Round(100 * float64(max(selectedCorrect-selectedWrong, 0)) / float64(correct))),
+		MissingPoints: missingPoints,
+	}
+	switch {
+	case res.Score == 100:
+		res.Explanation = "Выбраны все верные варианты."
+	case question.Type == entity.QuestionTypeSingleChoice:
+		res.Explanation = "Выбран неверный вариант."
+	default:
+		res.Explanation = fmt.Sprintf("Выбрано верных вариантов: %d из %d, неверных: %d.", selectedCorrect, correct, selectedWrong)
+	}
+
+	return strings.Join(texts, "; "), res, nil
+}
+
+// scoreNumeric accepts answers within the question tolerance, both dot and comma are taken as decimal separators.
+func scoreNumeric(question entity.Question, answer string) (service_models.AnswerScoringResult, error) {
+	if question.NumericAnswer == nil {
+		return service_models.AnswerScoringResult{}, fmt.Errorf("numeric question %d has no answer", question.ID)
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(answer), ",", "."), 64)
+	if err != nil {
+		return service_models.AnswerScoringResult{}, fmt.Errorf("%w: answer must be a number", inerrors.ErrInvalidInput)
+	}
+
+	if math.Abs(value-*question.NumericAnswer) <= question.NumericTolerance {
+		return service_models.AnswerScoringResult{
+			Score:       100,
+			Explanation: "Ответ верный.",
+		}, nil
+	}
+
+	return service_models.AnswerScoringResult{
+		Score:       0,
+		Explanation: fmt.Sprintf("Ответ неверный, правильный ответ: %g.", *question.NumericAnswer),
+	}, nil
+}
+
+// scoreCode combines the LLM review of the code with the share of passed test cases half and half.
+// Test cases are skipped when no code runner is configured.
+func (s *Service) scoreCode(ctx context.Context, question entity.Question, code string) (service_models.AnswerScoringResult, error) {
+	res, err := s.scoreByLLM(ctx, question, code)
+	if err != nil {
+		return service_models.AnswerScoringResult{}, err
+	}
+
+	if s.codeRunner == nil || len(question.TestCases) == 0 {
+		return res, nil
+	}
+
+	passed := 0
+	for i, testCase := range question.TestCases {
+		run, err := s.codeRunner.Run(ctx, question.CodeLanguage, code, testCase.Input)
+		if err != nil {
+			return service_models.AnswerScoringResult{}, fmt.Errorf("can't run test case %d: %w", i+1, err)
+		}
+
+		if run.ExitCode == 0 && strings.TrimSpace(run.Stdout) == strings.TrimSpace(testCase.ExpectedOutput) {
+			passed++
+			continue
+		}
+		res.MissingPoints = append(res.MissingPoints, fmt.Sprintf("Не пройден тест %d", i+1))
+	}
+
+	testScore := 100 * float64(passed) / float64(len(question.TestCases))
+	res.Score = int(math.Round((float64(res.Score) + testScore) / 2))
+	res.Explanation = strings.TrimSpace(fmt.Sprintf("%s Тесты: пройдено %d из %d.", res.Explanation, passed, len(question.TestCases)))
+
+	return res, nil
+}
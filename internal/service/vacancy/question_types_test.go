@@ -0,0 +1,115 @@
+package vacancy
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+func TestScoreChoice(t *testing.T) {
+	single := entity.Question{
+		Type: entity.QuestionTypeSingleChoice,
+		Options: []entity.ChoiceOption{
+			{Text: "chan"},
+			{Text: "map", Correct: true},
+			{Text: "slice"},
+		},
+	}
+	multiple := entity.Question{
+		Type: entity.QuestionTypeMultipleChoice,
+		Options: []entity.ChoiceOption{
+			{Text: "int", Correct: true},
+			{Text: "string", Correct: true},
+			{Text: "[]byte"},
+			{Text: "bool", Correct: true},
+			{Text: "func()"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		question    entity.Question
+		selected    []int
+		wantContent string
+		wantScore   int
+		wantMissing []string
+		wantErr     error
+	}{
+		{name: "single correct", question: single, selected: []int{1}, wantContent: "map", wantScore: 100},
+		{name: "single wrong", question: single, selected: []int{0}, wantContent: "chan", wantScore: 0, wantMissing: []string{"map"}},
+		{name: "single takes one option", question: single, selected: []int{0, 1}, wantErr: inerrors.ErrInvalidInput},
+		{name: "nothing selected", question: single, selected: nil, wantErr: inerrors.ErrInvalidInput},
+		{name: "out of range", question: single, selected: []int{3}, wantErr: inerrors.ErrInvalidInput},
+		{name: "negative option", question: single, selected: []int{-1}, wantErr: inerrors.ErrInvalidInput},
+		{name: "multiple all correct", question: multiple, selected: []int{3, 0, 1}, wantContent: "bool; int; string", wantScore: 100},
+		{name: "multiple partly", question: multiple, selected: []int{0, 1}, wantContent: "int; string", wantScore: 67, wantMissing: []string{"bool"}},
+		{name: "wrong cancels correct", question: multiple, selected: []int{0, 1, 2}, wantContent: "int; string; []byte", wantScore: 33, wantMissing: []string{"bool"}},
+		{name: "not below zero", question: multiple, selected: []int{2, 4, 0}, wantContent: "[]byte; func(); int", wantScore: 0, wantMissing: []string{"string", "bool"}},
+		{name: "selected twice", question: multiple, selected: []int{0, 0}, wantErr: inerrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, res, err := scoreChoice(tt.question, tt.selected)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("scoreChoice() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if content != tt.wantContent {
+				t.Errorf("content = %q, want %q", content, tt.wantContent)
+			}
+			if res.Score != tt.wantScore {
+				t.Errorf("score = %d, want %d", res.Score, tt.wantScore)
+			}
+			if !slices.Equal(res.MissingPoints, tt.wantMissing) {
+				t.Errorf("missing points = %q, want %q", res.MissingPoints, tt.wantMissing)
+			}
+			if res.Explanation == "" {
+				t.Error("explanation is empty")
+			}
+		})
+	}
+}
+
+func TestScoreNumeric(t *testing.T) {
+	answer := 3.14
+	question := entity.Question{Type: entity.QuestionTypeNumeric, NumericAnswer: &answer, NumericTolerance: 0.01}
+
+	tests := []struct {
+		name      string
+		question  entity.Question
+		answer    string
+		wantScore int
+		wantErr   error
+	}{
+		{name: "exact", question: question, answer: "3.14", wantScore: 100},
+		{name: "comma separator", question: question, answer: " 3,145 ", wantScore: 100},
+		{name: "on the tolerance bound", question: question, answer: "3.15", wantScore: 100},
+		{name: "outside the tolerance", question: question, answer: "3.2", wantScore: 0},
+		{name: "exact without tolerance", question: entity.Question{NumericAnswer: &answer}, answer: "3.14", wantScore: 100},
+		{name: "not a number", question: question, answer: "пи", wantErr: inerrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := scoreNumeric(tt.question, tt.answer)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("scoreNumeric() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && res.Score != tt.wantScore {
+				t.Errorf("score = %d, want %d", res.Score, tt.wantScore)
+			}
+		})
+	}
+
+	_, err := scoreNumeric(entity.Question{Type: entity.QuestionTypeNumeric}, "1")
+	if err == nil || errors.Is(err, inerrors.ErrInvalidInput) {
+		t.Errorf("scoreNumeric() of a question without answer error = %v, want an internal error", err)
+	}
+}
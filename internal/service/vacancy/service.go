@@ -2,53 +2,248 @@ package vacancy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"hr-helper/internal/dto_models"
 	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/pkg/houston/dobby"
 	"hr-helper/internal/service_models"
 )
 
 const (
+	// minInterviewScore is the threshold of the round made of a flat question list.
 	minInterviewScore = 75
 )
 
+const (
+	importFormatText = "text"
+	importFormatHTML = "html"
+	importFormatHH   = "hh"
+)
+
 type Storage interface {
 	CreateVacancy(ctx context.Context, vacancy dto_models.CreateVacancyRequest) (uuid.UUID, error)
 	ArchiveVacancy(ctx context.Context, candidateID int64, vacancyID uuid.UUID, isArchived bool) error
 	CreateAnswer(ctx context.Context, answer service_models.ScoredAnswer) (int64, error)
+	GetByID(ctx context.Context, id uuid.UUID) (entity.Vacancy, error)
 	GetQuestionByID(ctx context.Context, id int64) (entity.Question, error)
-	GetQuestionsByVacancyID(ctx context.Context, vacancyID uuid.UUID) ([]entity.Question, error)
-	GetAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.Answer, error)
+	GetQuestionsByRound(ctx context.Context, vacancyID uuid.UUID, round int, candidateID int64) ([]entity.Question, error)
+	CopyBankQuestions(ctx context.Context, vacancyID uuid.UUID, round int, candidateID *int64, bankQuestionIDs []int64) error
+	MarkQuestionsDrawn(ctx context.Context, candidateID int64, vacancyID uuid.UUID, round int) (bool, error)
+	GetAnswers(ctx context.Context, candidateID int64, vacancyID uuid.UUID, round int) ([]entity.Answer, error)
+	GetRounds(ctx context.Context, vacancyID uuid.UUID) ([]entity.InterviewRound, error)
+	CreateRoundResult(ctx context.Context, result entity.RoundResult) error
+	GetRoundResults(ctx context.Context, candidateID int64, vacancyID uuid.UUID) ([]entity.RoundResult, error)
 	GetVacanciesWithQuestions(ctx context.Context) ([]entity.VacancyWithQuestion, error)
 	GetVacancyWithQuestions(ctx context.Context, vacancyID uuid.UUID) (entity.VacancyWithQuestion, error)
 	UpdateInterviewResult(ctx context.Context, candidateID int64, vacancyID uuid.UUID, interviewResult service_models.InterviewResult) error
 	DeleteVacancy(ctx context.Context, vacancyID uuid.UUID) error
 }
 
+type MetaStorage interface {
+	GetMeta(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.Meta, error)
+}
+
 type LLMClient interface {
-	ScoreAnswer(ctx context.Context, answer string, reference string) (service_models.AnswerScoringResult, error)
+	ScoreAnswer(ctx context.Context, answer string, question entity.Question, vacancy entity.Vacancy) (service_models.AnswerScoringResult, error)
+	ExtractVacancy(ctx context.Context, description string) (service_models.VacancyDraft, error)
+}
+
+type VoiceStorage interface {
+	Upload(ctx context.Context, candidateID int64, vacancyID uuid.UUID, questionID int64, data []byte, contentType string) error
+}
+
+type SpeechRecognizer interface {
+	Transcribe(ctx context.Context, audio []byte, contentType string) (string, error)
+}
+
+type TextExtractor interface {
+	ExtractText(ctx context.Context, data []byte, contentType string) (string, error)
 }
 
 type Service struct {
-	store     Storage
-	llmClient LLMClient
+	store         Storage
+	textExtractor TextExtractor
+	llmClient     LLMClient
+	metaStore     MetaStorage
+	publisher     EventPublisher
+	transactor    Transactor
+	voiceStorage  VoiceStorage
+	recognizer    SpeechRecognizer
+	codeRunner    CodeRunner
+	bankStore     QuestionBankStorage
 }
 
-func NewService(store Storage, llmClient LLMClient) *Service {
+func NewService(
+	store Storage,
+	textExtractor TextExtractor,
+	llmClient LLMClient,
+	metaStore MetaStorage,
+	publisher EventPublisher,
+	transactor Transactor,
+	voiceStorage VoiceStorage,
+	recognizer SpeechRecognizer,
+	codeRunner CodeRunner,
+	bankStore QuestionBankStorage,
+) *Service {
 	return &Service{
-		store:     store,
-		llmClient: llmClient,
+		store:         store,
+		textExtractor: textExtractor,
+		llmClient:     llmClient,
+		metaStore:     metaStore,
+		publisher:     publisher,
+		transactor:    transactor,
+		voiceStorage:  voiceStorage,
+		recognizer:    recognizer,
+		codeRunner:    codeRunner,
+		bankStore:     bankStore,
 	}
 }
 
 func (s *Service) CreateVacancy(ctx context.Context, vacancy dto_models.CreateVacancyRequest) (uuid.UUID, error) {
+	vacancy, err := normalizeRounds(vacancy)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	for r, round := range vacancy.Rounds {
+		for i := range round.Questions {
+			err = validateQuestion(&vacancy.Rounds[r].Questions[i])
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("round %d question %d: %w", r+1, i+1, err)
+			}
+		}
+
+		for i, draw := range round.BankDraws {
+			if draw.Count <= 0 {
+				return uuid.Nil, fmt.Errorf("%w: round %d bank draw %d: count must be positive", inerrors.ErrInvalidInput, r+1, i+1)
+			}
+			vacancy.Rounds[r].BankDraws[i].Tag, err = normalizeTag(draw.Tag)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("round %d bank draw %d: %w", r+1, i+1, err)
+			}
+		}
+	}
+
 	return s.store.CreateVacancy(ctx, vacancy)
 }
 
+// normalizeRounds turns the flat question list into a single round with the default threshold.
+func normalizeRounds(vacancy dto_models.CreateVacancyRequest) (dto_models.CreateVacancyRequest, error) {
+	if len(vacancy.Questions) > 0 && len(vacancy.Rounds) > 0 {
+		return dto_models.CreateVacancyRequest{}, fmt.Errorf("%w: use either questions or rounds", inerrors.ErrInvalidInput)
+	}
+
+	if len(vacancy.Rounds) == 0 {
+		vacancy.Rounds = []dto_models.CreateRoundRequest{{
+			Threshold: minInterviewScore,
+			Questions: vacancy.Questions,
+		}}
+		vacancy.Questions = nil
+
+		return vacancy, nil
+	}
+
+	for i, round := range vacancy.Rounds {
+		if round.Threshold < 0 || round.Threshold > 100 {
+			return dto_models.CreateVacancyRequest{}, fmt.Errorf("%w: round %d: threshold must be in [0, 100]", inerrors.ErrInvalidInput, i+1)
+		}
+		if len(round.Questions) == 0 && len(round.BankDraws) == 0 {
+			return dto_models.CreateVacancyRequest{}, fmt.Errorf("%w: round %d has no questions", inerrors.ErrInvalidInput, i+1)
+		}
+	}
+
+	return vacancy, nil
+}
+
+func (s *Service) ImportVacancy(ctx context.Context, req dto_models.ImportVacancyRequest) (service_models.VacancyDraft, error) {
+	switch req.Format {
+	case importFormatText:
+		return s.extractVacancyDraft(ctx, req.Content)
+	case importFormatHTML:
+		text, err := s.textExtractor.ExtractText(ctx, []byte(req.Content), "text/html")
+		if err != nil {
+			return service_models.VacancyDraft{}, fmt.Errorf("can't extract text from html: %w", err)
+		}
+
+		return s.extractVacancyDraft(ctx, text)
+	case importFormatHH:
+		if req.HHVacancy == nil {
+			return service_models.VacancyDraft{}, fmt.Errorf("%w: hh_vacancy is required", inerrors.ErrInvalidInput)
+		}
+
+		return s.importHHVacancy(ctx, *req.HHVacancy)
+	default:
+		return service_models.VacancyDraft{}, fmt.Errorf("%w: unknown format %q", inerrors.ErrInvalidInput, req.Format)
+	}
+}
+
+func (s *Service) ImportVacancyDocument(ctx context.Context, document []byte, contentType string) (service_models.VacancyDraft, error) {
+	text, err := s.textExtractor.ExtractText(ctx, document, contentType)
+	if err != nil {
+		return service_models.VacancyDraft{}, fmt.Errorf("can't extract text from document: %w", err)
+	}
+
+	return s.extractVacancyDraft(ctx, text)
+}
+
+func (s *Service) importHHVacancy(ctx context.Context, hhVacancy dto_models.HHVacancy) (service_models.VacancyDraft, error) {
+	draft := service_models.VacancyDraft{
+		Title:           strings.TrimSpace(hhVacancy.Name),
+		KeyRequirements: make([]string, 0, len(hhVacancy.KeySkills)),
+	}
+	for _, skill := range hhVacancy.KeySkills {
+		if name := strings.TrimSpace(skill.Name); name != "" {
+			draft.KeyRequirements = append(draft.KeyRequirements, name)
+		}
+	}
+
+	if len(draft.KeyRequirements) > 0 && draft.Title != "" {
+		return draft, nil
+	}
+
+	// key_skills are optional in hh.ru vacancies, so the rest is taken from the description
+	text, err := s.textExtractor.ExtractText(ctx, []byte(hhVacancy.Description), "text/html")
+	if err != nil {
+		return service_models.VacancyDraft{}, fmt.Errorf("can't extract text from description: %w", err)
+	}
+
+	extracted, err := s.extractVacancyDraft(ctx, text)
+	if err != nil {
+		return service_models.VacancyDraft{}, err
+	}
+
+	if draft.Title == "" {
+		draft.Title = extracted.Title
+	}
+	if len(draft.KeyRequirements) == 0 {
+		draft.KeyRequirements = extracted.KeyRequirements
+	}
+
+	return draft, nil
+}
+
+func (s *Service) extractVacancyDraft(ctx context.Context, description string) (service_models.VacancyDraft, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return service_models.VacancyDraft{}, fmt.Errorf("%w: empty vacancy description", inerrors.ErrInvalidInput)
+	}
+
+	draft, err := s.llmClient.ExtractVacancy(ctx, description)
+	if err != nil {
+		return service_models.VacancyDraft{}, fmt.Errorf("can't extract vacancy via llm: %w", err)
+	}
+
+	return draft, nil
+}
+
 func (s *Service) ArchiveVacancy(ctx context.Context, candidateID int64, vacancyID uuid.UUID) error {
 	return s.store.ArchiveVacancy(ctx, candidateID, vacancyID, true)
 }
@@ -59,17 +254,85 @@ func (s *Service) CreateAnswer(ctx context.Context, req dto_models.CreateAnswerR
 		return 0, fmt.Errorf("can't get question: %w", err)
 	}
 
-	scoringResult, err := s.llmClient.ScoreAnswer(ctx, req.Content, question.Reference)
+	err = s.checkRoundOpen(ctx, req.CandidateID, question)
 	if err != nil {
-		return 0, fmt.Errorf("can't score answer via llm: %w", err)
+		return 0, err
 	}
 
-	id, err := s.store.CreateAnswer(ctx, service_models.ScoredAnswer{
+	return s.scoreAnswer(ctx, question, req, false)
+}
+
+// CreateVoiceAnswer stores the voice file, transcribes it and scores the transcript like a text answer.
+func (s *Service) CreateVoiceAnswer(ctx context.Context, req dto_models.CreateVoiceAnswerRequest, audio []byte, contentType string) (int64, string, error) {
+	question, err := s.store.GetQuestionByID(ctx, req.QuestionID)
+	if err != nil {
+		return 0, "", fmt.Errorf("can't get question: %w", err)
+	}
+
+	if question.Type != entity.QuestionTypeText {
+		return 0, "", fmt.Errorf("%w: voice answers are accepted for text questions only", inerrors.ErrInvalidInput)
+	}
+
+	err = s.checkRoundOpen(ctx, req.CandidateID, question)
+	if err != nil {
+		return 0, "", err
+	}
+
+	err = s.voiceStorage.Upload(ctx, req.CandidateID, question.VacancyID, question.ID, audio, contentType)
+	if err != nil {
+		return 0, "", fmt.Errorf("can't upload voice answer: %w", err)
+	}
+
+	transcript, err := s.recognizer.Transcribe(ctx, audio, contentType)
+	if err != nil {
+		return 0, "", fmt.Errorf("can't transcribe voice answer: %w", err)
+	}
+	transcript = strings.TrimSpace(transcript)
+	if transcript == "" {
+		return 0, "", fmt.Errorf("%w: no speech recognized", inerrors.ErrInvalidInput)
+	}
+
+	id, err := s.scoreAnswer(ctx, question, dto_models.CreateAnswerRequest{
 		CandidateID: req.CandidateID,
 		QuestionID:  req.QuestionID,
-		Content:     req.Content,
+		Content:     transcript,
 		TimeTaken:   req.TimeTaken,
-		Score:       scoringResult.Score,
+	}, true)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return id, transcript, nil
+}
+
+// scoreAnswer scores the answer by the question type and saves it. For choice questions the content
+// is replaced with the selected options.
+func (s *Service) scoreAnswer(ctx context.Context, question entity.Question, req dto_models.CreateAnswerRequest, voice bool) (int64, error) {
+	var scoringResult service_models.AnswerScoringResult
+	var err error
+	switch question.Type {
+	case entity.QuestionTypeSingleChoice, entity.QuestionTypeMultipleChoice:
+		req.Content, scoringResult, err = scoreChoice(question, req.SelectedOptions)
+	case entity.QuestionTypeNumeric:
+		scoringResult, err = scoreNumeric(question, req.Content)
+	case entity.QuestionTypeCode:
+		scoringResult, err = s.scoreCode(ctx, question, req.Content)
+	default:
+		scoringResult, err = s.scoreByLLM(ctx, question, req.Content)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.store.CreateAnswer(ctx, service_models.ScoredAnswer{
+		CandidateID:   req.CandidateID,
+		QuestionID:    req.QuestionID,
+		Content:       req.Content,
+		TimeTaken:     req.TimeTaken,
+		Score:         scoringResult.Score,
+		Explanation:   scoringResult.Explanation,
+		MissingPoints: nonNil(scoringResult.MissingPoints),
+		Voice:         voice,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("can't create answer in db: %w", err)
@@ -78,8 +341,32 @@ func (s *Service) CreateAnswer(ctx context.Context, req dto_models.CreateAnswerR
 	return id, nil
 }
 
+func (s *Service) scoreByLLM(ctx context.Context, question entity.Question, answer string) (service_models.AnswerScoringResult, error) {
+	vacancy, err := s.store.GetByID(ctx, question.VacancyID)
+	if err != nil {
+		return service_models.AnswerScoringResult{}, fmt.Errorf("can't get vacancy: %w", err)
+	}
+
+	scoringResult, err := s.llmClient.ScoreAnswer(ctx, answer, question, vacancy)
+	if err != nil {
+		return service_models.AnswerScoringResult{}, fmt.Errorf("can't score answer via llm: %w", err)
+	}
+	if len(question.Rubric) > 0 {
+		scoringResult.Score, err = rubricScore(question.Rubric, scoringResult.RubricCoverage)
+		if err != nil {
+			return service_models.AnswerScoringResult{}, fmt.Errorf("can't score answer by rubric: %w", err)
+		}
+	} else {
+		// the model doesn't always keep to the scale
+		scoringResult.Score = min(max(scoringResult.Score, 0), 100)
+	}
+
+	return scoringResult, nil
+}
+
+// GetQuestionsByVacancyID returns the questions of the first interview round asked to everyone.
 func (s *Service) GetQuestionsByVacancyID(ctx context.Context, vacancyID uuid.UUID) ([]entity.Question, error) {
-	questions, err := s.store.GetQuestionsByVacancyID(ctx, vacancyID)
+	questions, err := s.store.GetQuestionsByRound(ctx, vacancyID, 1, 0)
 	if err != nil {
 		return nil, fmt.Errorf("can't get questions: %w", err)
 	}
@@ -87,6 +374,91 @@ func (s *Service) GetQuestionsByVacancyID(ctx context.Context, vacancyID uuid.UU
 	return questions, nil
 }
 
+// GetRoundQuestions returns the round the candidate is on with its questions, including the bank questions
+// drawn for the candidate. ErrConflict means the candidate has no round left to answer.
+func (s *Service) GetRoundQuestions(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.InterviewRound, []entity.Question, error) {
+	meta, err := s.getMeta(ctx, candidateID, vacancyID)
+	if err != nil {
+		return entity.InterviewRound{}, nil, err
+	}
+
+	err = s.checkRoundNotScored(ctx, candidateID, vacancyID, meta.CurrentRound)
+	if err != nil {
+		return entity.InterviewRound{}, nil, err
+	}
+
+	rounds, err := s.store.GetRounds(ctx, vacancyID)
+	if err != nil {
+		return entity.InterviewRound{}, nil, fmt.Errorf("can't get rounds: %w", err)
+	}
+	i := slices.IndexFunc(rounds, func(round entity.InterviewRound) bool {
+		return round.Number == meta.CurrentRound
+	})
+	if i < 0 {
+		return entity.InterviewRound{}, nil, fmt.Errorf("%w: round %d of vacancy %s", inerrors.ErrNotFound, meta.CurrentRound, vacancyID)
+	}
+
+	if len(rounds[i].BankDraws) > 0 {
+		err = s.drawBankQuestions(ctx, candidateID, rounds[i])
+		if err != nil {
+			return entity.InterviewRound{}, nil, fmt.Errorf("can't draw bank questions: %w", err)
+		}
+	}
+
+	questions, err := s.store.GetQuestionsByRound(ctx, vacancyID, meta.CurrentRound, candidateID)
+	if err != nil {
+		return entity.InterviewRound{}, nil, fmt.Errorf("can't get questions: %w", err)
+	}
+
+	return rounds[i], questions, nil
+}
+
+// checkRoundOpen makes sure the question belongs to the round the candidate is on and the round isn't scored yet.
+func (s *Service) checkRoundOpen(ctx context.Context, candidateID int64, question entity.Question) error {
+	if question.CandidateID != nil && *question.CandidateID != candidateID {
+		return fmt.Errorf("%w: question is drawn for another candidate", inerrors.ErrConflict)
+	}
+
+	meta, err := s.getMeta(ctx, candidateID, question.VacancyID)
+	if err != nil {
+		return err
+	}
+
+	if question.Round != meta.CurrentRound {
+		return fmt.Errorf("%w: question is from round %d, candidate is on round %d", inerrors.ErrConflict, question.Round, meta.CurrentRound)
+	}
+
+	return s.checkRoundNotScored(ctx, candidateID, question.VacancyID, meta.CurrentRound)
+}
+
+func (s *Service) checkRoundNotScored(ctx context.Context, candidateID int64, vacancyID uuid.UUID, round int) error {
+	results, err := s.store.GetRoundResults(ctx, candidateID, vacancyID)
+	if err != nil {
+		return fmt.Errorf("can't get round results: %w", err)
+	}
+
+	for _, result := range results {
+		if result.Round == round {
+			return fmt.Errorf("%w: round %d is already completed", inerrors.ErrConflict, round)
+		}
+	}
+
+	return nil
+}
+
+// getMeta treats a missing meta as the first round of the interview.
+func (s *Service) getMeta(ctx context.Context, candidateID int64, vacancyID uuid.UUID) (entity.Meta, error) {
+	meta, err := s.metaStore.GetMeta(ctx, candidateID, vacancyID)
+	if errors.Is(err, inerrors.ErrNotFound) {
+		return entity.Meta{CandidateID: candidateID, VacancyID: vacancyID, CurrentRound: 1}, nil
+	}
+	if err != nil {
+		return entity.Meta{}, fmt.Errorf("can't get meta: %w", err)
+	}
+
+	return meta, nil
+}
+
 func (s *Service) DeleteVacancy(ctx context.Context, vacancyID uuid.UUID) error {
 	err := s.store.DeleteVacancy(ctx, vacancyID)
 	if err != nil {
@@ -114,37 +486,171 @@ func (s *Service) GetVacancyWithQuestionsByID(ctx context.Context, vacancyID uui
 	return vacancy, nil
 }
 
-func (s *Service) ScoreCandidateInterview(ctx context.Context, req dto_models.ProcessInterviewRequest) error {
-	answers, err := s.store.GetAnswers(ctx, req.CandidateID, req.VacancyID)
+// ScoreCandidateInterview scores the round the candidate is on. Passing a round opens the next one,
+// the interview completes when a round is failed or the last one is passed.
+func (s *Service) ScoreCandidateInterview(ctx context.Context, req dto_models.ProcessInterviewRequest) (service_models.RoundScoringResult, error) {
+	meta, err := s.getMeta(ctx, req.CandidateID, req.VacancyID)
 	if err != nil {
-		return fmt.Errorf("can't get answers: %w", err)
+		return service_models.RoundScoringResult{}, err
 	}
+	oldStatus := meta.Status
 
-	res := s.checkInterviewScore(answers)
+	rounds, err := s.store.GetRounds(ctx, req.VacancyID)
+	if err != nil {
+		return service_models.RoundScoringResult{}, fmt.Errorf("can't get rounds: %w", err)
+	}
+	i := slices.IndexFunc(rounds, func(round entity.InterviewRound) bool {
+		return round.Number == meta.CurrentRound
+	})
+	if i < 0 {
+		return service_models.RoundScoringResult{}, fmt.Errorf("%w: round %d of vacancy %s", inerrors.ErrNotFound, meta.CurrentRound, req.VacancyID)
+	}
+	round := rounds[i]
+
+	answers, err := s.store.GetAnswers(ctx, req.CandidateID, req.VacancyID, round.Number)
+	if err != nil {
+		return service_models.RoundScoringResult{}, fmt.Errorf("can't get answers: %w", err)
+	}
+	questions, err := s.store.GetQuestionsByRound(ctx, req.VacancyID, round.Number, req.CandidateID)
+	if err != nil {
+		return service_models.RoundScoringResult{}, fmt.Errorf("can't get questions: %w", err)
+	}
+	if len(answers) == 0 {
+		return service_models.RoundScoringResult{}, fmt.Errorf("%w: no answers to round %d", inerrors.ErrInvalidInput, round.Number)
+	}
+	unanswered := unansweredQuestions(questions, answers)
+	if len(unanswered) > 0 {
+		return service_models.RoundScoringResult{}, fmt.Errorf("%w: questions %v of round %d are not answered", inerrors.ErrInvalidInput, unanswered, round.Number)
+	}
 
-	err = s.store.UpdateInterviewResult(ctx, req.CandidateID, req.VacancyID, res)
+	results, err := s.store.GetRoundResults(ctx, req.CandidateID, req.VacancyID)
 	if err != nil {
-		return fmt.Errorf("can't update scoring results: %w", err)
+		return service_models.RoundScoringResult{}, fmt.Errorf("can't get round results: %w", err)
 	}
 
-	return nil
+	res := service_models.RoundScoringResult{
+		Round: round.Number,
+		Score: meanAnswerScore(answers),
+	}
+	res.Passed = res.Score >= round.Threshold
+
+	roundScores := float64(res.Score)
+	for _, result := range results {
+		roundScores += float64(result.Score)
+	}
+	interviewScore := int(math.Round(roundScores / float64(len(results)+1)))
+	interviewResult := service_models.InterviewResult{
+		CurrentRound: round.Number,
+	}
+
+	switch {
+	case !res.Passed:
+		interviewResult.Status = entity.CandidateVacancyStatusInterviewFailed
+		interviewResult.Score = &interviewScore
+	case i == len(rounds)-1:
+		interviewResult.Status = entity.CandidateVacancyStatusInterviewOk
+		interviewResult.Score = &interviewScore
+	default:
+		nextRound := rounds[i+1].Number
+		res.NextRound = &nextRound
+		interviewResult.CurrentRound = nextRound
+		interviewResult.Status = oldStatus
+	}
+	res.Status = interviewResult.Status
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.store.CreateRoundResult(ctx, entity.RoundResult{
+			CandidateID: req.CandidateID,
+			VacancyID:   req.VacancyID,
+			Round:       res.Round,
+			Score:       res.Score,
+			Passed:      res.Passed,
+		})
+		if err != nil {
+			return fmt.Errorf("can't save round result: %w", err)
+		}
+
+		err = s.store.UpdateInterviewResult(ctx, req.CandidateID, req.VacancyID, interviewResult)
+		if err != nil {
+			return fmt.Errorf("can't update scoring results: %w", err)
+		}
+
+		if res.NextRound == nil {
+			err = s.publish(ctx, entity.EventTypeInterviewCompleted, entity.InterviewCompletedEvent{
+				CandidateID: req.CandidateID,
+				VacancyID:   req.VacancyID,
+				Score:       interviewScore,
+				Status:      string(interviewResult.Status),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if oldStatus == interviewResult.Status {
+			return nil
+		}
+
+		return s.publish(ctx, entity.EventTypeStatusChanged, entity.StatusChangedEvent{
+			CandidateID: req.CandidateID,
+			VacancyID:   req.VacancyID,
+			OldStatus:   string(oldStatus),
+			NewStatus:   string(interviewResult.Status),
+		})
+	}, dobby.TxOptions{IsoLevel: dobby.ReadCommitted})
+	if err != nil {
+		return service_models.RoundScoringResult{}, err
+	}
+
+	return res, nil
+}
+
+// unansweredQuestions returns the ids of the round questions, drawn ones included, the candidate has not answered.
+func unansweredQuestions(questions []entity.Question, answers []entity.Answer) []int64 {
+	var unanswered []int64
+	for _, question := range questions {
+		answered := slices.ContainsFunc(answers, func(answer entity.Answer) bool {
+			return answer.QuestionID == question.ID
+		})
+		if !answered {
+			unanswered = append(unanswered, question.ID)
+		}
+	}
+
+	return unanswered
 }
 
-func (s *Service) checkInterviewScore(answers []entity.Answer) service_models.InterviewResult {
+func meanAnswerScore(answers []entity.Answer) int {
 	scoreSum := 0.0
 	for _, answer := range answers {
 		scoreSum += float64(answer.Score)
 	}
 
-	avgScore := int(math.Round(scoreSum / float64(len(answers))))
-	res := service_models.InterviewResult{
-		Score: avgScore,
+	return int(math.Round(scoreSum / float64(len(answers))))
+}
+
+// rubricScore is the weighted share of covered rubric points on the 0-100 scale.
+func rubricScore(rubric []entity.RubricPoint, coverage []float64) (int, error) {
+	if len(coverage) != len(rubric) {
+		return 0, fmt.Errorf("got coverage of %d rubric points, want %d", len(coverage), len(rubric))
 	}
-	if avgScore >= minInterviewScore {
-		res.Status = entity.CandidateVacancyStatusInterviewOk
-	} else {
-		res.Status = entity.CandidateVacancyStatusInterviewFailed
+
+	var covered, total float64
+	for i, point := range rubric {
+		covered += point.Weight * min(max(coverage[i], 0), 1)
+		total += point.Weight
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	return int(math.Round(100 * covered / total)), nil
+}
+
+func nonNil(items []string) []string {
+	if items == nil {
+		return []string{}
 	}
 
-	return res
+	return items
 }
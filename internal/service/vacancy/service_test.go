@@ -0,0 +1,140 @@
+package vacancy
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+	"hr-helper/internal/service_models"
+)
+
+func TestUnansweredQuestions(t *testing.T) {
+	questions := []entity.Question{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name    string
+		answers []entity.Answer
+		want    []int64
+	}{
+		{
+			name:    "all answered",
+			answers: []entity.Answer{{QuestionID: 3}, {QuestionID: 1}, {QuestionID: 2}},
+		},
+		{
+			name:    "drawn question skipped",
+			answers: []entity.Answer{{QuestionID: 1}, {QuestionID: 2}},
+			want:    []int64{3},
+		},
+		{
+			name:    "answer to another question",
+			answers: []entity.Answer{{QuestionID: 1}, {QuestionID: 4}},
+			want:    []int64{2, 3},
+		},
+		{
+			name: "nothing answered",
+			want: []int64{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unansweredQuestions(questions, tt.answers)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unansweredQuestions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRubricScore(t *testing.T) {
+	rubric := []entity.RubricPoint{
+		{Point: "buffered and unbuffered channels", Weight: 2},
+		{Point: "closing a channel", Weight: 1},
+		{Point: "select", Weight: 1},
+	}
+
+	tests := []struct {
+		name     string
+		rubric   []entity.RubricPoint
+		coverage []float64
+		want     int
+		wantErr  bool
+	}{
+		{name: "fully covered", rubric: rubric, coverage: []float64{1, 1, 1}, want: 100},
+		{name: "nothing covered", rubric: rubric, coverage: []float64{0, 0, 0}, want: 0},
+		{name: "weighted", rubric: rubric, coverage: []float64{1, 0, 0}, want: 50},
+		{name: "partial coverage", rubric: rubric, coverage: []float64{0.5, 1, 0}, want: 50},
+		{name: "rounded", rubric: rubric, coverage: []float64{0, 0, 1.0 / 3}, want: 8},
+		{name: "coverage out of range", rubric: rubric, coverage: []float64{1.5, -1, 1}, want: 75},
+		{name: "zero weights", rubric: []entity.RubricPoint{{Point: "a"}}, coverage: []float64{1}, want: 0},
+		{name: "missing coverage", rubric: rubric, coverage: []float64{1, 1}, wantErr: true},
+		{name: "extra coverage", rubric: rubric, coverage: []float64{1, 1, 1, 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rubricScore(tt.rubric, tt.coverage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rubricScore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rubricScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+type scoringStorageStub struct {
+	Storage
+}
+
+func (scoringStorageStub) GetByID(_ context.Context, id uuid.UUID) (entity.Vacancy, error) {
+	return entity.Vacancy{ID: id}, nil
+}
+
+type llmStub struct {
+	LLMClient
+	result service_models.AnswerScoringResult
+}
+
+func (l llmStub) ScoreAnswer(context.Context, string, entity.Question, entity.Vacancy) (service_models.AnswerScoringResult, error) {
+	return l.result, nil
+}
+
+func TestServiceScoreByLLM(t *testing.T) {
+	rubric := []entity.RubricPoint{{Point: "a", Weight: 1}, {Point: "b", Weight: 1}}
+
+	tests := []struct {
+		name     string
+		question entity.Question
+		result   service_models.AnswerScoringResult
+		want     int
+	}{
+		{name: "score on the scale", result: service_models.AnswerScoringResult{Score: 73}, want: 73},
+		{name: "score above the scale", result: service_models.AnswerScoringResult{Score: 120}, want: 100},
+		{name: "negative score", result: service_models.AnswerScoringResult{Score: -5}, want: 0},
+		{
+			name:     "rubric overrides the model score",
+			question: entity.Question{Rubric: rubric},
+			result:   service_models.AnswerScoringResult{Score: 150, RubricCoverage: []float64{1, 0}},
+			want:     50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(scoringStorageStub{}, nil, llmStub{result: tt.result}, nil, nil, nil, nil, nil, nil, nil)
+
+			got, err := s.scoreByLLM(context.Background(), tt.question, "ответ")
+			if err != nil {
+				t.Fatalf("scoreByLLM() error = %v", err)
+			}
+			if got.Score != tt.want {
+				t.Errorf("scoreByLLM() score = %d, want %d", got.Score, tt.want)
+			}
+		})
+	}
+}
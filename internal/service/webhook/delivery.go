@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hr-helper/internal/pkg/houston/loggy"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	deliveryTimeout = 10 * time.Second
+	// deliveryLease must exceed deliveryTimeout, otherwise another worker may send the same delivery concurrently
+	deliveryLease = time.Minute
+
+	maxErrorBodySize = 1 << 10
+)
+
+const (
+	headerEvent     = "X-Webhook-Event"
+	headerDelivery  = "X-Webhook-Delivery"
+	headerSignature = "X-Webhook-Signature"
+)
+
+// Start sends due deliveries every cfg.PollInterval until the returned stop function is called.
+func (s *Service) Start(ctx context.Context) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := s.DeliverDue(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				loggy.Errorf("can't deliver webhooks: %v", err)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (s *Service) DeliverDue(ctx context.Context) error {
+	jobs, err := s.store.ClaimDueDeliveries(ctx, s.cfg.BatchSize, deliveryLease)
+	if err != nil {
+		return fmt.Errorf("can't claim deliveries: %w", err)
+	}
+
+	for _, job := range jobs {
+		statusCode, sendErr := s.send(ctx, job)
+		if sendErr == nil {
+			err = s.store.MarkDeliverySucceeded(ctx, job.ID, statusCode)
+			if err != nil {
+				return fmt.Errorf("can't mark delivery %d succeeded: %w", job.ID, err)
+			}
+			continue
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+
+		var nextAttemptAt *time.Time
+		if attempts := job.Attempts + 1; attempts < s.cfg.MaxAttempts {
+			t := time.Now().Add(s.backoff(attempts))
+			nextAttemptAt = &t
+		}
+
+		err = s.store.MarkDeliveryFailed(ctx, job.ID, code, sendErr.Error(), nextAttemptAt)
+		if err != nil {
+			return fmt.Errorf("can't mark delivery %d failed: %w", job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// backoff doubles the delay after each failed attempt up to cfg.MaxBackoff: base, 2*base, 4*base...
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.cfg.MaxBackoff)
+}
+
+func (s *Service) send(ctx context.Context, job service_models.WebhookDeliveryJob) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("can't create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerEvent, job.EventType)
+	req.Header.Set(headerDelivery, job.EventID.String())
+	req.Header.Set(headerSignature, "t="+timestamp+",v1="+Sign(job.Secret, timestamp, job.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("can't do req: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("non-2xx status: %s, body: %s", resp.Status, body)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns hex(HMAC-SHA256(secret, timestamp + "." + payload)). Receivers should compare it
+// with the v1 part of the signature header and reject stale timestamps.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+	"hr-helper/internal/service_models"
+)
+
+const (
+	secretSize          = 32
+	deliveriesPageLimit = 100
+)
+
+type Storage interface {
+	CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (int64, error)
+	GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+	CreateDeliveries(ctx context.Context, event entity.Event, payload []byte) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]service_models.WebhookDeliveryJob, error)
+	MarkDeliverySucceeded(ctx context.Context, deliveryID int64, statusCode int) error
+	MarkDeliveryFailed(ctx context.Context, deliveryID int64, statusCode *int, lastError string, nextAttemptAt *time.Time) error
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID int64) (int64, error)
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type Config struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	MaxAttempts  int           `yaml:"max_attempts"`
+	BaseBackoff  time.Duration `yaml:"base_backoff"`
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+}
+
+func (c Config) Validate() error {
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("max_attempts must be positive")
+	}
+	if c.BaseBackoff <= 0 || c.MaxBackoff < c.BaseBackoff {
+		return fmt.Errorf("base_backoff must be positive and not greater than max_backoff")
+	}
+
+	return nil
+}
+
+type Service struct {
+	cfg        Config
+	store      Storage
+	httpClient HTTPClient
+	resolver   Resolver
+}
+
+func NewService(cfg Config, store Storage, httpClient HTTPClient) *Service {
+	return &Service{
+		cfg:        cfg,
+		store:      store,
+		httpClient: httpClient,
+		resolver:   net.DefaultResolver,
+	}
+}
+
+// CreateSubscription returns the subscription with its secret; the secret is generated when not given.
+func (s *Service) CreateSubscription(ctx context.Context, req dto_models.CreateWebhookSubscriptionRequest) (entity.WebhookSubscription, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return entity.WebhookSubscription{}, fmt.Errorf("%w: invalid url %q", inerrors.ErrInvalidInput, req.URL)
+	}
+	err = s.checkTarget(ctx, u.Hostname())
+	if err != nil {
+		return entity.WebhookSubscription{}, fmt.Errorf("%w: url %q: %w", inerrors.ErrInvalidInput, req.URL, err)
+	}
+
+	if len(req.EventTypes) == 0 {
+		return entity.WebhookSubscription{}, fmt.Errorf("%w: no event types", inerrors.ErrInvalidInput)
+	}
+	for _, eventType := range req.EventTypes {
+		if !slices.Contains(entity.EventTypes, eventType) {
+			return entity.WebhookSubscription{}, fmt.Errorf("%w: unknown event type %q", inerrors.ErrInvalidInput, eventType)
+		}
+	}
+
+	subscription := entity.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		IsActive:   true,
+		CreatedAt:  time.Now(),
+	}
+	if subscription.Secret == "" {
+		subscription.Secret, err = generateSecret()
+		if err != nil {
+			return entity.WebhookSubscription{}, fmt.Errorf("can't generate secret: %w", err)
+		}
+	}
+
+	subscription.ID, err = s.store.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return entity.WebhookSubscription{}, fmt.Errorf("can't create subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *Service) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return s.store.GetSubscriptions(ctx)
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	return s.store.DeleteSubscription(ctx, subscriptionID)
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, subscriptionID int64) ([]entity.WebhookDelivery, error) {
+	return s.store.GetDeliveries(ctx, subscriptionID, deliveriesPageLimit)
+}
+
+func (s *Service) Redeliver(ctx context.Context, deliveryID int64) (int64, error) {
+	return s.store.Redeliver(ctx, deliveryID)
+}
+
+// Publish schedules deliveries of the event to all subscribers; they are sent by the worker.
+func (s *Service) Publish(ctx context.Context, event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("can't marshal event: %w", err)
+	}
+
+	err = s.store.CreateDeliveries(ctx, event, payload)
+	if err != nil {
+		return fmt.Errorf("can't create deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
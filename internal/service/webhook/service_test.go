@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"hr-helper/internal/dto_models"
+	"hr-helper/internal/entity"
+	"hr-helper/internal/inerrors"
+)
+
+type storageStub struct {
+	Storage
+	created []entity.WebhookSubscription
+}
+
+func (s *storageStub) CreateSubscription(_ context.Context, subscription entity.WebhookSubscription) (int64, error) {
+	s.created = append(s.created, subscription)
+	return int64(len(s.created)), nil
+}
+
+type resolverStub map[string][]string
+
+func (r resolverStub) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"id":1}`)
+
+	tests := []struct {
+		name   string
+		secret string
+		want   string
+	}{
+		{
+			name:   "secret",
+			secret: "secret",
+			want:   "3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		},
+		{
+			name:   "other secret",
+			secret: "other",
+			want:   "e0cb77fc6d5b2877ec062213c262d236b5dd5a833d29fdc5a058c5fbfa287b47",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sign(tt.secret, "1700000000", payload)
+			if got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if Sign("secret", "1700000001", payload) == tests[0].want {
+		t.Error("Sign() doesn't depend on the timestamp")
+	}
+}
+
+func TestServiceBackoff(t *testing.T) {
+	s := &Service{cfg: Config{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 5, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		got := s.backoff(tt.attempts)
+		if got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestServiceCreateSubscription(t *testing.T) {
+	resolver := resolverStub{
+		"hooks.example.com":  {"93.184.216.34"},
+		"intranet.local":     {"10.0.0.5"},
+		"mixed.example.com":  {"93.184.216.34", "127.0.0.1"},
+		"metadata.cloud.com": {"169.254.169.254"},
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "public host", url: "https://hooks.example.com/hr"},
+		{name: "public ip", url: "http://93.184.216.34:8080/hr"},
+		{name: "loopback ip", url: "http://127.0.0.1:8086/api", wantErr: true},
+		{name: "ipv6 loopback", url: "http://[::1]/api", wantErr: true},
+		{name: "unspecified ip", url: "http://0.0.0.0/api", wantErr: true},
+		{name: "private ip", url: "http://192.168.1.10/api", wantErr: true},
+		{name: "host resolving to private ip", url: "https://intranet.local/hook", wantErr: true},
+		{name: "host resolving to loopback among others", url: "https://mixed.example.com/hook", wantErr: true},
+		{name: "link-local metadata", url: "http://metadata.cloud.com/latest", wantErr: true},
+		{name: "unresolvable host", url: "https://unknown.example.com/hook", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://hooks.example.com/hr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storageStub{}
+			s := NewService(Config{}, store, nil)
+			s.resolver = resolver
+
+			_, err := s.CreateSubscription(context.Background(), dto_models.CreateWebhookSubscriptionRequest{
+				URL:        tt.url,
+				EventTypes: []string{entity.EventTypeStatusChanged},
+			})
+			if tt.wantErr {
+				if !errors.Is(err, inerrors.ErrInvalidInput) {
+					t.Errorf("CreateSubscription() error = %v, want ErrInvalidInput", err)
+				}
+				if len(store.created) > 0 {
+					t.Error("subscription is created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateSubscription() error = %v", err)
+			}
+			if len(store.created) != 1 || store.created[0].Secret == "" {
+				t.Errorf("created %+v, want one subscription with a generated secret", store.created)
+			}
+		})
+	}
+}
+
+func TestCheckDialAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443"},
+		{address: "127.0.0.1:80", wantErr: true},
+		{address: "10.1.2.3:443", wantErr: true},
+		{address: "172.16.0.1:443", wantErr: true},
+		{address: "169.254.169.254:80", wantErr: true},
+		{address: "[::1]:443", wantErr: true},
+		{address: "[fd00::1]:443", wantErr: true},
+		{address: "[::ffff:127.0.0.1]:80", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := checkDialAddress("tcp", tt.address, nil)
+			if tt.wantErr != errors.Is(err, errForbiddenTarget) || !tt.wantErr && err != nil {
+				t.Errorf("checkDialAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+var errForbiddenTarget = errors.New("private, loopback and link-local addresses are not allowed")
+
+// NewHTTPClient returns a client for the deliveries which refuses to connect to internal addresses,
+// so that a subscription host resolving to one after it was checked can't reach internal services.
+func NewHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: checkDialAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would make the dialer check the proxy address instead of the target
+	transport.Proxy = nil
+
+	return &http.Client{Transport: transport}
+}
+
+func checkDialAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || forbiddenIP(ip) {
+		return fmt.Errorf("%w: %s", errForbiddenTarget, address)
+	}
+
+	return nil
+}
+
+// checkTarget rejects hosts which are or resolve to internal addresses.
+func (s *Service) checkTarget(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if forbiddenIP(ip) {
+			return errForbiddenTarget
+		}
+		return nil
+	}
+
+	addrs, err := s.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("can't resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if forbiddenIP(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", errForbiddenTarget, host, addr.IP)
+		}
+	}
+
+	return nil
+}
+
+func forbiddenIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast()
+}
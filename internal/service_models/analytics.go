@@ -0,0 +1,99 @@
+package service_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+)
+
+type AnalyticsFilter struct {
+	VacancyID uuid.UUID
+	From      time.Time
+	To        time.Time
+}
+
+// ApplicationTimeline is an application with the moments it passed pipeline stages;
+// a nil moment means the stage wasn't reached.
+type ApplicationTimeline struct {
+	CandidateID    int64
+	Status         string
+	ResumeScore    int
+	InterviewScore *int
+	ScreenedAt     time.Time
+	FirstAnswerAt  *time.Time
+	LastAnswerAt   *time.Time
+	BookedAt       *time.Time
+}
+
+type QuestionReach struct {
+	QuestionID int64
+	Position   int
+	Content    string
+	Answered   int
+}
+
+type FunnelStage struct {
+	Name  string
+	Count int
+	// ConversionFromPrevious is the share of the previous stage that reached this one; the first stage has 1.
+	ConversionFromPrevious float64
+	ConversionFromApplied  float64
+}
+
+type StageDuration struct {
+	Name          string
+	Samples       int
+	MedianSeconds *float64
+}
+
+type HistogramBucket struct {
+	From  int
+	To    int
+	Count int
+}
+
+type QuestionDropOff struct {
+	QuestionID int64
+	Position   int
+	Content    string
+	Answered   int
+	// DropOff is the number of candidates who answered the previous question but not this one.
+	DropOff     int
+	DropOffRate float64
+}
+
+type FunnelReport struct {
+	Filter                  AnalyticsFilter
+	Stages                  []FunnelStage
+	Durations               []StageDuration
+	ResumeScoreHistogram    []HistogramBucket
+	InterviewScoreHistogram []HistogramBucket
+	QuestionDropOffs        []QuestionDropOff
+}
+
+// AnswerOutcome is an answer together with the current status of the candidate's application.
+type AnswerOutcome struct {
+	Question  entity.Question
+	Score     int
+	TimeTaken int64
+	Status    string
+}
+
+type QuestionStats struct {
+	Question    entity.Question
+	Answers     int
+	MeanScore   float64
+	MedianScore float64
+	StdDevScore float64
+	// AvgTimeTaken is in seconds; AvgTimeShare is the mean of time_taken / time_limit.
+	AvgTimeTaken float64
+	AvgTimeShare *float64
+	TimeoutRate  *float64
+	// OutcomeCorrelation is the Pearson correlation between the score and passing the interview,
+	// computed over OutcomeSamples answers of candidates with a final interview result.
+	OutcomeCorrelation *float64
+	OutcomeSamples     int
+	Flags              []string
+}
@@ -0,0 +1,14 @@
+package service_models
+
+import "time"
+
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      uint64
+	Offset     uint64
+}
@@ -0,0 +1,12 @@
+package service_models
+
+import "github.com/google/uuid"
+
+// MergedFiles are files of a candidate collapsed into another one by the deduplication migration:
+// resumes of VacancyIDs are to be copied to the target, the rest deleted. TargetID is nil
+// when the target was deleted or erased since.
+type MergedFiles struct {
+	SourceID   int64       `db:"source_id"`
+	TargetID   *int64      `db:"target_id"`
+	VacancyIDs []uuid.UUID `db:"vacancy_ids"`
+}
@@ -0,0 +1,21 @@
+package service_models
+
+import (
+	"github.com/google/uuid"
+
+	"hr-helper/internal/entity"
+)
+
+type CandidateExportFilter struct {
+	VacancyID       *uuid.UUID
+	Status          string
+	IncludeArchived bool
+}
+
+// VacancyAnswerRow is an application to the vacancy joined with one of its answers, if any.
+type VacancyAnswerRow struct {
+	Candidate  entity.Candidate
+	Meta       entity.Meta
+	QuestionID *int64
+	Score      *int
+}
@@ -0,0 +1,11 @@
+package service_models
+
+import "hr-helper/internal/entity"
+
+// OutboxDelivery is a message to be published to one sink.
+type OutboxDelivery struct {
+	MessageID int64
+	Sink      string
+	Event     entity.Event
+	Attempts  int
+}
@@ -0,0 +1,11 @@
+package service_models
+
+type BankQuestionFilter struct {
+	// Tags must all be present on the question.
+	Tags []string
+	// Query is a case-insensitive substring of the question content.
+	Query  string
+	Type   string
+	Limit  uint64
+	Offset uint64
+}
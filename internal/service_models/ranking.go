@@ -0,0 +1,47 @@
+package service_models
+
+import "hr-helper/internal/entity"
+
+type RankingWeights struct {
+	Resume    float64
+	Interview float64
+	Answers   float64
+	Speed     float64
+	// QuestionWeights override the default weight 1 of questions in the answers component.
+	QuestionWeights map[int64]float64
+}
+
+type RankingFilter struct {
+	Weights         RankingWeights
+	IncludeArchived bool
+	Limit           int
+}
+
+// RankedCandidate holds components on the 0-100 scale; a nil component means the candidate hasn't reached that stage.
+type RankedCandidate struct {
+	Rank           int
+	Candidate      entity.Candidate
+	Meta           entity.Meta
+	ResumeScore    *float64
+	InterviewScore *float64
+	AnswersScore   *float64
+	SpeedScore     *float64
+	Composite      float64
+}
+
+type CandidateRanking struct {
+	Weights    RankingWeights
+	Candidates []RankedCandidate
+}
+
+type ComparedQuestion struct {
+	Question entity.Question
+	// Answers are keyed by candidate id; candidates who skipped the question are absent.
+	Answers map[int64]entity.Answer
+}
+
+type CandidateComparison struct {
+	Weights    RankingWeights
+	Candidates []RankedCandidate
+	Questions  []ComparedQuestion
+}
@@ -0,0 +1,21 @@
+package service_models
+
+import (
+	"time"
+
+	"hr-helper/internal/entity"
+)
+
+type RetentionFilter struct {
+	Status        string
+	IsArchived    *bool
+	UpdatedBefore time.Time
+	// SkipResumeDeleted excludes applications whose resume is already deleted
+	SkipResumeDeleted bool
+}
+
+type RetentionRuleReport struct {
+	Rule         string
+	Action       string
+	Applications []entity.Meta
+}
@@ -13,18 +13,60 @@ type ResumeScreeningResultWithStatus struct {
 }
 
 type AnswerScoringResult struct {
-	Score int `json:"score"`
+	Score         int      `json:"score"`
+	Explanation   string   `json:"explanation"`
+	MissingPoints []string `json:"missing_points"`
+	// RubricCoverage is set for rubric questions: how fully each rubric point is covered, from 0 to 1.
+	RubricCoverage []float64 `json:"rubric_coverage"`
 }
 
 type ScoredAnswer struct {
-	CandidateID int64
-	QuestionID  int64
-	Content     string
-	TimeTaken   int
-	Score       int
+	CandidateID   int64
+	QuestionID    int64
+	Content       string
+	TimeTaken     int
+	Score         int
+	Explanation   string
+	MissingPoints []string
+	Voice         bool
+}
+
+// CodeRunResult is the outcome of running a code answer on a single test case.
+type CodeRunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
 }
 
 type InterviewResult struct {
 	Status entity.CandidateVacancyStatus
-	Score  int
+	// Score is the mean of the completed round scores, it is nil until the interview completes.
+	Score        *int
+	CurrentRound int
+}
+
+type RoundScoringResult struct {
+	Round     int
+	Score     int
+	Passed    bool
+	NextRound *int
+	Status    entity.CandidateVacancyStatus
+}
+
+type CandidateSummaryResult struct {
+	Strengths      []string `json:"strengths"`
+	Weaknesses     []string `json:"weaknesses"`
+	RedFlags       []string `json:"red_flags"`
+	Recommendation string   `json:"recommendation"`
+	Rationale      string   `json:"rationale"`
+}
+
+type AIGeneratedJudgement struct {
+	Likelihood float64 `json:"likelihood"`
+	Reason     string  `json:"reason"`
+}
+
+type VacancyDraft struct {
+	Title           string   `json:"title"`
+	KeyRequirements []string `json:"key_requirements"`
 }
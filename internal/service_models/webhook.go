@@ -0,0 +1,18 @@
+package service_models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryJob is a delivery claimed by the worker together with its subscription's endpoint.
+type WebhookDeliveryJob struct {
+	ID        int64
+	EventID   uuid.UUID
+	EventType string
+	Payload   json.RawMessage
+	Attempts  int
+	URL       string
+	Secret    string
+}
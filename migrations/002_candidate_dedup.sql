@@ -0,0 +1,120 @@
+-- +goose Up
+
+-- 0 was stored for candidates without a Telegram id, they aren't duplicates of each other
+UPDATE candidate
+   SET telegram_id = NULL
+ WHERE telegram_id = 0;
+
+-- duplicates by telegram_id are collapsed into the oldest candidate the same way /api/v1/candidates/merge does it
+CREATE TEMPORARY TABLE candidate_duplicate ON COMMIT DROP AS
+SELECT id AS source_id,
+       min(id) OVER (PARTITION BY telegram_id) AS target_id
+  FROM candidate
+ WHERE telegram_id IS NOT NULL;
+
+DELETE FROM candidate_duplicate
+ WHERE source_id = target_id;
+
+-- resumes of the collapsed candidates stay in the object storage under their ids, the candidate service
+-- copies the ones of moved screenings to the target on start and deletes the rest, see MoveMergedFiles
+CREATE TABLE candidate_merged_files
+(
+    source_id   BIGINT PRIMARY KEY,
+    target_id   BIGINT REFERENCES candidate (id) ON DELETE SET NULL,
+    vacancy_ids UUID[] NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+INSERT INTO candidate_merged_files (source_id, target_id)
+SELECT source_id, target_id
+  FROM candidate_duplicate;
+
+-- contacts of the collapsed candidates the target doesn't take, they go to the change history
+-- once it's created in 003
+CREATE TABLE candidate_merged_contact
+(
+    target_id  BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    field      TEXT,
+    value      TEXT,
+    kept_value TEXT
+);
+
+UPDATE candidate_vacancy_meta m
+   SET candidate_id = d.target_id
+  FROM candidate_duplicate d
+ WHERE m.candidate_id = d.source_id
+   AND NOT EXISTS (SELECT 1 FROM candidate_vacancy_meta t WHERE t.candidate_id = d.target_id AND t.vacancy_id = m.vacancy_id)
+   AND m.candidate_id = (SELECT min(s.candidate_id)
+                           FROM candidate_vacancy_meta s
+                           JOIN candidate_duplicate sd ON sd.source_id = s.candidate_id
+                          WHERE sd.target_id = d.target_id
+                            AND s.vacancy_id = m.vacancy_id);
+
+WITH moved AS (
+    UPDATE resume_screening r
+       SET candidate_id = d.target_id
+      FROM candidate_duplicate d
+     WHERE r.candidate_id = d.source_id
+       AND NOT EXISTS (SELECT 1 FROM resume_screening t WHERE t.candidate_id = d.target_id AND t.vacancy_id = r.vacancy_id)
+       AND r.id = (SELECT min(s.id)
+                     FROM resume_screening s
+                     JOIN candidate_duplicate sd ON sd.source_id = s.candidate_id
+                    WHERE sd.target_id = d.target_id
+                      AND s.vacancy_id = r.vacancy_id)
+ RETURNING d.source_id, r.vacancy_id
+)
+UPDATE candidate_merged_files f
+   SET vacancy_ids = m.vacancy_ids
+  FROM (SELECT source_id, array_agg(vacancy_id) AS vacancy_ids FROM moved GROUP BY source_id) m
+ WHERE f.source_id = m.source_id;
+
+UPDATE answer a
+   SET candidate_id = d.target_id
+  FROM candidate_duplicate d
+ WHERE a.candidate_id = d.source_id
+   AND NOT EXISTS (SELECT 1 FROM answer t WHERE t.candidate_id = d.target_id AND t.question_id = a.question_id)
+   AND a.id = (SELECT min(s.id)
+                 FROM answer s
+                 JOIN candidate_duplicate sd ON sd.source_id = s.candidate_id
+                WHERE sd.target_id = d.target_id
+                  AND s.question_id = a.question_id);
+
+UPDATE candidate c SET
+telegram_username = COALESCE(NULLIF(c.telegram_username, ''), s.telegram_username),
+full_name         = COALESCE(NULLIF(c.full_name, ''), s.full_name),
+phone             = COALESCE(NULLIF(c.phone, ''), s.phone),
+city              = COALESCE(NULLIF(c.city, ''), s.city)
+  FROM (SELECT d.target_id,
+               (array_agg(src.telegram_username ORDER BY src.id DESC) FILTER (WHERE src.telegram_username <> ''))[1] AS telegram_username,
+               (array_agg(src.full_name ORDER BY src.id DESC) FILTER (WHERE src.full_name <> ''))[1]                 AS full_name,
+               (array_agg(src.phone ORDER BY src.id DESC) FILTER (WHERE src.phone <> ''))[1]                         AS phone,
+               (array_agg(src.city ORDER BY src.id DESC) FILTER (WHERE src.city <> ''))[1]                           AS city
+          FROM candidate_duplicate d
+          JOIN candidate src ON src.id = d.source_id
+      GROUP BY d.target_id) s
+ WHERE c.id = s.target_id;
+
+INSERT INTO candidate_merged_contact (target_id, field, value, kept_value)
+SELECT d.target_id, f.field, f.value, f.kept_value
+  FROM candidate_duplicate d
+  JOIN candidate src ON src.id = d.source_id
+  JOIN candidate t ON t.id = d.target_id
+ CROSS JOIN LATERAL (VALUES ('telegram_username', src.telegram_username, t.telegram_username),
+                            ('full_name', src.full_name, t.full_name),
+                            ('phone', src.phone, t.phone),
+                            ('city', src.city, t.city)) f (field, value, kept_value)
+ WHERE f.value <> ''
+   AND f.value IS DISTINCT FROM f.kept_value;
+
+DELETE FROM candidate
+ WHERE id IN (SELECT source_id FROM candidate_duplicate);
+
+DROP INDEX IF EXISTS candidate_telegram_id_idx;
+CREATE UNIQUE INDEX candidate_telegram_id_unique_idx ON candidate (telegram_id) WHERE telegram_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS candidate_merged_contact;
+DROP TABLE IF EXISTS candidate_merged_files;
+
+DROP INDEX IF EXISTS candidate_telegram_id_unique_idx;
+CREATE INDEX candidate_telegram_id_idx ON candidate (telegram_id);
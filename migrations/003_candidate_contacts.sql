@@ -0,0 +1,32 @@
+-- +goose Up
+
+ALTER TABLE candidate
+    ADD COLUMN email             TEXT NOT NULL DEFAULT '',
+    ADD COLUMN preferred_contact TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE candidate_change
+(
+    id           SERIAL PRIMARY KEY,
+    candidate_id BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    field        TEXT,
+    old_value    TEXT,
+    new_value    TEXT,
+    source       TEXT,
+    created_at   TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE INDEX candidate_change_candidate_id_idx ON candidate_change (candidate_id);
+
+-- contacts of candidates collapsed in 002 are kept in the history of the candidate they were collapsed into
+INSERT INTO candidate_change (candidate_id, field, old_value, new_value, source)
+SELECT target_id, field, value, kept_value, 'migration'
+  FROM candidate_merged_contact;
+
+DROP TABLE candidate_merged_contact;
+
+-- +goose Down
+DROP TABLE IF EXISTS candidate_change;
+
+ALTER TABLE candidate
+    DROP COLUMN IF EXISTS preferred_contact,
+    DROP COLUMN IF EXISTS email;
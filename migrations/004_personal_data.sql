@@ -0,0 +1,22 @@
+-- +goose Up
+
+ALTER TABLE candidate
+    ADD COLUMN erased_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE personal_data_request
+(
+    id           SERIAL PRIMARY KEY,
+    candidate_id BIGINT,
+    type         TEXT,
+    reason       TEXT,
+    created_at   TIMESTAMP WITH TIME ZONE default now(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX personal_data_request_candidate_id_idx ON personal_data_request (candidate_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS personal_data_request;
+
+ALTER TABLE candidate
+    DROP COLUMN IF EXISTS erased_at;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+ALTER TABLE candidate_vacancy_meta
+    ADD COLUMN resume_deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN anonymized_at     TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE candidate_vacancy_meta
+    DROP COLUMN IF EXISTS anonymized_at,
+    DROP COLUMN IF EXISTS resume_deleted_at;
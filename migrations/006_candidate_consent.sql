@@ -0,0 +1,17 @@
+-- +goose Up
+
+CREATE TABLE candidate_consent
+(
+    id             SERIAL PRIMARY KEY,
+    candidate_id   BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    type           TEXT,
+    policy_version TEXT,
+    channel        TEXT,
+    granted_at     TIMESTAMP WITH TIME ZONE default now(),
+    withdrawn_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX candidate_consent_candidate_id_idx ON candidate_consent (candidate_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS candidate_consent;
@@ -0,0 +1,43 @@
+-- +goose Up
+
+CREATE TABLE audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    actor       TEXT,
+    action      TEXT,
+    target_type TEXT,
+    target_id   TEXT,
+    before      JSONB,
+    after       JSONB,
+    status_code SMALLINT,
+    method      TEXT,
+    path        TEXT,
+    remote_addr TEXT,
+    user_agent  TEXT,
+    request_id  TEXT,
+    created_at  TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_actor_idx ON audit_log (actor);
+CREATE INDEX audit_log_target_idx ON audit_log (target_type, target_id);
+
+-- +goose StatementBegin
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_log_append_only_trigger
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS audit_log_append_only_trigger ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,34 @@
+-- +goose Up
+
+CREATE TABLE webhook_subscription
+(
+    id          SERIAL PRIMARY KEY,
+    url         TEXT,
+    secret      TEXT,
+    event_types TEXT[],
+    is_active   BOOLEAN DEFAULT true,
+    created_at  TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE TABLE webhook_delivery
+(
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  BIGINT REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+    event_id         UUID,
+    event_type       TEXT,
+    payload          JSONB,
+    status           TEXT,
+    attempts         SMALLINT DEFAULT 0,
+    next_attempt_at  TIMESTAMP WITH TIME ZONE default now(),
+    last_status_code SMALLINT,
+    last_error       TEXT DEFAULT '',
+    created_at       TIMESTAMP WITH TIME ZONE default now(),
+    delivered_at     TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX webhook_delivery_subscription_id_idx ON webhook_delivery (subscription_id);
+CREATE INDEX webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_subscription;
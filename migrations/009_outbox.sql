@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    event_id        UUID UNIQUE,
+    event_type      TEXT,
+    occurred_at     TIMESTAMP WITH TIME ZONE,
+    data            JSONB,
+    published_sinks TEXT[] DEFAULT '{}',
+    attempts        INTEGER DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE default now(),
+    last_error      TEXT DEFAULT '',
+    created_at      TIMESTAMP WITH TIME ZONE default now(),
+    published_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (next_attempt_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE telegram_notification
+(
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     UUID,
+    candidate_id BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    vacancy_id   UUID,
+    telegram_id  BIGINT,
+    status       TEXT,
+    text         TEXT,
+    error        TEXT DEFAULT '',
+    created_at   TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE INDEX telegram_notification_candidate_id_idx ON telegram_notification (candidate_id);
+-- an event is sent at most once even though the outbox relay may deliver it again
+CREATE UNIQUE INDEX telegram_notification_sent_event_id_idx ON telegram_notification (event_id) WHERE error = '';
+
+-- +goose Down
+DROP TABLE IF EXISTS telegram_notification;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE recruiter_subscription
+(
+    email           TEXT PRIMARY KEY,
+    vacancy_ids     UUID[] DEFAULT '{}', -- empty means all vacancies
+    daily_digest    BOOLEAN DEFAULT true,
+    instant_alerts  BOOLEAN DEFAULT true,
+    alert_min_score INTEGER DEFAULT 90,
+    last_digest_at  TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE default now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS recruiter_subscription;
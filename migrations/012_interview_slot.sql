@@ -0,0 +1,22 @@
+-- +goose Up
+
+CREATE TABLE interview_slot
+(
+    id                SERIAL PRIMARY KEY,
+    recruiter_email   TEXT,
+    vacancy_id        UUID REFERENCES vacancy (id) ON DELETE CASCADE, -- NULL means any vacancy
+    starts_at         TIMESTAMP WITH TIME ZONE,
+    ends_at           TIMESTAMP WITH TIME ZONE,
+    location          TEXT DEFAULT '',
+    candidate_id      BIGINT REFERENCES candidate (id) ON DELETE SET NULL,
+    booked_vacancy_id UUID REFERENCES vacancy (id) ON DELETE SET NULL,
+    booked_at         TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE INDEX interview_slot_recruiter_email_starts_at_idx ON interview_slot (recruiter_email, starts_at);
+CREATE INDEX interview_slot_candidate_id_idx ON interview_slot (candidate_id);
+CREATE INDEX interview_slot_free_starts_at_idx ON interview_slot (starts_at) WHERE candidate_id IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS interview_slot;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+CREATE TABLE candidate_summary
+(
+    candidate_id   BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    vacancy_id     UUID REFERENCES vacancy (id) ON DELETE CASCADE,
+    strengths      TEXT[],
+    weaknesses     TEXT[],
+    red_flags      TEXT[],
+    recommendation TEXT,
+    rationale      TEXT,
+    created_at     TIMESTAMP WITH TIME ZONE default now(),
+    updated_at     TIMESTAMP WITH TIME ZONE default now(),
+    PRIMARY KEY (candidate_id, vacancy_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS candidate_summary;
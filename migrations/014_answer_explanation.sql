@@ -0,0 +1,10 @@
+-- +goose Up
+
+ALTER TABLE answer
+    ADD COLUMN explanation    TEXT DEFAULT '',
+    ADD COLUMN missing_points TEXT[] DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE answer
+    DROP COLUMN IF EXISTS explanation,
+    DROP COLUMN IF EXISTS missing_points;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- rubric is a list of expected points with weights: [{"point": "...", "weight": 2}]
+ALTER TABLE question
+    ADD COLUMN rubric JSONB DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE question
+    DROP COLUMN IF EXISTS rubric;
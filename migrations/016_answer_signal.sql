@@ -0,0 +1,26 @@
+-- +goose Up
+
+ALTER TABLE answer
+    ADD COLUMN voice BOOLEAN DEFAULT false;
+
+CREATE TABLE answer_signal
+(
+    answer_id            BIGINT PRIMARY KEY REFERENCES answer (id) ON DELETE CASCADE,
+    chars_per_minute     DOUBLE PRECISION,
+    peer_similarity      DOUBLE PRECISION,
+    similar_answer_id    BIGINT REFERENCES answer (id) ON DELETE SET NULL,
+    reference_similarity DOUBLE PRECISION,
+    -- ai_likelihood is NULL when the candidate hasn't consented to ai evaluation
+    ai_likelihood        DOUBLE PRECISION,
+    ai_reason            TEXT,
+    flags                TEXT[],
+    risk                 TEXT,
+    created_at           TIMESTAMP WITH TIME ZONE default now(),
+    updated_at           TIMESTAMP WITH TIME ZONE default now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS answer_signal;
+
+ALTER TABLE answer
+    DROP COLUMN IF EXISTS voice;
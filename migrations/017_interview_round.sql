@@ -0,0 +1,58 @@
+-- +goose Up
+
+CREATE TABLE interview_round
+(
+    vacancy_id UUID REFERENCES vacancy (id) ON DELETE CASCADE,
+    number     SMALLINT,
+    title      TEXT DEFAULT '',
+    threshold  SMALLINT,
+    PRIMARY KEY (vacancy_id, number)
+);
+
+-- existing vacancies become single-round ones with the former pass score,
+-- 75 is minInterviewScore from internal/service/vacancy/service.go
+INSERT INTO interview_round (vacancy_id, number, threshold)
+SELECT id, 1, 75
+  FROM vacancy;
+
+ALTER TABLE question
+    ADD COLUMN round SMALLINT DEFAULT 1;
+
+ALTER TABLE candidate_vacancy_meta
+    ADD COLUMN current_round SMALLINT DEFAULT 1;
+
+CREATE TABLE interview_round_result
+(
+    candidate_id BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    vacancy_id   UUID,
+    round        SMALLINT,
+    score        SMALLINT,
+    passed       BOOLEAN,
+    created_at   TIMESTAMP WITH TIME ZONE default now(),
+    PRIMARY KEY (candidate_id, vacancy_id, round),
+    FOREIGN KEY (vacancy_id, round) REFERENCES interview_round (vacancy_id, number) ON DELETE CASCADE
+);
+
+-- already interviewed candidates get the result of the single round from their interview score and status
+INSERT INTO interview_round_result (candidate_id, vacancy_id, round, score, passed, created_at)
+SELECT m.candidate_id,
+       m.vacancy_id,
+       1,
+       m.interview_score,
+       m.status IN ('interview_ok', 'interview_scheduled'),
+       COALESCE(m.updated_at, now())
+  FROM candidate_vacancy_meta m
+  JOIN interview_round r ON r.vacancy_id = m.vacancy_id AND r.number = 1
+ WHERE m.interview_score IS NOT NULL
+   AND m.status IN ('interview_ok', 'interview_failed', 'interview_scheduled');
+
+-- +goose Down
+DROP TABLE IF EXISTS interview_round_result;
+
+ALTER TABLE candidate_vacancy_meta
+    DROP COLUMN IF EXISTS current_round;
+
+ALTER TABLE question
+    DROP COLUMN IF EXISTS round;
+
+DROP TABLE IF EXISTS interview_round;
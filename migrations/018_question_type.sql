@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- options are choice question options: [{"text": "...", "correct": true}],
+-- test_cases are code question cases: [{"input": "...", "expected_output": "..."}]
+ALTER TABLE question
+    ADD COLUMN type              TEXT DEFAULT 'text',
+    ADD COLUMN options           JSONB DEFAULT '[]',
+    ADD COLUMN numeric_answer    DOUBLE PRECISION,
+    ADD COLUMN numeric_tolerance DOUBLE PRECISION DEFAULT 0,
+    ADD COLUMN code_language     TEXT DEFAULT '',
+    ADD COLUMN test_cases        JSONB DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE question
+    DROP COLUMN IF EXISTS type,
+    DROP COLUMN IF EXISTS options,
+    DROP COLUMN IF EXISTS numeric_answer,
+    DROP COLUMN IF EXISTS numeric_tolerance,
+    DROP COLUMN IF EXISTS code_language,
+    DROP COLUMN IF EXISTS test_cases;
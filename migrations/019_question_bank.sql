@@ -0,0 +1,53 @@
+-- +goose Up
+
+-- tags are "kind:value" pairs, e.g. "skill:react", "seniority:middle", "language:ru"
+CREATE TABLE bank_question
+(
+    id                BIGSERIAL PRIMARY KEY,
+    type              TEXT DEFAULT 'text',
+    content           TEXT,
+    reference         TEXT DEFAULT '',
+    time_limit        SMALLINT,
+    rubric            JSONB DEFAULT '[]',
+    options           JSONB DEFAULT '[]',
+    numeric_answer    DOUBLE PRECISION,
+    numeric_tolerance DOUBLE PRECISION DEFAULT 0,
+    code_language     TEXT DEFAULT '',
+    test_cases        JSONB DEFAULT '[]',
+    tags              TEXT[] DEFAULT '{}',
+    created_at        TIMESTAMP WITH TIME ZONE default now()
+);
+
+CREATE INDEX bank_question_tags_idx ON bank_question USING GIN (tags);
+
+-- questions copied from the bank keep a link to it, candidate_id is set for questions drawn for a single candidate
+ALTER TABLE question
+    ADD COLUMN bank_question_id BIGINT REFERENCES bank_question (id) ON DELETE SET NULL,
+    ADD COLUMN candidate_id     BIGINT REFERENCES candidate (id) ON DELETE CASCADE;
+
+-- bank_draws are the rules of drawing bank questions for each candidate: [{"tag": "skill:react", "count": 2}]
+ALTER TABLE interview_round
+    ADD COLUMN bank_draws JSONB DEFAULT '[]';
+
+-- marks the rounds the candidate already got drawn questions for
+CREATE TABLE bank_question_draw
+(
+    candidate_id BIGINT REFERENCES candidate (id) ON DELETE CASCADE,
+    vacancy_id   UUID,
+    round        SMALLINT,
+    created_at   TIMESTAMP WITH TIME ZONE default now(),
+    PRIMARY KEY (candidate_id, vacancy_id, round),
+    FOREIGN KEY (vacancy_id, round) REFERENCES interview_round (vacancy_id, number) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS bank_question_draw;
+
+ALTER TABLE interview_round
+    DROP COLUMN IF EXISTS bank_draws;
+
+ALTER TABLE question
+    DROP COLUMN IF EXISTS bank_question_id,
+    DROP COLUMN IF EXISTS candidate_id;
+
+DROP TABLE IF EXISTS bank_question;
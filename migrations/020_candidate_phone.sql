@@ -0,0 +1,53 @@
+-- +goose Up
+
+-- brings phones saved before the normalization to E.164 the same way the service does it; phones that can't be
+-- normalized and phones of later duplicates are cleared, the old values stay in candidate_change and
+-- /api/v1/candidates/duplicates still reports the duplicates by them, so that they can be merged
+CREATE TEMPORARY TABLE candidate_phone ON COMMIT DROP AS
+SELECT id,
+       phone AS old_phone,
+       CASE
+           WHEN btrim(phone) !~ '^[0-9+().[:space:]-]+$' THEN NULL
+           WHEN btrim(phone) LIKE '+%' THEN digits
+           WHEN length(digits) = 11 AND digits LIKE '8%' THEN '7' || substr(digits, 2)
+           WHEN length(digits) = 10 AND digits LIKE '9%' THEN '7' || digits
+           WHEN length(digits) = 11 AND digits LIKE '7%' THEN digits
+       END AS digits
+  FROM (SELECT id, phone, regexp_replace(phone, '[^0-9]', '', 'g') AS digits
+          FROM candidate
+         WHERE phone <> '') c;
+
+ALTER TABLE candidate_phone
+    ADD COLUMN new_phone TEXT NOT NULL DEFAULT '';
+
+UPDATE candidate_phone
+   SET new_phone = '+' || digits
+ WHERE length(digits) BETWEEN 8 AND 15
+   AND digits NOT LIKE '0%';
+
+UPDATE candidate_phone p
+   SET new_phone = ''
+ WHERE new_phone <> ''
+   AND EXISTS (SELECT 1 FROM candidate_phone o WHERE o.new_phone = p.new_phone AND o.id < p.id);
+
+INSERT INTO candidate_change (candidate_id, field, old_value, new_value, source)
+SELECT id, 'phone', old_phone, new_phone, 'migration'
+  FROM candidate_phone
+ WHERE old_phone <> new_phone;
+
+UPDATE candidate c
+   SET phone = p.new_phone
+  FROM candidate_phone p
+ WHERE c.id = p.id
+   AND p.old_phone <> p.new_phone;
+
+ALTER TABLE candidate
+    ADD CONSTRAINT candidate_phone_e164_check CHECK (phone = '' OR phone ~ '^\+[1-9][0-9]{7,14}$');
+
+CREATE UNIQUE INDEX candidate_phone_unique_idx ON candidate (phone) WHERE phone <> '';
+
+-- +goose Down
+DROP INDEX IF EXISTS candidate_phone_unique_idx;
+
+ALTER TABLE candidate
+    DROP CONSTRAINT IF EXISTS candidate_phone_e164_check;
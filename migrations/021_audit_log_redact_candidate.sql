@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- candidate updates and deletions used to be logged with full profiles,
+-- they are reduced to the candidate id and the names of the changed fields
+ALTER TABLE audit_log
+    DISABLE TRIGGER audit_log_append_only_trigger;
+
+UPDATE audit_log
+   SET before = NULL,
+       after  = jsonb_strip_nulls(jsonb_build_object(
+               'id', (after ->> 'id')::BIGINT,
+               'changed_fields', (SELECT jsonb_agg(field)
+                                    FROM unnest(ARRAY ['telegram_username', 'full_name', 'phone', 'city', 'email', 'preferred_contact']) AS field
+                                   WHERE before ->> field IS DISTINCT FROM after ->> field)
+                        ))
+ WHERE action = 'candidate.update'
+   AND after IS NOT NULL;
+
+UPDATE audit_log
+   SET before = jsonb_build_object('id', (before ->> 'id')::BIGINT)
+ WHERE action = 'candidate.delete'
+   AND before IS NOT NULL;
+
+ALTER TABLE audit_log
+    ENABLE TRIGGER audit_log_append_only_trigger;
+
+-- +goose Down
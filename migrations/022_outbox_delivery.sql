@@ -0,0 +1,63 @@
+-- +goose Up
+
+-- every sink gets its own delivery of a message, so a slow or failing sink doesn't hold up the others
+CREATE TABLE outbox_delivery
+(
+    message_id      BIGINT REFERENCES outbox (id) ON DELETE CASCADE,
+    sink            TEXT,
+    attempts        INTEGER DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE default now(),
+    last_error      TEXT DEFAULT '',
+    published_at    TIMESTAMP WITH TIME ZONE,
+    dead_at         TIMESTAMP WITH TIME ZONE, -- attempts are exhausted
+    PRIMARY KEY (message_id, sink)
+);
+
+CREATE INDEX outbox_delivery_due_idx ON outbox_delivery (sink, next_attempt_at) WHERE published_at IS NULL AND dead_at IS NULL;
+
+-- a message is dispatched once its deliveries are created; published_sinks of unpublished messages are kept
+-- to skip the sinks that already got them
+ALTER TABLE outbox
+    ADD COLUMN dispatched_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE outbox
+   SET dispatched_at = published_at
+ WHERE published_at IS NOT NULL;
+
+DROP INDEX IF EXISTS outbox_unpublished_idx;
+CREATE INDEX outbox_undispatched_idx ON outbox (id) WHERE dispatched_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS published_at;
+
+-- +goose Down
+ALTER TABLE outbox
+    ADD COLUMN attempts        INTEGER DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE default now(),
+    ADD COLUMN last_error      TEXT DEFAULT '',
+    ADD COLUMN published_at    TIMESTAMP WITH TIME ZONE;
+
+UPDATE outbox o
+   SET published_at = o.dispatched_at
+ WHERE o.dispatched_at IS NOT NULL
+   AND NOT EXISTS (SELECT 1 FROM outbox_delivery d WHERE d.message_id = o.id AND d.published_at IS NULL);
+
+UPDATE outbox o
+   SET published_sinks = (SELECT array_agg(d.sink) FROM outbox_delivery d WHERE d.message_id = o.id AND d.published_at IS NOT NULL)
+ WHERE o.published_at IS NULL
+   AND o.dispatched_at IS NOT NULL;
+
+UPDATE outbox
+   SET published_sinks = '{}'
+ WHERE published_sinks IS NULL;
+
+DROP INDEX IF EXISTS outbox_undispatched_idx;
+CREATE INDEX outbox_unpublished_idx ON outbox (next_attempt_at) WHERE published_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS dispatched_at;
+
+DROP TABLE IF EXISTS outbox_delivery;
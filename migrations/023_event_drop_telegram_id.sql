@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- candidate.created events used to carry the telegram id of the candidate,
+-- it stayed in the outbox and in the webhook delivery log after the erasure
+UPDATE outbox
+   SET data = data - 'telegram_id'
+ WHERE event_type = 'candidate.created'
+   AND data ? 'telegram_id';
+
+UPDATE webhook_delivery
+   SET payload = jsonb_set(payload, '{data}', (payload -> 'data') - 'telegram_id')
+ WHERE event_type = 'candidate.created'
+   AND payload -> 'data' ? 'telegram_id';
+
+-- +goose Down
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- interview_score used to be set after every passed round, it is kept only for completed interviews,
+-- the candidates in the middle of one still have the screening status
+UPDATE candidate_vacancy_meta
+   SET interview_score = NULL
+ WHERE status = 'screening_ok'
+   AND interview_score IS NOT NULL;
+
+-- +goose Down
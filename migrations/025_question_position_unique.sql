@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- concurrent bank draws could give questions of a vacancy the same position,
+-- the later ones are moved after the last question
+WITH duplicate AS (
+    SELECT id,
+           vacancy_id,
+           row_number() OVER (PARTITION BY vacancy_id, position ORDER BY id) AS n
+      FROM question
+),
+moved AS (
+    SELECT d.id,
+           (SELECT MAX(q.position) FROM question q WHERE q.vacancy_id = d.vacancy_id)
+               + row_number() OVER (PARTITION BY d.vacancy_id ORDER BY d.id) AS position
+      FROM duplicate d
+     WHERE d.n > 1
+)
+UPDATE question q
+   SET position = m.position
+  FROM moved m
+ WHERE q.id = m.id;
+
+CREATE UNIQUE INDEX question_vacancy_id_position_unique_idx ON question (vacancy_id, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS question_vacancy_id_position_unique_idx;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- an event republished by the outbox must not be delivered to a subscription twice,
+-- manual redeliveries are told apart by the delivery they copy
+ALTER TABLE webhook_delivery
+    ADD COLUMN redelivery_of BIGINT REFERENCES webhook_delivery (id) ON DELETE SET NULL;
+
+-- redeliveries made before the column can't be told from duplicates, both become redeliveries of the first delivery
+UPDATE webhook_delivery d
+   SET redelivery_of = f.id
+  FROM (SELECT DISTINCT ON (subscription_id, event_id) id, subscription_id, event_id
+          FROM webhook_delivery
+      ORDER BY subscription_id, event_id, id) f
+ WHERE d.subscription_id = f.subscription_id
+   AND d.event_id = f.event_id
+   AND d.id <> f.id;
+
+CREATE UNIQUE INDEX webhook_delivery_subscription_id_event_id_unique_idx
+    ON webhook_delivery (subscription_id, event_id) WHERE redelivery_of IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS webhook_delivery_subscription_id_event_id_unique_idx;
+ALTER TABLE webhook_delivery
+    DROP COLUMN IF EXISTS redelivery_of;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- an alert is sent to a recipient at most once even though the outbox relay may deliver the event again
+CREATE TABLE recruiter_alert
+(
+    event_id   UUID,
+    email      TEXT,
+    created_at TIMESTAMP WITH TIME ZONE default now(),
+    PRIMARY KEY (event_id, email)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS recruiter_alert;